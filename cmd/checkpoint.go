@@ -0,0 +1,123 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/watcheth/watcheth/internal/config"
+	"github.com/watcheth/watcheth/internal/consensus"
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+var checkpointCmd = &cobra.Command{
+	Use:   "checkpoint",
+	Short: "Compare finalized checkpoints with checkpoint sync providers",
+	Long: `Compare the finalized checkpoint of each configured consensus client with those of the
+configured checkpoint sync providers. Exits with status 1 if any client has finalized a
+different chain from a provider.`,
+	Run: runCheckpoint,
+}
+
+func init() {
+	rootCmd.AddCommand(checkpointCmd)
+}
+
+// checkpointNode is a consensus client with its finalized checkpoint
+type checkpointNode struct {
+	name       string
+	client     *consensus.ConsensusClient
+	checkpoint consensus.Checkpoint
+}
+
+func runCheckpoint(cmd *cobra.Command, args []string) {
+	logger.SetDebugMode(IsDebugMode())
+
+	var cfg config.Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		fmt.Printf("Error parsing config: %v\n", err)
+		os.Exit(1)
+	}
+	if len(cfg.CheckpointProviders) == 0 {
+		fmt.Printf("No checkpoint providers configured. Please add checkpoint_providers to your watcheth.yml\n")
+		os.Exit(1)
+	}
+
+	var nodes []checkpointNode
+	for _, clientCfg := range cfg.Clients {
+		if !clientCfg.IsConsensus() {
+			continue
+		}
+		client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		checkpoint, err := client.GetFinalizedCheckpoint(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", clientCfg.Name, err)
+			continue
+		}
+		nodes = append(nodes, checkpointNode{name: clientCfg.Name, client: client, checkpoint: *checkpoint})
+	}
+
+	if !printCheckpointComparison(cfg.CheckpointProviders, nodes) {
+		os.Exit(1)
+	}
+}
+
+// printCheckpointComparison compares the finalized checkpoint of each node
+// with those of the checkpoint sync providers, and returns false if any node
+// disagrees with a provider
+func printCheckpointComparison(providers []config.CheckpointProvider, nodes []checkpointNode) bool {
+	if len(providers) == 0 {
+		return true
+	}
+
+	agrees := true
+	fmt.Printf("Checkpoint Providers:\n")
+	for _, provider := range providers {
+		source := consensus.NewConsensusClient(provider.Name, provider.Endpoint)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		expected, err := source.GetFinalizedCheckpoint(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", provider.Name, err)
+			continue
+		}
+		fmt.Printf("  %s: epoch %d (%s)\n", provider.Name, expected.Epoch, expected.Root)
+
+		for _, node := range nodes {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			status, err := consensus.CompareCheckpoint(ctx, node.client, node.checkpoint, *expected)
+			cancel()
+			switch {
+			case err != nil:
+				fmt.Printf("    ⚠️  %s: could not check: %v\n", node.name, err)
+			case status == consensus.CheckpointMatch:
+				fmt.Printf("    ✅ %s agrees (epoch %d)\n", node.name, node.checkpoint.Epoch)
+			case status == consensus.CheckpointMismatch:
+				agrees = false
+				fmt.Printf("    ❌ %s disagrees: epoch %d (%s)\n", node.name, node.checkpoint.Epoch, node.checkpoint.Root)
+			default:
+				fmt.Printf("    ⚠️  %s is behind at epoch %d, not checked\n", node.name, node.checkpoint.Epoch)
+			}
+		}
+	}
+	fmt.Println()
+	return agrees
+}
@@ -16,14 +16,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/watcheth/watcheth/internal/common"
 	"github.com/watcheth/watcheth/internal/config"
 	"github.com/watcheth/watcheth/internal/consensus"
 	"github.com/watcheth/watcheth/internal/execution"
 	"github.com/watcheth/watcheth/internal/logger"
+	"github.com/watcheth/watcheth/internal/monitor"
 	"github.com/watcheth/watcheth/internal/validator/vouch"
 )
 
@@ -86,8 +89,26 @@ func runList(cmd *cobra.Command, args []string) {
 	// Check consensus clients
 	if len(consensusClients) > 0 {
 		fmt.Printf("=== Consensus Clients (%d) ===\n\n", len(consensusClients))
+		var infos []*consensus.ConsensusNodeInfo
+		var nodes []checkpointNode
 		for _, clientCfg := range consensusClients {
-			checkConsensusClient(clientCfg)
+			if info := checkConsensusClient(clientCfg); info != nil {
+				infos = append(infos, info)
+				nodes = append(nodes, checkpointNode{
+					name:       clientCfg.Name,
+					client:     consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint),
+					checkpoint: consensus.Checkpoint{Epoch: info.FinalizedEpoch, Root: info.FinalizedRoot},
+				})
+			}
+		}
+		printNetworkComparison(infos)
+		printCheckpointComparison(cfg.CheckpointProviders, nodes)
+
+		if len(cfg.Validators) > 0 {
+			printValidatorBalances(consensusClients, cfg.Validators)
+			printAttestationEffectiveness(consensusClients, cfg.Validators)
+			printValidatorDuties(consensusClients, cfg.Validators)
+			printSyncCommittees(consensusClients, cfg.Validators)
 		}
 	}
 
@@ -108,9 +129,11 @@ func runList(cmd *cobra.Command, args []string) {
 	}
 }
 
-func checkConsensusClient(clientCfg config.ClientConfig) {
+// checkConsensusClient prints the state of the client, and returns its info
+// if it is connected
+func checkConsensusClient(clientCfg config.ClientConfig) *consensus.ConsensusNodeInfo {
 	fmt.Printf("Checking %s at %s...\n", clientCfg.Name, clientCfg.Endpoint)
-	client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint)
+	client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint, consensus.WithMetricsEndpoint(clientCfg.MetricsEndpoint))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	info, err := client.GetNodeInfo(ctx)
@@ -118,51 +141,337 @@ func checkConsensusClient(clientCfg config.ClientConfig) {
 
 	if err != nil {
 		fmt.Printf("  ❌ Error: %v\n\n", err)
-		return
+		return nil
 	}
 
 	if !info.IsConnected {
 		fmt.Printf("  ❌ Not connected: %v\n\n", info.LastError)
-		return
+		return nil
 	}
 
-	fmt.Printf("  ✅ Connected\n")
+	fmt.Printf("  %s Connected%s\n", statusSymbol(info.Sources), degradedSuffix(info.Sources))
+	printSources(info.Sources)
+	fmt.Printf("  Health: %s\n", info.Health)
 	if info.PeerCount > 0 {
 		fmt.Printf("  Peer Count: %d\n", info.PeerCount)
 	}
+	if peers := info.Peers; peers != nil {
+		fmt.Printf("  Peer States: %d connected, %d connecting, %d disconnecting, %d disconnected\n",
+			peers.Connected, peers.Connecting, peers.Disconnecting, peers.Disconnected)
+		if peers.HasDetails {
+			fmt.Printf("  Peer Directions: %d inbound, %d outbound\n", peers.Inbound, peers.Outbound)
+		}
+	}
+	if identity := info.Identity; identity != nil {
+		fmt.Printf("  Peer ID: %s\n", identity.PeerID)
+		fmt.Printf("  ENR: %s\n", identity.ENR)
+		for _, address := range identity.P2PAddresses {
+			fmt.Printf("  P2P Address: %s\n", address)
+		}
+		fmt.Printf("  Attestation Subnets: %d of 64 %v\n", len(identity.Attnets), identity.Attnets)
+		fmt.Printf("  Sync Committee Subnets: %d of 4 %v\n", len(identity.Syncnets), identity.Syncnets)
+		for _, address := range identity.PrivateAddresses {
+			fmt.Printf("  ⚠️  Advertising private address %s\n", address)
+		}
+	}
 	if info.NodeVersion != "" {
 		fmt.Printf("  Node Version: %s\n", info.NodeVersion)
 	}
+	if metrics := info.Metrics; metrics != nil {
+		// A single scrape has no gossip rate
+		fmt.Printf("  Metrics (%s): head delay %s, DB %d bytes, memory %d bytes\n",
+			metrics.Implementation, metrics.HeadDelay, metrics.DBSizeBytes, metrics.MemoryBytes)
+	}
+	if network := info.Network; network != nil {
+		fmt.Printf("  Genesis: %s (validators root %s)\n", network.GenesisTime.UTC().Format(time.RFC3339), network.GenesisValidatorsRoot)
+		fmt.Printf("  Deposit Contract: %s (chain %s)\n", network.DepositContract, network.DepositChainID)
+		fmt.Printf("  Spec Values: %d\n", len(network.Spec))
+	}
 	if info.CurrentFork != "" {
-		fmt.Printf("  Current Fork: %s\n", info.CurrentFork)
+		if info.CurrentForkName != "" {
+			fmt.Printf("  Current Fork: %s (%s)\n", info.CurrentForkName, info.CurrentFork)
+		} else {
+			fmt.Printf("  Current Fork: %s\n", info.CurrentFork)
+		}
+	}
+	if next := info.NextFork; next != nil {
+		fmt.Printf("  Next Fork: %s at epoch %d (%s)\n", next.DisplayName(), next.Epoch, next.Activation.UTC().Format(time.RFC3339))
+	} else if len(info.ForkSchedule) > 0 {
+		fmt.Printf("  Next Fork: none scheduled\n")
 	}
 	fmt.Printf("  Is Syncing: %v\n", info.IsSyncing)
 	fmt.Printf("  Is Optimistic: %v\n", info.IsOptimistic)
 	fmt.Printf("  EL Offline: %v\n", info.ElOffline)
 	fmt.Printf("  Current Slot: %d\n", info.CurrentSlot)
 	fmt.Printf("  Head Slot: %d\n", info.HeadSlot)
+	if info.HeadRoot != "" {
+		fmt.Printf("  Head Root: %s\n", info.HeadRoot)
+	}
 	fmt.Printf("  Sync Distance: %d\n", info.SyncDistance)
 	fmt.Printf("  Current Epoch: %d\n", info.CurrentEpoch)
 	fmt.Printf("  Finalized Epoch: %d\n", info.FinalizedEpoch)
+	if pool := info.Pool; pool != nil {
+		fmt.Printf("  Operation Pool: %d attestations, %d voluntary exits, %d attester slashings, %d proposer slashings, %d BLS changes\n",
+			pool.Attestations, pool.VoluntaryExits, pool.AttesterSlashings, pool.ProposerSlashings, pool.BLSToExecutionChanges)
+		if len(pool.Failed) > 0 {
+			fmt.Printf("  ⚠️  Pools not available: %s\n", strings.Join(pool.Failed, ", "))
+		}
+		for _, slashing := range pool.Slashings {
+			fmt.Printf("  ⚠️  Pending %s slashing for %v\n", slashing.Kind, slashing.Validators)
+		}
+	}
+	for _, block := range info.Blobs {
+		switch {
+		case block.Missing():
+			fmt.Printf("  ⚠️  Block at slot %d: %d of %d blobs available\n", block.Slot, block.Available, block.Expected)
+		case block.Known:
+			fmt.Printf("  Block at slot %d: %d blobs\n", block.Slot, block.Expected)
+		default:
+			fmt.Printf("  Block at slot %d: %d blobs, sidecars not served\n", block.Slot, block.Expected)
+		}
+	}
+	fmt.Printf("  Reorgs Seen: %d\n", info.ReorgCount)
+	if info.LastReorg != nil {
+		fmt.Printf("  Last Reorg: slot %d, depth %d (%s -> %s)\n", info.LastReorg.Slot, info.LastReorg.Depth,
+			consensus.ShortRoot(info.LastReorg.OldHeadRoot), consensus.ShortRoot(info.LastReorg.NewHeadRoot))
+	}
+	if missed, known := consensus.MissedSlots(info.SlotHistory); known > 0 {
+		fmt.Printf("  Missed Slots: %d of %d checked\n", missed, known)
+		for _, epoch := range info.SlotHistory {
+			for _, slot := range epoch.Slots {
+				if slot.Status != consensus.SlotMissed {
+					continue
+				}
+				if slot.Proposer != nil {
+					fmt.Printf("    Slot %d (epoch %d), proposer %d\n", slot.Slot, epoch.Epoch, slot.Proposer.ValidatorIndex)
+				} else {
+					fmt.Printf("    Slot %d (epoch %d)\n", slot.Slot, epoch.Epoch)
+				}
+			}
+		}
+	}
 	fmt.Printf("  Next Slot In: %s\n", formatDuration(info.TimeToNextSlot))
 	fmt.Printf("  Next Epoch In: %s\n\n", formatDuration(info.TimeToNextEpoch))
+	return info
+}
+
+// printNetworkComparison compares the genesis and spec of the connected
+// consensus clients and prints any that differ
+func printNetworkComparison(infos []*consensus.ConsensusNodeInfo) {
+	if len(infos) < 2 {
+		return
+	}
+
+	report := monitor.AnalyzeSpecs(infos)
+	fmt.Printf("Network Config:\n")
+	if len(report.OtherNetwork) == 0 && len(report.Differences) == 0 {
+		fmt.Printf("  ✅ All %d clients agree on genesis and spec\n\n", len(infos))
+		return
+	}
+	for _, info := range infos {
+		differences := report.OtherNetwork[info.Name]
+		if len(differences) > 0 {
+			fmt.Printf("  ❌ %s is on a different network\n", info.Name)
+		} else if differences = report.Differences[info.Name]; len(differences) > 0 {
+			fmt.Printf("  ⚠️  %s has a different spec\n", info.Name)
+		}
+		for _, difference := range differences {
+			fmt.Printf("    %s: %s, others have %s\n", difference.Key, difference.Value, difference.Expected)
+		}
+	}
+	fmt.Println()
+}
+
+// printValidatorBalances prints the balance and status of the configured
+// validators, from the first consensus client that has them
+func printValidatorBalances(clientCfgs []config.ClientConfig, validators []string) {
+	fmt.Printf("=== Validator Balances (%d validators) ===\n\n", len(validators))
+
+	for _, clientCfg := range clientCfgs {
+		client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint, consensus.WithValidators(validators))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		balances, err := client.GetValidatorBalances(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", clientCfg.Name, err)
+			continue
+		}
+
+		fmt.Printf("  From %s, epoch %d\n", clientCfg.Name, balances.Epoch)
+		for _, validator := range balances.Validators {
+			slashed := ""
+			if validator.Slashed {
+				slashed = " ⚠️ slashed"
+			}
+			fmt.Printf("    %d: %.9f ETH (effective %.0f ETH), %s%s\n", validator.Index,
+				float64(validator.Balance)/1e9, float64(validator.EffectiveBalance)/1e9, validator.Status, slashed)
+		}
+		for _, id := range balances.Missing {
+			fmt.Printf("    ⚠️  Validator %s not found on node\n", id)
+		}
+		fmt.Printf("  Total: %.9f ETH\n\n", float64(balances.TotalBalance())/1e9)
+		return
+	}
+	fmt.Println()
+}
+
+// printAttestationEffectiveness prints how well the configured validators
+// attested in the last completed epoch, from the first consensus client that
+// has their rewards
+func printAttestationEffectiveness(clientCfgs []config.ClientConfig, validators []string) {
+	fmt.Printf("=== Attestation Effectiveness (%d validators) ===\n\n", len(validators))
+
+	for _, clientCfg := range clientCfgs {
+		client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint, consensus.WithValidators(validators))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		effectiveness, err := client.GetAttestationEffectiveness(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", clientCfg.Name, err)
+			continue
+		}
+
+		total := len(effectiveness.Validators)
+		fmt.Printf("  From %s, epoch %d\n", clientCfg.Name, effectiveness.Epoch)
+		fmt.Printf("    Head:   %d/%d correct\n", effectiveness.HeadCorrect, total)
+		fmt.Printf("    Target: %d/%d correct\n", effectiveness.TargetCorrect, total)
+		fmt.Printf("    Source: %d/%d correct\n", effectiveness.SourceCorrect, total)
+		fmt.Printf("    Reward: %.9f of %.9f ETH (%.1f%%)\n", float64(effectiveness.Reward)/1e9,
+			float64(effectiveness.IdealReward)/1e9, effectiveness.Effectiveness())
+		for _, performance := range effectiveness.Validators {
+			if !performance.Source || !performance.Target || !performance.Head {
+				fmt.Printf("    ⚠️  Validator %d: head %t, target %t, source %t\n", performance.ValidatorIndex,
+					performance.Head, performance.Target, performance.Source)
+			}
+		}
+		fmt.Println()
+		return
+	}
+	fmt.Println()
+}
+
+// printValidatorDuties prints the duty schedule of the configured validators
+// for the current and next epoch, from the first consensus client that has it
+func printValidatorDuties(clientCfgs []config.ClientConfig, validators []string) {
+	fmt.Printf("=== Validator Duties (%d validators) ===\n\n", len(validators))
+
+	for _, clientCfg := range clientCfgs {
+		client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint, consensus.WithValidators(validators))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		duties, err := client.GetDuties(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", clientCfg.Name, err)
+			continue
+		}
+
+		fmt.Printf("  From %s, current slot %d (epoch %d)\n", clientCfg.Name, duties.CurrentSlot, duties.CurrentEpoch)
+		for _, id := range duties.Unresolved {
+			fmt.Printf("  ⚠️  Validator %s not found on node\n", id)
+		}
+
+		for _, epoch := range []uint64{duties.CurrentEpoch, duties.CurrentEpoch + 1} {
+			fmt.Printf("\n  Epoch %d:\n", epoch)
+			scheduled := false
+			for _, duty := range duties.Proposals {
+				if duty.Slot/duties.SlotsPerEpoch == epoch {
+					fmt.Printf("    Slot %d: proposal by validator %d%s\n", duty.Slot, duty.ValidatorIndex, slotsAway(duties, duty.Slot))
+					scheduled = true
+				}
+			}
+			for _, duty := range duties.Attestations {
+				if duty.Slot/duties.SlotsPerEpoch == epoch {
+					fmt.Printf("    Slot %d: attestation by validator %d (committee %d)%s\n", duty.Slot, duty.ValidatorIndex, duty.CommitteeIndex, slotsAway(duties, duty.Slot))
+					scheduled = true
+				}
+			}
+			if !scheduled {
+				fmt.Printf("    No duties\n")
+			}
+		}
+		fmt.Println()
+		return
+	}
+	fmt.Println()
+}
+
+// printSyncCommittees prints the configured validators in the current and
+// next sync committee, from the first consensus client that has them
+func printSyncCommittees(clientCfgs []config.ClientConfig, validators []string) {
+	fmt.Printf("=== Sync Committees (%d validators) ===\n\n", len(validators))
+
+	for _, clientCfg := range clientCfgs {
+		client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint, consensus.WithValidators(validators))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		status, err := client.GetSyncCommittees(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", clientCfg.Name, err)
+			continue
+		}
+
+		fmt.Printf("  From %s, epoch %d\n", clientCfg.Name, status.CurrentEpoch)
+		if status.Current == nil && status.Next == nil {
+			fmt.Println("    No validators in the current or next sync committee")
+		}
+		if current := status.Current; current != nil {
+			fmt.Printf("    ⭐ Current period %d (until epoch %d):\n", current.Period, current.EndEpoch)
+			for _, member := range current.Members {
+				fmt.Printf("      %d: %d positions\n", member.ValidatorIndex, len(member.Positions))
+			}
+			if participated, expected := status.ParticipationRate(); expected > 0 {
+				fmt.Printf("    Participation: %d/%d over the last %d slots\n", participated, expected, len(status.Participation))
+			}
+		}
+		if next := status.Next; next != nil {
+			fmt.Printf("    Next period %d (from epoch %d):\n", next.Period, next.StartEpoch)
+			for _, member := range next.Members {
+				fmt.Printf("      %d: %d positions\n", member.ValidatorIndex, len(member.Positions))
+			}
+		}
+		fmt.Println()
+		return
+	}
+	fmt.Println()
+}
+
+// slotsAway describes how far a future slot is from the current one
+func slotsAway(duties *consensus.ValidatorDuties, slot uint64) string {
+	switch {
+	case slot < duties.CurrentSlot:
+		return " (done)"
+	case slot == duties.CurrentSlot:
+		return " (now)"
+	default:
+		return fmt.Sprintf(" (in %d slots)", slot-duties.CurrentSlot)
+	}
 }
 
 func checkExecutionClient(clientCfg config.ClientConfig) {
 	fmt.Printf("Checking %s at %s...\n", clientCfg.Name, clientCfg.Endpoint)
-	client := execution.NewClient(clientCfg.Name, clientCfg.Endpoint)
+	client := execution.NewClient(clientCfg.Name, clientCfg.Endpoint,
+		execution.WithEngineAPI(clientCfg.EngineEndpoint, clientCfg.JWTSecretPath))
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	info, err := client.GetNodeInfo(ctx)
 	cancel()
 
+	// The engine API is reported even when the RPC endpoint is down
 	if err != nil {
-		fmt.Printf("  ❌ Error: %v\n\n", err)
+		fmt.Printf("  ❌ Error: %v\n", err)
+		printEngine(info.Engine)
+		fmt.Println()
 		return
 	}
 
 	if !info.IsConnected {
-		fmt.Printf("  ❌ Not connected: %v\n\n", info.LastError)
+		fmt.Printf("  ❌ Not connected: %v\n", info.LastError)
+		printEngine(info.Engine)
+		fmt.Println()
 		return
 	}
 
@@ -171,7 +480,8 @@ func checkExecutionClient(clientCfg config.ClientConfig) {
 		status = fmt.Sprintf("Syncing (%.1f%%)", info.SyncProgress)
 	}
 
-	fmt.Printf("  ✅ Status: %s\n", status)
+	fmt.Printf("  %s Status: %s%s\n", statusSymbol(info.Sources), status, degradedSuffix(info.Sources))
+	printSources(info.Sources)
 	if info.PeerCount > 0 {
 		fmt.Printf("  Peer Count: %d\n", info.PeerCount)
 	}
@@ -196,9 +506,41 @@ func checkExecutionClient(clientCfg config.ClientConfig) {
 	if info.BlockTime > 0 {
 		fmt.Printf("  Time Since Last Block: %s\n", formatDuration(info.BlockTime))
 	}
+	printEngine(info.Engine)
 	fmt.Println()
 }
 
+// printEngine prints whether the engine API is reachable, authenticated and
+// capable, and what it supports
+func printEngine(engine *execution.EngineStatus) {
+	if engine == nil {
+		return
+	}
+
+	switch {
+	case engine.SecretError != nil:
+		fmt.Printf("  ⚠️  Engine API not checked, JWT secret unreadable: %v\n", engine.SecretError)
+		return
+	case !engine.Reachable:
+		fmt.Printf("  ❌ Engine API at %s: %v\n", engine.Endpoint, engine.Error)
+		return
+	case !engine.Authenticated:
+		fmt.Printf("  ❌ Engine API: authentication failed, check jwt_secret_path: %v\n", engine.Error)
+		return
+	case engine.Error != nil:
+		fmt.Printf("  ⚠️  Engine API: %v\n", engine.Error)
+		return
+	case !engine.Capable:
+		fmt.Printf("  ⚠️  Engine API: missing %s\n", strings.Join(engine.Missing, ", "))
+	default:
+		fmt.Printf("  ✅ Engine API: reachable, authenticated and capable\n")
+	}
+	for _, client := range engine.ClientVersions {
+		fmt.Printf("  Engine Client: %s %s (%s)\n", client.Name, client.Version, client.Commit)
+	}
+	fmt.Printf("  Engine Capabilities: %s\n", strings.Join(engine.Capabilities, ", "))
+}
+
 func checkValidatorClient(clientCfg config.ClientConfig) {
 	fmt.Printf("Checking %s at %s...\n", clientCfg.Name, clientCfg.Endpoint)
 
@@ -220,7 +562,8 @@ func checkValidatorClient(clientCfg config.ClientConfig) {
 			return
 		}
 
-		fmt.Printf("  ✅ Connected\n")
+		fmt.Printf("  %s Connected%s\n", statusSymbol(info.Sources), degradedSuffix(info.Sources))
+		printSources(info.Sources)
 		fmt.Printf("  Service Ready: %v\n", info.Ready)
 
 		// Attestation performance
@@ -274,6 +617,45 @@ func checkValidatorClient(clientCfg config.ClientConfig) {
 	}
 }
 
+// statusSymbol marks a connected node, warning if some of its sources failed.
+// Sources the node does not serve are not failures.
+func statusSymbol(sources common.Sources) string {
+	if sources.Degraded() {
+		return "⚠️ "
+	}
+	return "✅"
+}
+
+// degradedSuffix qualifies the status of a connected node whose sources
+// failed
+func degradedSuffix(sources common.Sources) string {
+	if sources.Degraded() {
+		return ", degraded"
+	}
+	return ""
+}
+
+// printSources lists the sources of a connected node that failed, and with
+// --verbose how long each took to answer
+func printSources(sources common.Sources) {
+	for _, name := range sources.Failed() {
+		if strings.HasPrefix(name, "engine_") {
+			// Reported by printEngine
+			continue
+		}
+		if sources[name].Unsupported {
+			fmt.Printf("  ⚠️  %s disabled on this node\n", name)
+			continue
+		}
+		fmt.Printf("  ⚠️  %s unknown: %v\n", name, sources.Err(name))
+	}
+	if verbose {
+		for _, name := range sources.Names() {
+			fmt.Printf("  Source %s: %s\n", name, sources[name].Latency.Round(time.Millisecond))
+		}
+	}
+}
+
 func formatDuration(duration time.Duration) string {
 	if duration < 0 {
 		return "0s"
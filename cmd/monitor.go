@@ -70,10 +70,14 @@ func runMonitor(cmd *cobra.Command, args []string) {
 	// Add clients based on their type
 	for _, clientCfg := range cfg.Clients {
 		if clientCfg.IsConsensus() {
-			client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint)
+			client := consensus.NewConsensusClient(clientCfg.Name, clientCfg.Endpoint,
+				consensus.WithEventStream(clientCfg.StreamEvents),
+				consensus.WithValidators(cfg.Validators),
+				consensus.WithMetricsEndpoint(clientCfg.MetricsEndpoint))
 			mon.AddConsensusClient(client)
 		} else if clientCfg.IsExecution() {
-			client := execution.NewClient(clientCfg.Name, clientCfg.Endpoint)
+			client := execution.NewClient(clientCfg.Name, clientCfg.Endpoint,
+				execution.WithEngineAPI(clientCfg.EngineEndpoint, clientCfg.JWTSecretPath))
 			mon.AddExecutionClient(client)
 		} else if clientCfg.IsValidator() {
 			// Special handling for different validator types
@@ -84,6 +88,10 @@ func runMonitor(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	for _, provider := range cfg.CheckpointProviders {
+		mon.AddCheckpointProvider(provider.Name, consensus.NewConsensusClient(provider.Name, provider.Endpoint))
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -36,3 +36,17 @@ func NewHTTPClient(timeout time.Duration) *http.Client {
 		},
 	}
 }
+
+// NewStreamingHTTPClient creates an HTTP client for long-lived streaming
+// responses such as server-sent events. It has no overall request timeout,
+// so callers must bound the request with a context.
+func NewStreamingHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			MaxIdleConns:          10,
+			MaxIdleConnsPerHost:   2,
+			IdleConnTimeout:       90 * time.Second,
+			ResponseHeaderTimeout: DefaultHTTPTimeout,
+		},
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// FetchMetrics scrapes a Prometheus metrics endpoint, appending /metrics to
+// the endpoint if it is not there already
+func FetchMetrics(ctx context.Context, client *http.Client, endpoint string) (map[string]*io_prometheus_client.MetricFamily, error) {
+	url := endpoint
+	if !strings.HasSuffix(endpoint, "/metrics") {
+		url = fmt.Sprintf("%s/metrics", endpoint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Debug("Failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d from metrics endpoint", resp.StatusCode)
+	}
+
+	return ParseMetrics(resp.Body)
+}
+
+// ParseMetrics parses metrics in the Prometheus text format
+func ParseMetrics(r io.Reader) (map[string]*io_prometheus_client.MetricFamily, error) {
+	parser := expfmt.TextParser{}
+	metricFamilies, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return metricFamilies, nil
+}
+
+// LabelValue returns the value of the named label, or an empty string if the
+// metric does not have it
+func LabelValue(labels []*io_prometheus_client.LabelPair, name string) string {
+	for _, label := range labels {
+		if label.Name != nil && *label.Name == name && label.Value != nil {
+			return *label.Value
+		}
+	}
+	return ""
+}
+
+// HistogramSumAndCount returns the sum and count of all histograms in the
+// family
+func HistogramSumAndCount(mf *io_prometheus_client.MetricFamily) (sum float64, count float64) {
+	if mf == nil || len(mf.Metric) == 0 {
+		return 0, 0
+	}
+
+	for _, m := range mf.Metric {
+		if m.Histogram != nil {
+			if m.Histogram.SampleSum != nil {
+				sum += *m.Histogram.SampleSum
+			}
+			if m.Histogram.SampleCount != nil {
+				count += float64(*m.Histogram.SampleCount)
+			}
+		}
+	}
+
+	return sum, count
+}
+
+// MetricValue returns the value of a gauge, counter or untyped metric
+func MetricValue(m *io_prometheus_client.Metric) (float64, bool) {
+	switch {
+	case m.Gauge != nil && m.Gauge.Value != nil:
+		return *m.Gauge.Value, true
+	case m.Counter != nil && m.Counter.Value != nil:
+		return *m.Counter.Value, true
+	case m.Untyped != nil && m.Untyped.Value != nil:
+		return *m.Untyped.Value, true
+	}
+	return 0, false
+}
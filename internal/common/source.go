@@ -0,0 +1,131 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SourceStatus is the outcome of the last query of one data source of a
+// node, such as an endpoint or RPC method
+type SourceStatus struct {
+	LastSuccess time.Time     // Zero if the source has never answered
+	Error       error         // Nil if the last query succeeded
+	Unsupported bool          // The error says the node does not serve the source at all
+	Latency     time.Duration // Of the last query
+}
+
+// UnsupportedError is implemented by errors that can tell a source the node
+// does not serve, e.g. a disabled RPC namespace, from one that failed
+type UnsupportedError interface {
+	error
+	Unsupported() bool
+}
+
+// Sources are the statuses of the data sources of a node, by name
+type Sources map[string]SourceStatus
+
+// Failed returns the names of the sources whose last query failed, sorted
+func (s Sources) Failed() []string {
+	var names []string
+	for name, status := range s {
+		if status.Error != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Degraded returns true if any source failed, i.e. the node is reachable
+// but some of what it reports is unknown. Sources the node does not serve are
+// not failures, as they will not recover.
+func (s Sources) Degraded() bool {
+	for _, status := range s {
+		if status.Error != nil && !status.Unsupported {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the error of the last query of a source, or nil if it
+// succeeded or was not queried
+func (s Sources) Err(name string) error {
+	return s[name].Error
+}
+
+// Names returns the names of all sources, sorted
+func (s Sources) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SourceTracker keeps the status of each data source of a node across
+// polls, so that when a source last answered survives it failing. It is
+// safe for concurrent use.
+type SourceTracker struct {
+	mu       sync.Mutex
+	statuses Sources
+}
+
+// Observe records the outcome of a query of a source that started at start
+func (t *SourceTracker) Observe(name string, start time.Time, err error) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.statuses == nil {
+		t.statuses = make(Sources)
+	}
+	status := t.statuses[name]
+	status.Error = err
+	var unsupported UnsupportedError
+	status.Unsupported = errors.As(err, &unsupported) && unsupported.Unsupported()
+	status.Latency = now.Sub(start)
+	if err == nil {
+		status.LastSuccess = now
+	}
+	t.statuses[name] = status
+}
+
+// Forget drops sources that are no longer queried, so that their last
+// outcome no longer counts
+func (t *SourceTracker) Forget(names ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, name := range names {
+		delete(t.statuses, name)
+	}
+}
+
+// Snapshot returns a copy of the status of each source seen so far
+func (t *SourceTracker) Snapshot() Sources {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	sources := make(Sources, len(t.statuses))
+	for name, status := range t.statuses {
+		sources[name] = status
+	}
+	return sources
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Largest message read from a WebSocket, well above any JSON-RPC response
+// watcheth asks for
+const maxWebSocketMessage = 16 * 1024 * 1024
+
+// How long a close message may take to send before the connection is closed
+// anyway
+const webSocketCloseTimeout = time.Second
+
+// WebSocketConn exchanges JSON-RPC messages with an execution client over a
+// WebSocket. Reads must not be concurrent; writes may be.
+type WebSocketConn struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// NewWebSocketConn wraps a connection whose handshake is complete
+func NewWebSocketConn(conn *websocket.Conn) *WebSocketConn {
+	conn.SetReadLimit(maxWebSocketMessage)
+	return &WebSocketConn{conn: conn}
+}
+
+// DialWebSocket connects to a ws:// or wss:// endpoint
+func DialWebSocket(ctx context.Context, endpoint string) (*WebSocketConn, error) {
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("handshake: http status %d: %w", resp.StatusCode, err)
+		}
+		return nil, fmt.Errorf("dial: %w", err)
+	}
+	return NewWebSocketConn(conn), nil
+}
+
+// SetDeadline bounds reads and writes on the connection
+func (c *WebSocketConn) SetDeadline(t time.Time) error {
+	if err := c.conn.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.conn.SetWriteDeadline(t)
+}
+
+// ReadMessage returns the next text or binary message, answering pings on
+// the way. Returns io.EOF once the peer closes the connection.
+func (c *WebSocketConn) ReadMessage() ([]byte, error) {
+	_, message, err := c.conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if errors.As(err, &closeErr) && closeErr.Code == websocket.CloseNormalClosure {
+		return nil, io.EOF
+	}
+	return message, err
+}
+
+// WriteMessage sends a text message
+func (c *WebSocketConn) WriteMessage(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// Close tells the peer the connection is closing and closes it
+func (c *WebSocketConn) Close() error {
+	message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	_ = c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(webSocketCloseTimeout))
+	return c.conn.Close()
+}
@@ -21,6 +21,20 @@ import (
 type Config struct {
 	Clients         []ClientConfig `mapstructure:"clients"`
 	RefreshInterval string         `mapstructure:"refresh_interval"`
+
+	// Validators are the indices or pubkeys of our own validators, whose
+	// duties are looked up on the consensus clients
+	Validators []string `mapstructure:"validators"`
+
+	// CheckpointProviders are trusted checkpoint sync endpoints whose
+	// finalized checkpoint the consensus clients are checked against
+	CheckpointProviders []CheckpointProvider `mapstructure:"checkpoint_providers"`
+}
+
+// CheckpointProvider is a trusted beacon API serving finalized checkpoints
+type CheckpointProvider struct {
+	Name     string `mapstructure:"name"`
+	Endpoint string `mapstructure:"endpoint"`
 }
 
 type ClientConfig struct {
@@ -28,6 +42,19 @@ type ClientConfig struct {
 	Type     string `mapstructure:"type"` // "consensus", "execution", or "validator"
 	Endpoint string `mapstructure:"endpoint"`
 	LogPath  string `mapstructure:"log_path"`
+
+	// StreamEvents subscribes consensus clients to the beacon node event
+	// stream, falling back to polling when the stream is unavailable
+	StreamEvents bool `mapstructure:"stream_events"`
+
+	// MetricsEndpoint is the Prometheus endpoint of a consensus client, from
+	// which implementation specific metrics are scraped
+	MetricsEndpoint string `mapstructure:"metrics_endpoint"`
+
+	// EngineEndpoint is the authenticated engine API of an execution client,
+	// checked with the JWT secret in the file at JWTSecretPath
+	EngineEndpoint string `mapstructure:"engine_endpoint"`
+	JWTSecretPath  string `mapstructure:"jwt_secret_path"`
 }
 
 func (c *Config) GetRefreshInterval() time.Duration {
@@ -0,0 +1,123 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"sort"
+	"time"
+)
+
+// Number of recent heads whose arrival is kept
+const headArrivalHistory = 64
+
+// HeadArrival is when a node first saw the head block of a slot
+type HeadArrival struct {
+	Slot   uint64
+	Delay  time.Duration // Time after the start of the slot
+	Polled bool          // Seen by polling, so the delay is an upper bound
+}
+
+// HeadArrivalStats is the distribution of head arrival delays over recent
+// slots
+type HeadArrivalStats struct {
+	Samples  int
+	Median   time.Duration
+	P90      time.Duration
+	Max      time.Duration
+	Deadline time.Duration // Attestation deadline, a third into the slot
+	Late     int           // Heads that arrived after the deadline
+	Polled   bool          // Whether any delay was measured by polling
+}
+
+// arrivalTracker records how long into each slot its head block was seen
+type arrivalTracker struct {
+	arrivals []HeadArrival // Oldest first
+	lastSlot uint64        // Newest head slot seen
+	started  bool
+}
+
+// observe records when the head of a slot was first seen, returning false if
+// it was not recorded. The first head after startup may have arrived long
+// before it was seen, and a head more than a slot late is from a node catching
+// up rather than from gossip, so neither is recorded.
+func (t *arrivalTracker) observe(slot uint64, seen time.Time, polled bool, chainConfig *ChainConfig) bool {
+	if !t.started {
+		t.started = true
+		t.lastSlot = slot
+		return false
+	}
+	if slot <= t.lastSlot {
+		return false
+	}
+	t.lastSlot = slot
+
+	slotDuration := time.Duration(chainConfig.SecondsPerSlot) * time.Second
+	start := chainConfig.GenesisTime.Add(time.Duration(slot) * slotDuration)
+	delay := seen.Sub(start)
+	if delay < 0 {
+		// Clock skew between us and the node
+		delay = 0
+	}
+	if delay >= 2*slotDuration {
+		return false
+	}
+
+	t.arrivals = append(t.arrivals, HeadArrival{Slot: slot, Delay: delay, Polled: polled})
+	if len(t.arrivals) > headArrivalHistory {
+		t.arrivals = t.arrivals[len(t.arrivals)-headArrivalHistory:]
+	}
+	return true
+}
+
+// apply copies the recent arrivals and their distribution onto the node info
+func (t *arrivalTracker) apply(info *ConsensusNodeInfo, chainConfig *ChainConfig) {
+	info.HeadArrivals = make([]HeadArrival, len(t.arrivals))
+	copy(info.HeadArrivals, t.arrivals)
+	info.HeadArrival = t.stats(time.Duration(chainConfig.SecondsPerSlot) * time.Second / 3)
+}
+
+// stats returns the distribution of the recorded delays, or nil if there are
+// none yet
+func (t *arrivalTracker) stats(deadline time.Duration) *HeadArrivalStats {
+	if len(t.arrivals) == 0 {
+		return nil
+	}
+
+	stats := &HeadArrivalStats{Samples: len(t.arrivals), Deadline: deadline}
+	delays := make([]time.Duration, len(t.arrivals))
+	for i, arrival := range t.arrivals {
+		delays[i] = arrival.Delay
+		if arrival.Delay > deadline {
+			stats.Late++
+		}
+		if arrival.Polled {
+			stats.Polled = true
+		}
+	}
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+
+	stats.Median = percentile(delays, 50)
+	stats.P90 = percentile(delays, 90)
+	stats.Max = delays[len(delays)-1]
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted delays
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
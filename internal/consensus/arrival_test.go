@@ -0,0 +1,76 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrivalTracker_Observe(t *testing.T) {
+	genesis := time.Unix(1606824023, 0)
+	chainConfig := &ChainConfig{GenesisTime: genesis, SecondsPerSlot: 12, SlotsPerEpoch: 32}
+	slotStart := func(slot uint64) time.Time {
+		return genesis.Add(time.Duration(slot*12) * time.Second)
+	}
+
+	var tracker arrivalTracker
+	assert.False(t, tracker.observe(100, slotStart(100).Add(30*time.Second), true, chainConfig), "first head after startup")
+	assert.True(t, tracker.observe(101, slotStart(101).Add(2*time.Second), false, chainConfig))
+	assert.False(t, tracker.observe(101, slotStart(101).Add(4*time.Second), true, chainConfig), "already seen")
+	assert.True(t, tracker.observe(103, slotStart(103).Add(-time.Second), true, chainConfig), "clock skew")
+	assert.False(t, tracker.observe(104, slotStart(104).Add(30*time.Second), true, chainConfig), "catching up")
+	assert.False(t, tracker.observe(102, slotStart(102).Add(time.Second), true, chainConfig), "older than the last head")
+
+	require.Len(t, tracker.arrivals, 2)
+	assert.Equal(t, HeadArrival{Slot: 101, Delay: 2 * time.Second}, tracker.arrivals[0])
+	assert.Equal(t, HeadArrival{Slot: 103, Polled: true}, tracker.arrivals[1])
+
+	for slot := uint64(200); slot < 200+2*headArrivalHistory; slot++ {
+		tracker.observe(slot, slotStart(slot).Add(time.Second), false, chainConfig)
+	}
+	assert.Len(t, tracker.arrivals, headArrivalHistory)
+}
+
+func TestArrivalTracker_Apply(t *testing.T) {
+	chainConfig := &ChainConfig{GenesisTime: time.Unix(1606824023, 0), SecondsPerSlot: 12, SlotsPerEpoch: 32}
+	tracker := arrivalTracker{}
+	for i, seconds := range []float64{1, 2, 5, 1.5, 3, 0.5, 2.5, 6, 1, 2} {
+		tracker.arrivals = append(tracker.arrivals, HeadArrival{
+			Slot:  uint64(i),
+			Delay: time.Duration(seconds * float64(time.Second)),
+		})
+	}
+
+	info := &ConsensusNodeInfo{}
+	tracker.apply(info, chainConfig)
+	assert.Len(t, info.HeadArrivals, 10)
+	assert.Equal(t, &HeadArrivalStats{
+		Samples:  10,
+		Median:   2 * time.Second,
+		P90:      5 * time.Second,
+		Max:      6 * time.Second,
+		Deadline: 4 * time.Second,
+		Late:     2,
+	}, info.HeadArrival)
+
+	// Nothing seen yet
+	info = &ConsensusNodeInfo{}
+	(&arrivalTracker{}).apply(info, chainConfig)
+	assert.Empty(t, info.HeadArrivals)
+	assert.Nil(t, info.HeadArrival)
+}
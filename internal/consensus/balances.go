@@ -0,0 +1,231 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Number of epochs of balances kept per validator
+const balanceHistoryEpochs = 4
+
+// BalanceProvider is implemented by consensus clients that can look up the
+// balance and status of the configured validators
+type BalanceProvider interface {
+	ValidatorSource
+	GetValidatorBalances(ctx context.Context) (*ValidatorBalances, error)
+}
+
+// ValidatorBalance is the state of a single validator at the head
+type ValidatorBalance struct {
+	Index            uint64
+	Pubkey           string
+	Status           string // e.g. active_ongoing, see the beacon API for all values
+	Slashed          bool
+	Balance          uint64 // Gwei
+	EffectiveBalance uint64 // Gwei
+	EpochDelta       int64  // Gwei, balance change over the last complete epoch
+	HasDelta         bool
+}
+
+// EpochDelta is the total balance change of the validators over an epoch
+type EpochDelta struct {
+	Epoch uint64
+	Delta int64 // Gwei
+}
+
+// ValidatorBalances holds the balance and status of the configured validators
+type ValidatorBalances struct {
+	Epoch      uint64
+	Validators []ValidatorBalance // Ordered by index
+	Missing    []string           // Configured validators not known to the node
+	Deltas     []EpochDelta       // Complete epochs, oldest first
+	UpdatedAt  time.Time
+}
+
+// TotalBalance returns the sum of the validator balances in Gwei
+func (b *ValidatorBalances) TotalBalance() uint64 {
+	var total uint64
+	for _, validator := range b.Validators {
+		total += validator.Balance
+	}
+	return total
+}
+
+// StatusCounts returns the number of validators in each status
+func (b *ValidatorBalances) StatusCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, validator := range b.Validators {
+		counts[validator.Status]++
+	}
+	return counts
+}
+
+// LastDelta returns the balance change over the most recent complete epoch
+func (b *ValidatorBalances) LastDelta() (EpochDelta, bool) {
+	if b == nil || len(b.Deltas) == 0 {
+		return EpochDelta{}, false
+	}
+	return b.Deltas[len(b.Deltas)-1], true
+}
+
+// balanceTracker keeps the latest balance seen in each recent epoch per
+// validator, to work out balance changes per epoch
+type balanceTracker struct {
+	history map[uint64]map[uint64]uint64 // Validator index, then epoch
+}
+
+func (t *balanceTracker) observe(index, epoch, balance uint64) {
+	if t.history == nil {
+		t.history = make(map[uint64]map[uint64]uint64)
+	}
+	epochs, ok := t.history[index]
+	if !ok {
+		epochs = make(map[uint64]uint64)
+		t.history[index] = epochs
+	}
+	epochs[epoch] = balance
+
+	for e := range epochs {
+		if e+balanceHistoryEpochs <= epoch {
+			delete(epochs, e)
+		}
+	}
+}
+
+// delta returns the balance change of a validator over the epoch, which is
+// the difference between the last balances seen in it and the epoch before
+func (t *balanceTracker) delta(index, epoch uint64) (int64, bool) {
+	if epoch == 0 {
+		return 0, false
+	}
+	epochs := t.history[index]
+	current, ok := epochs[epoch]
+	if !ok {
+		return 0, false
+	}
+	previous, ok := epochs[epoch-1]
+	if !ok {
+		return 0, false
+	}
+	return int64(current) - int64(previous), true
+}
+
+// deltas returns the total balance change of the validators for each complete
+// epoch before the current one, oldest first. Epochs are only included if
+// the change is known for every validator.
+func (t *balanceTracker) deltas(indices []uint64, currentEpoch uint64) []EpochDelta {
+	var deltas []EpochDelta
+	for back := uint64(balanceHistoryEpochs - 1); back >= 1; back-- {
+		if back > currentEpoch {
+			continue
+		}
+		epoch := currentEpoch - back
+
+		var total int64
+		complete := len(indices) > 0
+		for _, index := range indices {
+			delta, ok := t.delta(index, epoch)
+			if !ok {
+				complete = false
+				break
+			}
+			total += delta
+		}
+		if complete {
+			deltas = append(deltas, EpochDelta{Epoch: epoch, Delta: total})
+		}
+	}
+	return deltas
+}
+
+// GetValidatorBalances fetches the balance and status of the configured
+// validators at the head and updates their balance history. Balances are
+// those of the epoch of the head, which on a node that lags behind is not the
+// current one, so they are fetched from the state at the head's slot.
+func (c *ConsensusClient) GetValidatorBalances(ctx context.Context) (*ValidatorBalances, error) {
+	chainConfig, err := c.chainConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := c.getHeader(ctx, "head")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get head: %w", err)
+	}
+	headSlot, err := strconv.ParseUint(header.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse head slot: %w", err)
+	}
+	epoch := headSlot / chainConfig.SlotsPerEpoch
+
+	validators, err := c.getValidators(ctx, strconv.FormatUint(headSlot, 10), c.validators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators: %w", err)
+	}
+
+	balances := &ValidatorBalances{
+		Epoch:     epoch,
+		UpdatedAt: time.Now(),
+	}
+
+	found := make(map[string]bool, len(validators))
+	indices := make([]uint64, 0, len(validators))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, data := range validators {
+		index, err := strconv.ParseUint(data.Index, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse validator index: %w", err)
+		}
+		balance, _ := strconv.ParseUint(data.Balance, 10, 64)
+		effective, _ := strconv.ParseUint(data.Validator.EffectiveBalance, 10, 64)
+
+		c.balances.observe(index, epoch, balance)
+		validator := ValidatorBalance{
+			Index:            index,
+			Pubkey:           data.Validator.Pubkey,
+			Status:           data.Status,
+			Slashed:          data.Validator.Slashed,
+			Balance:          balance,
+			EffectiveBalance: effective,
+		}
+		if epoch > 0 {
+			validator.EpochDelta, validator.HasDelta = c.balances.delta(index, epoch-1)
+		}
+		balances.Validators = append(balances.Validators, validator)
+		indices = append(indices, index)
+
+		found[data.Index] = true
+		found[strings.ToLower(data.Validator.Pubkey)] = true
+	}
+	balances.Deltas = c.balances.deltas(indices, epoch)
+
+	for _, id := range c.validators {
+		if !found[strings.ToLower(id)] {
+			balances.Missing = append(balances.Missing, id)
+		}
+	}
+
+	sort.Slice(balances.Validators, func(i, j int) bool {
+		return balances.Validators[i].Index < balances.Validators[j].Index
+	})
+	return balances, nil
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestBalanceTracker(t *testing.T) {
+	var tracker balanceTracker
+
+	tracker.observe(1, 10, 32_000_000_000)
+	tracker.observe(1, 10, 32_000_001_000) // Last balance in the epoch counts
+	tracker.observe(1, 11, 32_000_015_000)
+	tracker.observe(1, 12, 32_000_010_000)
+	tracker.observe(2, 11, 31_000_000_000)
+	tracker.observe(2, 12, 31_000_012_000)
+
+	delta, ok := tracker.delta(1, 11)
+	require.True(t, ok)
+	assert.Equal(t, int64(14_000), delta)
+	delta, ok = tracker.delta(1, 12)
+	require.True(t, ok)
+	assert.Equal(t, int64(-5_000), delta)
+	_, ok = tracker.delta(2, 11)
+	assert.False(t, ok, "no balance for the epoch before")
+
+	// Epoch 11 is only complete for validator 1
+	assert.Equal(t, []EpochDelta{{Epoch: 11, Delta: 14_000}, {Epoch: 12, Delta: -5_000}}, tracker.deltas([]uint64{1}, 13))
+	assert.Equal(t, []EpochDelta{{Epoch: 12, Delta: 7_000}}, tracker.deltas([]uint64{1, 2}, 13))
+
+	// Old epochs are dropped
+	tracker.observe(1, 10+balanceHistoryEpochs, 32_000_020_000)
+	assert.NotContains(t, tracker.history[1], uint64(10))
+	assert.Contains(t, tracker.history[1], uint64(11))
+}
+
+func TestConsensusClient_GetValidatorBalances(t *testing.T) {
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	balance := uint64(32_000_000_000)
+	headSlot := "100"
+
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v1/beacon/headers/head" {
+			_, _ = fmt.Fprintf(w, `{"data": {"root": "0x01", "header": {"message": {"slot": "%s"}}}}`, headSlot)
+			return
+		}
+		if r.URL.Path == "/eth/v1/beacon/states/"+headSlot+"/validators" {
+			assert.Equal(t, "5,0xBB,0xcc", r.URL.Query().Get("id"))
+			_, _ = fmt.Fprintf(w, `{"data": [
+				{"index": "9", "balance": "31000000000", "status": "active_slashed", "validator": {"pubkey": "0xbb", "effective_balance": "31000000000", "slashed": true}},
+				{"index": "5", "balance": "%d", "status": "active_ongoing", "validator": {"pubkey": "0xaa", "effective_balance": "32000000000"}}
+			]}`, balance)
+			return
+		}
+		testutil.MockHTTPEndpoints(map[string]struct {
+			Status int
+			Body   string
+		}{
+			"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": {"genesis_time": "%d"}}`, genesis.Unix())},
+			"/eth/v1/config/spec":    {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+		})(w, r)
+	})
+
+	client := NewConsensusClient("test", server.URL, WithValidators([]string{"5", "0xBB", "0xcc"}))
+	balances, err := client.GetValidatorBalances(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(3), balances.Epoch)
+	require.Len(t, balances.Validators, 2)
+	assert.Equal(t, uint64(5), balances.Validators[0].Index)
+	assert.Equal(t, "active_ongoing", balances.Validators[0].Status)
+	assert.Equal(t, uint64(9), balances.Validators[1].Index)
+	assert.True(t, balances.Validators[1].Slashed)
+	assert.Equal(t, []string{"0xcc"}, balances.Missing)
+	assert.Equal(t, uint64(63_000_000_000), balances.TotalBalance())
+	assert.Equal(t, map[string]int{"active_ongoing": 1, "active_slashed": 1}, balances.StatusCounts())
+	assert.False(t, balances.Validators[0].HasDelta)
+	_, ok := balances.LastDelta()
+	assert.False(t, ok)
+
+	// Simulate a balance seen in the previous epoch
+	client.mu.Lock()
+	client.balances.observe(5, 2, balance-10_000)
+	client.balances.observe(9, 2, 31_000_000_000)
+	client.mu.Unlock()
+
+	balances, err = client.GetValidatorBalances(context.Background())
+	require.NoError(t, err)
+	// Deltas only cover complete epochs, the current one is still in progress
+	assert.False(t, balances.Validators[0].HasDelta)
+
+	client.mu.Lock()
+	client.balances.observe(5, 1, balance-25_000)
+	client.balances.observe(9, 1, 31_000_000_000)
+	client.mu.Unlock()
+
+	balances, err = client.GetValidatorBalances(context.Background())
+	require.NoError(t, err)
+	require.True(t, balances.Validators[0].HasDelta)
+	assert.Equal(t, int64(15_000), balances.Validators[0].EpochDelta)
+	last, ok := balances.LastDelta()
+	require.True(t, ok)
+	assert.Equal(t, EpochDelta{Epoch: 2, Delta: 15_000}, last)
+
+	// A node lagging an epoch behind reports the balances of its head's epoch
+	headSlot = "70"
+	balance += 5_000
+	balances, err = client.GetValidatorBalances(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), balances.Epoch)
+	client.mu.Lock()
+	assert.Equal(t, balance, client.balances.history[5][2])
+	assert.Equal(t, balance-5_000, client.balances.history[5][3], "epoch 3 left as seen")
+	client.mu.Unlock()
+}
@@ -0,0 +1,48 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import "time"
+
+// How long data that rarely changes, i.e. the genesis, spec and node version,
+// is used before it is fetched again. Refetching picks up a node that has
+// been upgraded or pointed at another network without restarting.
+const staticDataTTL = 15 * time.Minute
+
+// cached is a value along with when it was fetched
+type cached[T any] struct {
+	value   T
+	fetched time.Time
+	valid   bool
+}
+
+// get returns the value if it was fetched within the TTL
+func (c *cached[T]) get(now time.Time) (T, bool) {
+	if !c.valid || now.Sub(c.fetched) >= staticDataTTL {
+		var zero T
+		return zero, false
+	}
+	return c.value, true
+}
+
+// stale returns the value whatever its age, for when fetching it again fails
+func (c *cached[T]) stale() (T, bool) {
+	return c.value, c.valid
+}
+
+func (c *cached[T]) set(value T, now time.Time) {
+	c.value = value
+	c.fetched = now
+	c.valid = true
+}
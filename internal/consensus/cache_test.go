@@ -0,0 +1,83 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestCached(t *testing.T) {
+	now := time.Now()
+	var c cached[string]
+
+	_, ok := c.get(now)
+	assert.False(t, ok, "empty")
+	_, ok = c.stale()
+	assert.False(t, ok, "empty")
+
+	c.set("v1", now)
+	value, ok := c.get(now.Add(staticDataTTL - time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, "v1", value)
+
+	_, ok = c.get(now.Add(staticDataTTL))
+	assert.False(t, ok, "expired")
+	value, ok = c.stale()
+	assert.True(t, ok)
+	assert.Equal(t, "v1", value)
+}
+
+func TestConsensusClient_getVersion(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	status := http.StatusOK
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(testutil.ValidNodeVersionResponse))
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	for i := 0; i < 3; i++ {
+		version, err := client.getVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "Lighthouse/v4.5.0-1234567/x86_64-linux", version)
+	}
+	mu.Lock()
+	assert.Equal(t, 1, requests, "cached")
+	status = http.StatusInternalServerError
+	mu.Unlock()
+
+	// Expire the cached version, which is still used if the node fails
+	client.mu.Lock()
+	client.version.fetched = time.Now().Add(-staticDataTTL)
+	client.mu.Unlock()
+
+	version, err := client.getVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Lighthouse/v4.5.0-1234567/x86_64-linux", version)
+	mu.Lock()
+	assert.Equal(t, 2, requests, "refetched once expired")
+	mu.Unlock()
+}
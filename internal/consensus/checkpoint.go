@@ -0,0 +1,129 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CheckpointSource is implemented by beacon APIs that report a finalized
+// checkpoint, i.e. our own nodes and trusted checkpoint sync providers
+type CheckpointSource interface {
+	GetFinalizedCheckpoint(ctx context.Context) (*Checkpoint, error)
+	IsCanonical(ctx context.Context, root string) (bool, error)
+}
+
+// Checkpoint is a finalized checkpoint
+type Checkpoint struct {
+	Epoch uint64
+	Root  string
+}
+
+type CheckpointStatus int
+
+const (
+	CheckpointUnverified CheckpointStatus = iota // Node is behind the provider, or the check failed
+	CheckpointMatch                              // Provider checkpoint is in the node's canonical chain
+	CheckpointMismatch                           // Node finalized a different chain from the provider
+)
+
+func (s CheckpointStatus) String() string {
+	switch s {
+	case CheckpointMatch:
+		return "match"
+	case CheckpointMismatch:
+		return "mismatch"
+	default:
+		return "unverified"
+	}
+}
+
+// GetFinalizedCheckpoint returns the finalized checkpoint of the head state.
+// Checkpoint sync providers that do not serve finality checkpoints are asked
+// for the finalized block header instead.
+func (c *ConsensusClient) GetFinalizedCheckpoint(ctx context.Context) (*Checkpoint, error) {
+	finality, err := c.getFinalityCheckpoints(ctx)
+	if err == nil {
+		epoch, err := strconv.ParseUint(finality.Data.Finalized.Epoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse finalized epoch: %w", err)
+		}
+		return &Checkpoint{Epoch: epoch, Root: finality.Data.Finalized.Root}, nil
+	}
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) {
+		return nil, err
+	}
+
+	chainConfig, err := c.GetChainConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header, err := c.getHeader(ctx, "finalized")
+	if err != nil {
+		return nil, err
+	}
+	slot, err := strconv.ParseUint(header.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse finalized slot: %w", err)
+	}
+	// The checkpoint block is the last one at or before the epoch start
+	epoch := (slot + chainConfig.SlotsPerEpoch - 1) / chainConfig.SlotsPerEpoch
+	return &Checkpoint{Epoch: epoch, Root: header.Data.Root}, nil
+}
+
+// IsCanonical returns true if the block is in the node's canonical chain.
+// Nodes return 404 for blocks they do not know.
+func (c *ConsensusClient) IsCanonical(ctx context.Context, root string) (bool, error) {
+	header, err := c.getHeader(ctx, root)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return header.Data.Canonical, nil
+}
+
+// CompareCheckpoint checks a node's finalized checkpoint against a provider's.
+// Checkpoints at the same epoch must have the same root. A node that has
+// finalized past the provider must have the provider's checkpoint in its
+// canonical chain. A node behind the provider cannot be checked until it
+// catches up.
+func CompareCheckpoint(ctx context.Context, node CheckpointSource, nodeCheckpoint, providerCheckpoint Checkpoint) (CheckpointStatus, error) {
+	switch {
+	case nodeCheckpoint.Epoch == providerCheckpoint.Epoch:
+		if strings.EqualFold(nodeCheckpoint.Root, providerCheckpoint.Root) {
+			return CheckpointMatch, nil
+		}
+		return CheckpointMismatch, nil
+	case nodeCheckpoint.Epoch > providerCheckpoint.Epoch:
+		canonical, err := node.IsCanonical(ctx, providerCheckpoint.Root)
+		if err != nil {
+			return CheckpointUnverified, err
+		}
+		if canonical {
+			return CheckpointMatch, nil
+		}
+		return CheckpointMismatch, nil
+	default:
+		return CheckpointUnverified, nil
+	}
+}
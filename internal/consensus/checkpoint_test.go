@@ -0,0 +1,146 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestConsensusClient_GetFinalizedCheckpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints map[string]struct {
+			Status int
+			Body   string
+		}
+		expected    *Checkpoint
+		expectError bool
+	}{
+		{
+			name: "finality checkpoints",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/states/head/finality_checkpoints": {Status: http.StatusOK, Body: `{"data": {
+					"previous_justified": {"epoch": "101", "root": "0x01"},
+					"current_justified": {"epoch": "102", "root": "0x02"},
+					"finalized": {"epoch": "100", "root": "0xaa"}
+				}}`},
+			},
+			expected: &Checkpoint{Epoch: 100, Root: "0xaa"},
+		},
+		{
+			name: "finalized header fallback",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: testGenesisResponse},
+				"/eth/v1/config/spec":    {Status: http.StatusOK, Body: `{"data": {"SECONDS_PER_SLOT": "12", "SLOTS_PER_EPOCH": "32"}}`},
+				"/eth/v1/beacon/headers/finalized": {Status: http.StatusOK, Body: `{"data": {
+					"root": "0xbb", "canonical": true, "header": {"message": {"slot": "3199"}}
+				}}`},
+			},
+			expected: &Checkpoint{Epoch: 100, Root: "0xbb"},
+		},
+		{
+			name: "not available",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(tt.endpoints))
+			client := NewConsensusClient("test", server.URL)
+
+			checkpoint, err := client.GetFinalizedCheckpoint(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, checkpoint)
+		})
+	}
+}
+
+func TestConsensusClient_IsCanonical(t *testing.T) {
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/eth/v1/beacon/headers/0xaa": {Status: http.StatusOK, Body: `{"data": {"root": "0xaa", "canonical": true}}`},
+		"/eth/v1/beacon/headers/0xbb": {Status: http.StatusOK, Body: `{"data": {"root": "0xbb", "canonical": false}}`},
+		"/eth/v1/beacon/headers/0xcc": {Status: http.StatusInternalServerError, Body: ""},
+	}))
+	client := NewConsensusClient("test", server.URL)
+
+	canonical, err := client.IsCanonical(context.Background(), "0xaa")
+	require.NoError(t, err)
+	assert.True(t, canonical)
+
+	canonical, err = client.IsCanonical(context.Background(), "0xbb")
+	require.NoError(t, err)
+	assert.False(t, canonical, "orphaned block")
+
+	canonical, err = client.IsCanonical(context.Background(), "0xdd")
+	require.NoError(t, err)
+	assert.False(t, canonical, "unknown block")
+
+	_, err = client.IsCanonical(context.Background(), "0xcc")
+	assert.Error(t, err)
+}
+
+func TestCompareCheckpoint(t *testing.T) {
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/eth/v1/beacon/headers/0xaa": {Status: http.StatusOK, Body: `{"data": {"root": "0xaa", "canonical": true}}`},
+	}))
+	node := NewConsensusClient("test", server.URL)
+
+	tests := []struct {
+		name     string
+		node     Checkpoint
+		provider Checkpoint
+		expected CheckpointStatus
+	}{
+		{name: "same epoch and root", node: Checkpoint{Epoch: 100, Root: "0xAA"}, provider: Checkpoint{Epoch: 100, Root: "0xaa"}, expected: CheckpointMatch},
+		{name: "same epoch different root", node: Checkpoint{Epoch: 100, Root: "0xbb"}, provider: Checkpoint{Epoch: 100, Root: "0xaa"}, expected: CheckpointMismatch},
+		{name: "node ahead on the same chain", node: Checkpoint{Epoch: 101, Root: "0xbb"}, provider: Checkpoint{Epoch: 100, Root: "0xaa"}, expected: CheckpointMatch},
+		{name: "node ahead on another chain", node: Checkpoint{Epoch: 101, Root: "0xbb"}, provider: Checkpoint{Epoch: 100, Root: "0xcc"}, expected: CheckpointMismatch},
+		{name: "node behind", node: Checkpoint{Epoch: 99, Root: "0xbb"}, provider: Checkpoint{Epoch: 100, Root: "0xaa"}, expected: CheckpointUnverified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := CompareCheckpoint(context.Background(), node, tt.node, tt.provider)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, status)
+		})
+	}
+}
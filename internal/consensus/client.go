@@ -14,12 +14,15 @@
 package consensus
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/watcheth/watcheth/internal/common"
@@ -32,19 +35,84 @@ type Client interface {
 }
 
 type ConsensusClient struct {
-	endpoint   string
-	httpClient *http.Client
-	name       string
+	endpoint     string
+	httpClient   *http.Client
+	streamClient *http.Client
+	name         string
+	streamEvents bool
+
+	metricsEndpoint string // Prometheus endpoint, empty if not configured
+
+	mu        sync.Mutex
+	latest    *ConsensusNodeInfo     // Last snapshot, updated by polling and events
+	config    *ChainConfig           // Chain config from the last successful poll
+	network   cached[*NetworkConfig] // Genesis and spec
+	schedule  cached[[]ScheduledFork]
+	version   cached[string] // Node version
+	streaming bool           // Whether the event stream is currently connected
+	heads     headTracker
+	slots     slotTracker
+	arrivals  arrivalTracker
+
+	validators       []string               // Configured validator indices or pubkeys
+	validatorIndices map[string]uint64      // Resolved index of each configured validator
+	duties           map[uint64]epochDuties // Duties of the configured validators, by epoch
+	dutyRoots        dutyRoots              // Dependent roots of duties at the streamed head
+	balances         balanceTracker
+	effectiveness    *AttestationEffectiveness // Last completed epoch, rewards do not change
+	syncCommittees   map[uint64][]uint64       // Sync committee members per period
+	syncSlots        map[uint64]SyncSlotParticipation
+	peers            peerTracker
+	blobs            map[uint64]BlockBlobs // Blobs of recent blocks, by slot
+	attestations     cached[int]           // Size of the attestation pool
+	gossip           counterRate           // Gossip messages received, for the rate
+	sources          common.SourceTracker
 }
 
-func NewConsensusClient(name, endpoint string) *ConsensusClient {
-	return &ConsensusClient{
-		name:       name,
-		endpoint:   endpoint,
-		httpClient: common.NewHTTPClient(10 * time.Second),
+// Option configures optional behaviour of a ConsensusClient
+type Option func(*ConsensusClient)
+
+// WithEventStream enables subscribing to the beacon node event stream
+func WithEventStream(enabled bool) Option {
+	return func(c *ConsensusClient) {
+		c.streamEvents = enabled
+	}
+}
+
+// WithValidators sets the validators, by index or pubkey, whose duties are
+// looked up
+func WithValidators(validators []string) Option {
+	return func(c *ConsensusClient) {
+		c.validators = validators
+	}
+}
+
+// WithMetricsEndpoint sets the Prometheus endpoint of the node, from which
+// implementation specific metrics are scraped
+func WithMetricsEndpoint(endpoint string) Option {
+	return func(c *ConsensusClient) {
+		c.metricsEndpoint = endpoint
+	}
+}
+
+func NewConsensusClient(name, endpoint string, opts ...Option) *ConsensusClient {
+	c := &ConsensusClient{
+		name:         name,
+		endpoint:     endpoint,
+		httpClient:   common.NewHTTPClient(10 * time.Second),
+		streamClient: common.NewStreamingHTTPClient(),
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
+// GetNodeInfo polls the node. The queries that make up a poll are
+// independent, so they are sent concurrently, as are the lookups that follow
+// from the head. Only the chain config and
+// syncing status are needed to report on the node; the outcome of every query
+// is recorded in Sources, so a node missing the others shows as degraded.
 func (c *ConsensusClient) GetNodeInfo(ctx context.Context) (*ConsensusNodeInfo, error) {
 	info := &ConsensusNodeInfo{
 		Name:       c.name,
@@ -52,16 +120,106 @@ func (c *ConsensusClient) GetNodeInfo(ctx context.Context) (*ConsensusNodeInfo,
 		LastUpdate: time.Now(),
 	}
 
-	chainConfig, err := c.GetChainConfig(ctx)
-	if err != nil {
+	var (
+		chainConfig *ChainConfig
+		forks       []ScheduledFork
+		syncing     *SyncingResponse
+		headers     *HeadersResponse
+		finality    *FinalityCheckpointsResponse
+		// When the head was returned, as its arrival when polled
+		syncingSeen time.Time
+		headSeen    time.Time
+	)
+	queries := &queryGroup{node: c.name, sources: &c.sources}
+	queries.run(QueryHealth, func() error {
+		// Health is set even on failure, so that a node that is up but not
+		// ready says so
+		var err error
+		info.Health, err = c.getHealth(ctx)
+		return err
+	})
+	queries.run(QueryConfig, func() error {
+		var err error
+		if chainConfig, err = c.GetChainConfig(ctx); err != nil {
+			return err
+		}
+		// Cached by GetChainConfig, so this does not fetch again
+		info.Network, _ = c.GetNetworkConfig(ctx)
+		// Forks are named from the spec, so the schedule needs it
+		queries.run(QuerySchedule, func() error {
+			var err error
+			forks, err = c.getForkSchedule(ctx, chainConfig)
+			return err
+		})
+		return nil
+	})
+	queries.run(QuerySyncing, func() error {
+		var err error
+		syncing, err = c.getSyncing(ctx)
+		syncingSeen = time.Now()
+		return err
+	})
+	queries.run(QueryHeaders, func() error {
+		var err error
+		headers, err = c.getHeaders(ctx)
+		headSeen = time.Now()
+		return err
+	})
+	queries.run(QueryFinality, func() error {
+		var err error
+		finality, err = c.getFinalityCheckpoints(ctx)
+		return err
+	})
+	queries.run(QueryPeers, func() error {
+		// Get peer count, and the individual peers for the breakdown
+		peerCount, err := c.getPeerCount(ctx)
+		if err != nil {
+			return err
+		}
+		info.Peers = c.updatePeers(ctx, peerCount, time.Now())
+		info.PeerCount = info.Peers.Connected
+		return nil
+	})
+	queries.run(QueryIdentity, func() error {
+		var err error
+		info.Identity, err = c.GetNodeIdentity(ctx)
+		return err
+	})
+	queries.run(QueryVersion, func() error {
+		version, err := c.getVersion(ctx)
+		info.NodeVersion = version
+		// Metrics are mapped by implementation, so need the version
+		if c.metricsEndpoint != "" {
+			queries.run(QueryMetrics, func() error {
+				var err error
+				info.Metrics, err = c.GetClientMetrics(ctx, version)
+				return err
+			})
+		}
+		return err
+	})
+	queries.run(QueryFork, func() error {
+		fork, err := c.getFork(ctx)
+		if err != nil {
+			return err
+		}
+		info.CurrentFork = fork.Data.CurrentVersion
+		return nil
+	})
+	queries.start(func() {
+		// Each pool is recorded in Sources by GetOperationPool
+		info.Pool, _ = c.GetOperationPool(ctx)
+	})
+	queries.wait()
+	info.Sources = c.sources.Snapshot()
+
+	if err := info.Sources.Err(QueryConfig); err != nil {
 		info.IsConnected = false
 		info.LastError = err
 		logger.Error("[%s]: Failed to get chain config: %v", c.name, err)
 		return info, nil
 	}
-
-	syncing, err := c.getSyncing(ctx)
-	if err != nil {
+	if err := info.Sources.Err(QuerySyncing); err != nil {
 		info.IsConnected = false
 		info.LastError = err
 		logger.Error("[%s]: Failed to get syncing status: %v", c.name, err)
@@ -77,37 +235,204 @@ func (c *ConsensusClient) GetNodeInfo(ctx context.Context) (*ConsensusNodeInfo,
 	info.HeadSlot = headSlot
 	info.SyncDistance = syncDistance
 
-	// Try to get headers, but don't fail if not available
-	headers, err := c.getHeaders(ctx)
-	if err == nil && len(headers.Data) > 0 {
-		slot, _ := strconv.ParseUint(headers.Data[0].Header.Message.Slot, 10, 64)
+	// If headers are not available, head slot was already set from syncing
+	var observed *headRecord
+	if headers == nil || len(headers.Data) == 0 {
+		headSeen = syncingSeen
+	} else {
+		head := headers.Data[0]
+		slot, _ := strconv.ParseUint(head.Header.Message.Slot, 10, 64)
 		info.HeadSlot = slot
+		info.HeadRoot = head.Root
+		if head.Root != "" {
+			observed = &headRecord{Slot: slot, Root: head.Root, ParentRoot: head.Header.Message.ParentRoot}
+		}
+	}
+	if !info.IsSyncing {
+		c.observeArrival(info.HeadSlot, headSeen, true, chainConfig)
 	}
-	// If headers endpoint fails, head slot was already set from syncing response
 
-	finality, err := c.getFinalityCheckpoints(ctx)
-	if err != nil {
-		info.IsConnected = false
-		info.LastError = err
-		logger.Error("[%s]: Failed to get finality checkpoints: %v", c.name, err)
-		return info, nil
+	if finality != nil && info.Sources.Err(QueryFinality) == nil {
+		justifiedEpoch, _ := strconv.ParseUint(finality.Data.CurrentJustified.Epoch, 10, 64)
+		finalizedEpoch, _ := strconv.ParseUint(finality.Data.Finalized.Epoch, 10, 64)
+		info.JustifiedEpoch = justifiedEpoch
+		info.FinalizedEpoch = finalizedEpoch
+		info.JustifiedRoot = finality.Data.CurrentJustified.Root
+		info.FinalizedRoot = finality.Data.Finalized.Root
+
+		info.JustifiedSlot = epochStartSlot(justifiedEpoch, chainConfig.SlotsPerEpoch)
+		info.FinalizedSlot = epochStartSlot(finalizedEpoch, chainConfig.SlotsPerEpoch)
+	}
+
+	applySlotTiming(info, chainConfig, time.Now())
+	applyForks(info, forks, chainConfig)
+
+	// The lookups that follow from the head are independent of each other,
+	// so a slow one does not hold up the rest
+	followUps := &queryGroup{node: c.name, sources: &c.sources}
+	if observed != nil {
+		followUps.start(func() { c.observeHead(ctx, *observed) })
+	}
+	// Check block production in recent slots
+	followUps.start(func() { c.updateSlots(ctx, info, chainConfig) })
+	followUps.start(func() { c.updateBlobs(ctx, info, chainConfig) })
+	followUps.wait()
+
+	info.IsConnected = true
+	c.storeSnapshot(info, chainConfig)
+	logger.Info("[%s]: Successfully connected and retrieved node info", c.name)
+	return info, nil
+}
+
+// storeSnapshot records the latest polled node info so that events from the
+// stream can be applied on top of it.
+func (c *ConsensusClient) storeSnapshot(info *ConsensusNodeInfo, chainConfig *ChainConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info.EventStream = c.streaming
+	c.heads.apply(info)
+	c.slots.apply(info, chainConfig.SlotsPerEpoch)
+	c.arrivals.apply(info, chainConfig)
+	snapshot := *info
+	c.latest = &snapshot
+	c.config = chainConfig
+}
+
+// observeArrival records when the node's head was first seen
+func (c *ConsensusClient) observeArrival(slot uint64, seen time.Time, polled bool, chainConfig *ChainConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.arrivals.observe(slot, seen, polled, chainConfig)
+}
+
+// observeHead feeds the current head into the reorg tracker. Unseen
+// ancestors are looked up without holding c.mu, which the event stream and
+// readers need in the meantime.
+func (c *ConsensusClient) observeHead(ctx context.Context, head headRecord) {
+	c.mu.Lock()
+	known := c.heads.known()
+	c.mu.Unlock()
+
+	resolved := make(map[string]headRecord)
+	if len(known) > 0 && !known[head.Root] {
+		parent := head.ParentRoot
+		for i := 0; i < maxAncestorLookups && !known[parent]; i++ {
+			header, err := c.getHeader(ctx, parent)
+			if err != nil {
+				break
+			}
+			slot, _ := strconv.ParseUint(header.Data.Header.Message.Slot, 10, 64)
+			record := headRecord{Slot: slot, Root: header.Data.Root, ParentRoot: header.Data.Header.Message.ParentRoot}
+			resolved[parent] = record
+			parent = record.ParentRoot
+		}
+	}
+	lookup := func(root string) (headRecord, error) {
+		if record, ok := resolved[root]; ok {
+			return record, nil
+		}
+		return headRecord{}, fmt.Errorf("header %s not looked up", ShortRoot(root))
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if reorg := c.heads.observe(head, lookup, time.Now()); reorg != nil {
+		c.handleReorg(*reorg)
 	}
+}
+
+// handleReorg logs a reorg and forgets the outcome of the affected slots and
+// the duties that depended on them. Caller must hold c.mu.
+func (c *ConsensusClient) handleReorg(reorg ReorgEvent) {
+	logger.Warn("[%s]: Reorg of depth %d at slot %d (%s -> %s)",
+		c.name, reorg.Depth, reorg.Slot, ShortRoot(reorg.OldHeadRoot), ShortRoot(reorg.NewHeadRoot))
+
+	var from uint64
+	if reorg.Depth < reorg.Slot {
+		from = reorg.Slot - reorg.Depth
+	}
+	c.slots.invalidateFrom(from)
+	c.invalidateDuties(from)
+}
+
+// updateSlots fetches the proposer duties and block production outcome of
+// recent slots that are not known yet
+func (c *ConsensusClient) updateSlots(ctx context.Context, info *ConsensusNodeInfo, chainConfig *ChainConfig) {
+	firstEpoch := firstTrackedEpoch(info.CurrentEpoch)
+	firstSlot := epochStartSlot(firstEpoch, chainConfig.SlotsPerEpoch)
+
+	c.mu.Lock()
+	c.slots.prune(firstEpoch, chainConfig.SlotsPerEpoch)
+	epochs := c.slots.missingDuties(firstEpoch, info.CurrentEpoch)
+	slots := c.slots.pending(firstSlot, info.HeadSlot, maxSlotLookups)
+	c.mu.Unlock()
+
+	// Each lookup is recorded as it completes, so a slow one only delays
+	// itself
+	var wg sync.WaitGroup
+	for _, epoch := range epochs {
+		wg.Add(1)
+		go func(epoch uint64) {
+			defer wg.Done()
+			duties, _, err := c.getProposerDuties(ctx, epoch)
+			if err != nil {
+				logger.Debug("[%s]: Failed to get proposer duties for epoch %d: %v", c.name, epoch, err)
+				return
+			}
+			c.mu.Lock()
+			c.slots.setDuties(epoch, duties)
+			c.mu.Unlock()
+		}(epoch)
+	}
+	for _, slot := range slots {
+		wg.Add(1)
+		go func(slot uint64) {
+			defer wg.Done()
+			record, err := c.checkSlot(ctx, slot)
+			if err != nil {
+				logger.Debug("[%s]: Failed to check slot %d: %v", c.name, slot, err)
+				return
+			}
+			c.mu.Lock()
+			c.slots.record(record)
+			c.mu.Unlock()
+		}(slot)
+	}
+	wg.Wait()
+}
 
-	justifiedEpoch, _ := strconv.ParseUint(finality.Data.CurrentJustified.Epoch, 10, 64)
-	finalizedEpoch, _ := strconv.ParseUint(finality.Data.Finalized.Epoch, 10, 64)
-	info.JustifiedEpoch = justifiedEpoch
-	info.FinalizedEpoch = finalizedEpoch
+// checkSlot looks up the canonical block at a slot. Nodes return 404 for
+// slots without a block.
+func (c *ConsensusClient) checkSlot(ctx context.Context, slot uint64) (SlotRecord, error) {
+	header, err := c.getHeader(ctx, strconv.FormatUint(slot, 10))
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return SlotRecord{Slot: slot, Status: SlotMissed}, nil
+	}
+	if err != nil {
+		return SlotRecord{}, err
+	}
 
-	// Safely calculate slot numbers with overflow protection
-	if justifiedEpoch > 0 && justifiedEpoch <= (^uint64(0))/chainConfig.SlotsPerEpoch {
-		info.JustifiedSlot = justifiedEpoch * chainConfig.SlotsPerEpoch
+	record := SlotRecord{Slot: slot, Status: SlotProposed, Root: header.Data.Root}
+	if index, err := strconv.ParseUint(header.Data.Header.Message.ProposerIndex, 10, 64); err == nil {
+		record.Proposer = &ProposerDuty{Slot: slot, ValidatorIndex: index}
 	}
-	if finalizedEpoch > 0 && finalizedEpoch <= (^uint64(0))/chainConfig.SlotsPerEpoch {
-		info.FinalizedSlot = finalizedEpoch * chainConfig.SlotsPerEpoch
+	return record, nil
+}
+
+// epochStartSlot returns the first slot of the epoch, or 0 if the
+// calculation would overflow
+func epochStartSlot(epoch, slotsPerEpoch uint64) uint64 {
+	if epoch > 0 && epoch <= (^uint64(0))/slotsPerEpoch {
+		return epoch * slotsPerEpoch
 	}
+	return 0
+}
 
-	currentTime := time.Now()
-	timeSinceGenesis := currentTime.Sub(chainConfig.GenesisTime)
+// applySlotTiming fills in the wall clock derived slot and epoch fields
+func applySlotTiming(info *ConsensusNodeInfo, chainConfig *ChainConfig, now time.Time) {
+	timeSinceGenesis := now.Sub(chainConfig.GenesisTime)
 
 	// Only calculate current slot if time since genesis is positive
 	if timeSinceGenesis > 0 {
@@ -126,57 +451,19 @@ func (c *ConsensusClient) GetNodeInfo(ctx context.Context) (*ConsensusNodeInfo,
 		slotsUntilNextEpoch := chainConfig.SlotsPerEpoch - slotsInCurrentEpoch
 		info.TimeToNextEpoch = info.TimeToNextSlot + time.Duration((slotsUntilNextEpoch-1)*chainConfig.SecondsPerSlot)*time.Second
 	}
-
-	// Get peer count
-	peerCount, err := c.getPeerCount(ctx)
-	if err == nil {
-		connected, _ := strconv.ParseUint(peerCount.Data.Connected, 10, 64)
-		info.PeerCount = connected
-	}
-
-	// Get node version
-	nodeVersion, err := c.getNodeVersion(ctx)
-	if err == nil {
-		info.NodeVersion = nodeVersion.Data.Version
-	}
-
-	// Get fork info
-	fork, err := c.getFork(ctx)
-	if err == nil {
-		info.CurrentFork = fork.Data.CurrentVersion
-	}
-
-	info.IsConnected = true
-	logger.Info("[%s]: Successfully connected and retrieved node info", c.name)
-	return info, nil
 }
 
+// GetChainConfig returns the chain parameters from the cached network config
 func (c *ConsensusClient) GetChainConfig(ctx context.Context) (*ChainConfig, error) {
-	genesis, err := c.getGenesis(ctx)
+	network, err := c.GetNetworkConfig(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	spec, err := c.getSpec(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	genesisTime, err := strconv.ParseInt(genesis.Data.GenesisTime, 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse genesis time: %w", err)
-	}
-
-	// Extract string values from interface{}
-	secondsPerSlotStr, ok := spec.Data["SECONDS_PER_SLOT"].(string)
-	if !ok {
-		return nil, fmt.Errorf("SECONDS_PER_SLOT is not a string")
-	}
-	slotsPerEpochStr, ok := spec.Data["SLOTS_PER_EPOCH"].(string)
+	secondsPerSlotStr, ok := network.Spec["SECONDS_PER_SLOT"]
 	if !ok {
-		return nil, fmt.Errorf("SLOTS_PER_EPOCH is not a string")
+		return nil, fmt.Errorf("SECONDS_PER_SLOT is missing from the spec")
 	}
-
 	secondsPerSlot, err := strconv.ParseUint(secondsPerSlotStr, 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse SECONDS_PER_SLOT: %w", err)
@@ -185,6 +472,10 @@ func (c *ConsensusClient) GetChainConfig(ctx context.Context) (*ChainConfig, err
 		return nil, fmt.Errorf("SECONDS_PER_SLOT cannot be zero")
 	}
 
+	slotsPerEpochStr, ok := network.Spec["SLOTS_PER_EPOCH"]
+	if !ok {
+		return nil, fmt.Errorf("SLOTS_PER_EPOCH is missing from the spec")
+	}
 	slotsPerEpoch, err := strconv.ParseUint(slotsPerEpochStr, 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse SLOTS_PER_EPOCH: %w", err)
@@ -193,24 +484,103 @@ func (c *ConsensusClient) GetChainConfig(ctx context.Context) (*ChainConfig, err
 		return nil, fmt.Errorf("SLOTS_PER_EPOCH cannot be zero")
 	}
 
+	// Not all nodes need to report these, so fall back to the mainnet values
+	epochsPerSyncCommitteePeriod := uint64(defaultEpochsPerSyncCommitteePeriod)
+	if value, ok := network.Spec["EPOCHS_PER_SYNC_COMMITTEE_PERIOD"]; ok {
+		if parsed, err := strconv.ParseUint(value, 10, 64); err == nil && parsed > 0 {
+			epochsPerSyncCommitteePeriod = parsed
+		}
+	}
+	syncCommitteeSize := uint64(defaultSyncCommitteeSize)
+	if value, ok := network.Spec["SYNC_COMMITTEE_SIZE"]; ok {
+		if parsed, err := strconv.ParseUint(value, 10, 64); err == nil && parsed > 0 {
+			syncCommitteeSize = parsed
+		}
+	}
+
 	return &ChainConfig{
-		SecondsPerSlot: secondsPerSlot,
-		SlotsPerEpoch:  slotsPerEpoch,
-		GenesisTime:    time.Unix(genesisTime, 0),
+		SecondsPerSlot:               secondsPerSlot,
+		SlotsPerEpoch:                slotsPerEpoch,
+		EpochsPerSyncCommitteePeriod: epochsPerSyncCommitteePeriod,
+		SyncCommitteeSize:            syncCommitteeSize,
+		GenesisTime:                  network.GenesisTime,
+		Forks:                        forksFromSpec(network.Spec),
 	}, nil
 }
 
+// statusError is returned for responses with an unexpected HTTP status
+type statusError struct {
+	StatusCode int
+	Path       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d for %s", e.StatusCode, e.Path)
+}
+
 func (c *ConsensusClient) get(ctx context.Context, path string, v any) error {
+	return c.do(ctx, "GET", path, nil, v)
+}
+
+// post sends body encoded as JSON and decodes the response into v
+func (c *ConsensusClient) post(ctx context.Context, path string, body any, v any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	return c.do(ctx, "POST", path, bytes.NewReader(data), v)
+}
+
+// do sends the request and decodes the response into v. A 206 is a node that
+// is syncing but still serving data, so it is decoded like a 200.
+func (c *ConsensusClient) do(ctx context.Context, method, path string, reqBody io.Reader, v any) error {
+	resp, err := c.send(ctx, method, path, "", reqBody)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return &statusError{StatusCode: resp.StatusCode, Path: path}
+	}
+
+	return c.decodeJSON(path, resp.Body, v)
+}
+
+func (c *ConsensusClient) decodeJSON(path string, body []byte, v any) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		logger.Error("Failed to decode response from %s%s: %v", c.endpoint, path, err)
+		logger.Debug("Response body: %s", string(body))
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// response is a beacon API response, whatever its status
+type response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// send sends the request, asking for the accepted media types if set, and
+// returns the response whatever its status
+func (c *ConsensusClient) send(ctx context.Context, method, path, accept string, reqBody io.Reader) (*response, error) {
 	url := fmt.Sprintf("%s%s", c.endpoint, path)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -218,22 +588,11 @@ func (c *ConsensusClient) get(ctx context.Context, path string, v any) error {
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d for %s", resp.StatusCode, path)
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
-
-	if err := json.Unmarshal(body, v); err != nil {
-		logger.Error("Failed to decode response from %s: %v", url, err)
-		logger.Debug("Response body: %s", string(body))
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return nil
+	return &response{StatusCode: resp.StatusCode, ContentType: resp.Header.Get("Content-Type"), Body: body}, nil
 }
 
 func (c *ConsensusClient) getGenesis(ctx context.Context) (*GenesisResponse, error) {
@@ -248,6 +607,12 @@ func (c *ConsensusClient) getHeaders(ctx context.Context) (*HeadersResponse, err
 	return &resp, err
 }
 
+func (c *ConsensusClient) getHeader(ctx context.Context, blockID string) (*HeaderResponse, error) {
+	var resp HeaderResponse
+	err := c.get(ctx, fmt.Sprintf("/eth/v1/beacon/headers/%s", blockID), &resp)
+	return &resp, err
+}
+
 func (c *ConsensusClient) getFinalityCheckpoints(ctx context.Context) (*FinalityCheckpointsResponse, error) {
 	var resp FinalityCheckpointsResponse
 	err := c.get(ctx, "/eth/v1/beacon/states/head/finality_checkpoints", &resp)
@@ -278,8 +643,57 @@ func (c *ConsensusClient) getNodeVersion(ctx context.Context) (*NodeVersionRespo
 	return &resp, err
 }
 
+// getVersion returns the version of the node, fetching it when the cached
+// copy has expired. The expired copy is returned if it cannot be fetched
+// again.
+func (c *ConsensusClient) getVersion(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	version, ok := c.version.get(time.Now())
+	c.mu.Unlock()
+	if ok {
+		return version, nil
+	}
+
+	resp, err := c.getNodeVersion(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		if stale, ok := c.version.stale(); ok {
+			logger.Debug("[%s]: Using expired node version: %v", c.name, err)
+			return stale, nil
+		}
+		return "", err
+	}
+	c.version.set(resp.Data.Version, time.Now())
+	return resp.Data.Version, nil
+}
+
 func (c *ConsensusClient) getFork(ctx context.Context) (*ForkResponse, error) {
 	var resp ForkResponse
 	err := c.get(ctx, "/eth/v1/beacon/states/head/fork", &resp)
 	return &resp, err
 }
+
+// getProposerDuties returns the proposer duties of an epoch and the root of
+// the block they depend on
+func (c *ConsensusClient) getProposerDuties(ctx context.Context, epoch uint64) ([]ProposerDuty, string, error) {
+	var resp ProposerDutiesResponse
+	if err := c.get(ctx, fmt.Sprintf("/eth/v1/validator/duties/proposer/%d", epoch), &resp); err != nil {
+		return nil, "", err
+	}
+
+	duties := make([]ProposerDuty, 0, len(resp.Data))
+	for _, data := range resp.Data {
+		slot, err := strconv.ParseUint(data.Slot, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse duty slot: %w", err)
+		}
+		index, err := strconv.ParseUint(data.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse duty validator index: %w", err)
+		}
+		duties = append(duties, ProposerDuty{Slot: slot, ValidatorIndex: index, Pubkey: data.Pubkey})
+	}
+	return duties, resp.DependentRoot, nil
+}
@@ -17,10 +17,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/watcheth/watcheth/internal/testutil"
 )
 
@@ -60,9 +62,11 @@ func TestConsensusClient_GetChainConfig(t *testing.T) {
 				},
 			},
 			expected: &ChainConfig{
-				SecondsPerSlot: 12,
-				SlotsPerEpoch:  32,
-				GenesisTime:    time.Unix(1606824023, 0),
+				SecondsPerSlot:               12,
+				SlotsPerEpoch:                32,
+				EpochsPerSyncCommitteePeriod: 256,
+				SyncCommitteeSize:            512,
+				GenesisTime:                  time.Unix(1606824023, 0),
 			},
 			expectError: false,
 		},
@@ -136,7 +140,7 @@ func TestConsensusClient_GetChainConfig(t *testing.T) {
 			},
 			expected:    nil,
 			expectError: true,
-			errorMsg:    "SECONDS_PER_SLOT is not a string",
+			errorMsg:    "SECONDS_PER_SLOT is missing from the spec",
 		},
 		{
 			name: "zero SECONDS_PER_SLOT",
@@ -351,6 +355,7 @@ func TestConsensusClient_GetNodeInfo(t *testing.T) {
 			validate: func(t *testing.T, info *ConsensusNodeInfo) {
 				assert.False(t, info.IsConnected)
 				assert.NotNil(t, info.LastError)
+				assert.Error(t, info.Sources.Err(QueryConfig))
 			},
 		},
 		{
@@ -389,6 +394,26 @@ func TestConsensusClient_GetNodeInfo(t *testing.T) {
 				assert.Equal(t, uint64(0), info.PeerCount)
 				assert.Empty(t, info.NodeVersion)
 				assert.Empty(t, info.CurrentFork)
+				assert.Error(t, info.Sources.Err(QueryHeaders))
+				assert.Error(t, info.Sources.Err(QueryPeers))
+				assert.Error(t, info.Sources.Err(QueryVersion))
+				assert.Error(t, info.Sources.Err(QueryFork))
+				assert.NoError(t, info.Sources.Err(QuerySyncing))
+			},
+		},
+		{
+			name: "finality endpoint fails",
+			modifyEndpoints: func(endpoints map[string]struct {
+				Status int
+				Body   string
+			}) {
+				delete(endpoints, "/eth/v1/beacon/states/head/finality_checkpoints")
+			},
+			validate: func(t *testing.T, info *ConsensusNodeInfo) {
+				assert.True(t, info.IsConnected)
+				assert.Equal(t, uint64(150), info.HeadSlot)
+				assert.Equal(t, uint64(0), info.FinalizedSlot)
+				assert.Error(t, info.Sources.Err(QueryFinality))
 			},
 		},
 		{
@@ -439,6 +464,97 @@ func TestConsensusClient_GetNodeInfo(t *testing.T) {
 	}
 }
 
+func TestConsensusClient_GetNodeInfoConcurrent(t *testing.T) {
+	// Each of these takes a while to answer, which a sequential poll would
+	// pay for one after another
+	slow := map[string]string{
+		"/eth/v1/node/syncing":                            testutil.ValidSyncingResponse,
+		"/eth/v1/beacon/headers":                          `{"data": [{"header": {"message": {"slot": "150"}}}]}`,
+		"/eth/v1/beacon/states/head/finality_checkpoints": `{"data": {"current_justified": {"epoch": "3"}, "finalized": {"epoch": "2"}}}`,
+		"/eth/v1/node/peer_count":                         testutil.ValidPeerCountResponse,
+		"/eth/v1/node/version":                            testutil.ValidNodeVersionResponse,
+		"/eth/v1/beacon/states/head/fork":                 `{"data": {"current_version": "0x00000000"}}`,
+	}
+	const delay = 200 * time.Millisecond
+
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			_, _ = w.Write([]byte(`{"data": {"genesis_time": "1606824023"}}`))
+		case "/eth/v1/config/spec":
+			_, _ = w.Write([]byte(testutil.ValidChainConfigResponse))
+		default:
+			body, ok := slow[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			time.Sleep(delay)
+			_, _ = w.Write([]byte(body))
+		}
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	start := time.Now()
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Duration(len(slow)-2)*delay)
+
+	assert.True(t, info.IsConnected)
+	assert.Equal(t, uint64(150), info.HeadSlot)
+	assert.Equal(t, uint64(50), info.PeerCount)
+	assert.Equal(t, uint64(64), info.FinalizedSlot)
+	assert.Equal(t, "0x00000000", info.CurrentFork)
+	assert.Error(t, info.Sources.Err(QueryIdentity))
+}
+
+func TestConsensusClient_GetNodeInfoSources(t *testing.T) {
+	var mu sync.Mutex
+	peersAvailable := true
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			_, _ = w.Write([]byte(`{"data": {"genesis_time": "1606824023"}}`))
+		case "/eth/v1/config/spec":
+			_, _ = w.Write([]byte(testutil.ValidChainConfigResponse))
+		case "/eth/v1/node/syncing":
+			_, _ = w.Write([]byte(testutil.ValidSyncingResponse))
+		case "/eth/v1/node/peer_count":
+			if !peersAvailable {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(testutil.ValidPeerCountResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, info.Sources, QueryPeers)
+	peers := info.Sources[QueryPeers]
+	assert.NoError(t, peers.Error)
+	assert.False(t, peers.LastSuccess.IsZero())
+	assert.Positive(t, peers.Latency)
+
+	mu.Lock()
+	peersAvailable = false
+	mu.Unlock()
+
+	info, err = client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.True(t, info.IsConnected)
+	assert.True(t, info.Sources.Degraded())
+	assert.Contains(t, info.Sources.Failed(), QueryPeers)
+	assert.Error(t, info.Sources.Err(QueryPeers))
+	assert.Equal(t, peers.LastSuccess, info.Sources[QueryPeers].LastSuccess, "last success survives the failure")
+	assert.NoError(t, info.Sources.Err(QuerySyncing))
+}
+
 func TestConsensusClient_TimeCalculations(t *testing.T) {
 	// Create a test server with valid responses
 	endpoints := map[string]struct {
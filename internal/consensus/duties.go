@@ -0,0 +1,374 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// ValidatorSource is implemented by consensus clients that can be configured
+// with our own validators
+type ValidatorSource interface {
+	HasValidators() bool
+}
+
+// DutyProvider is implemented by consensus clients that can look up the
+// duties of the configured validators
+type DutyProvider interface {
+	ValidatorSource
+	GetDuties(ctx context.Context) (*ValidatorDuties, error)
+}
+
+type AttesterDuty struct {
+	Slot           uint64
+	ValidatorIndex uint64
+	CommitteeIndex uint64
+	Pubkey         string
+}
+
+// ValidatorDuties holds the duties of the configured validators for the
+// current and next epoch
+type ValidatorDuties struct {
+	CurrentSlot   uint64
+	CurrentEpoch  uint64
+	SlotsPerEpoch uint64
+	Validators    []uint64 // Resolved validator indices
+	Unresolved    []string // Configured pubkeys not known to the node
+	Proposals     []ProposerDuty
+	Attestations  []AttesterDuty
+	UpdatedAt     time.Time
+}
+
+// NextProposal returns the first proposal at or after the current slot, or
+// nil if there is none in the current or next epoch
+func (d *ValidatorDuties) NextProposal() *ProposerDuty {
+	if d == nil {
+		return nil
+	}
+	for i := range d.Proposals {
+		if d.Proposals[i].Slot >= d.CurrentSlot {
+			return &d.Proposals[i]
+		}
+	}
+	return nil
+}
+
+// UpcomingAttestations returns the attestations at or after the current slot
+func (d *ValidatorDuties) UpcomingAttestations() []AttesterDuty {
+	if d == nil {
+		return nil
+	}
+	var duties []AttesterDuty
+	for _, duty := range d.Attestations {
+		if duty.Slot >= d.CurrentSlot {
+			duties = append(duties, duty)
+		}
+	}
+	return duties
+}
+
+// HasValidators returns true if validators were configured for duty lookups
+func (c *ConsensusClient) HasValidators() bool {
+	return len(c.validators) > 0
+}
+
+// GetDuties fetches the proposer and attester duties of the configured
+// validators for the current and next epoch. The next epoch's duties are left
+// out if they cannot be fetched yet.
+func (c *ConsensusClient) GetDuties(ctx context.Context) (*ValidatorDuties, error) {
+	chainConfig, err := c.chainConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	indices, unresolved, err := c.resolveValidators(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve validators: %w", err)
+	}
+
+	var info ConsensusNodeInfo
+	now := time.Now()
+	applySlotTiming(&info, chainConfig, now)
+
+	duties := &ValidatorDuties{
+		CurrentSlot:   info.CurrentSlot,
+		CurrentEpoch:  info.CurrentEpoch,
+		SlotsPerEpoch: chainConfig.SlotsPerEpoch,
+		Validators:    indices,
+		Unresolved:    unresolved,
+		UpdatedAt:     now,
+	}
+	if len(indices) == 0 {
+		return duties, nil
+	}
+
+	c.mu.Lock()
+	for epoch := range c.duties {
+		if epoch < info.CurrentEpoch {
+			delete(c.duties, epoch)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, epoch := range []uint64{info.CurrentEpoch, info.CurrentEpoch + 1} {
+		epochDuties, err := c.getEpochDuties(ctx, epoch, info.CurrentEpoch, indices)
+		if err != nil {
+			if epoch == info.CurrentEpoch {
+				return nil, err
+			}
+			// Not every node serves proposer duties ahead of the epoch, and
+			// the current epoch's duties are what matter most
+			logger.Debug("[%s]: Failed to get duties for next epoch %d: %v", c.name, epoch, err)
+			continue
+		}
+		duties.Proposals = append(duties.Proposals, epochDuties.proposals...)
+		duties.Attestations = append(duties.Attestations, epochDuties.attestations...)
+	}
+
+	sort.Slice(duties.Proposals, func(i, j int) bool { return duties.Proposals[i].Slot < duties.Proposals[j].Slot })
+	sort.Slice(duties.Attestations, func(i, j int) bool { return duties.Attestations[i].Slot < duties.Attestations[j].Slot })
+	return duties, nil
+}
+
+// epochDuties are the duties of the configured validators in one epoch, with
+// the roots of the blocks they were computed from
+type epochDuties struct {
+	validators       string // Indices the duties were looked up for
+	proposals        []ProposerDuty
+	proposalsRoot    string
+	proposalsFinal   bool // Looked up in the epoch itself, not ahead of it
+	attestations     []AttesterDuty
+	attestationsRoot string
+}
+
+// dutyRoots are the roots of the blocks that duties depend on, as of the head
+// of the event stream
+type dutyRoots struct {
+	epoch    uint64 // Epoch of the head
+	previous string // Attester duties of the head's epoch depend on this
+	current  string // Proposer duties of the head's epoch and attester duties of the next depend on this
+}
+
+// proposer returns the root that the proposer duties of an epoch depend on,
+// or an empty string if not known
+func (r dutyRoots) proposer(epoch uint64) string {
+	if epoch != r.epoch {
+		return ""
+	}
+	return r.current
+}
+
+// attester returns the root that the attester duties of an epoch depend on,
+// or an empty string if not known
+func (r dutyRoots) attester(epoch uint64) string {
+	switch epoch {
+	case r.epoch:
+		return r.previous
+	case r.epoch + 1:
+		return r.current
+	default:
+		return ""
+	}
+}
+
+// rootChanged returns true if duties were computed from another block than
+// the one they are known to depend on. Duties are kept if either root is not
+// known, as not every node reports them.
+func rootChanged(known, dutiesRoot string) bool {
+	return known != "" && dutiesRoot != "" && known != dutiesRoot
+}
+
+// getEpochDuties returns the proposer and attester duties of the validators
+// in an epoch. Duties are cached by epoch and looked up again if the
+// validators change or the block they depend on is no longer the one at the
+// head. Proposer duties looked up ahead of their epoch may still change, so
+// are looked up again until the epoch starts.
+func (c *ConsensusClient) getEpochDuties(ctx context.Context, epoch, currentEpoch uint64, indices []uint64) (epochDuties, error) {
+	key := fmt.Sprint(indices)
+	c.mu.Lock()
+	duties, cached := c.duties[epoch]
+	roots := c.dutyRoots
+	c.mu.Unlock()
+	if !cached || duties.validators != key {
+		duties, cached = epochDuties{validators: key}, false
+	}
+
+	if !cached || !duties.proposalsFinal || rootChanged(roots.proposer(epoch), duties.proposalsRoot) {
+		proposals, root, err := c.getProposerDuties(ctx, epoch)
+		if err != nil {
+			return duties, fmt.Errorf("failed to get proposer duties for epoch %d: %w", epoch, err)
+		}
+		ours := make(map[uint64]bool, len(indices))
+		for _, index := range indices {
+			ours[index] = true
+		}
+		duties.proposals = nil
+		for _, duty := range proposals {
+			if ours[duty.ValidatorIndex] {
+				duties.proposals = append(duties.proposals, duty)
+			}
+		}
+		duties.proposalsRoot = root
+		duties.proposalsFinal = epoch <= currentEpoch
+	}
+
+	if !cached || rootChanged(roots.attester(epoch), duties.attestationsRoot) {
+		attestations, root, err := c.getAttesterDuties(ctx, epoch, indices)
+		if err != nil {
+			return duties, fmt.Errorf("failed to get attester duties for epoch %d: %w", epoch, err)
+		}
+		duties.attestations = attestations
+		duties.attestationsRoot = root
+	}
+
+	c.mu.Lock()
+	if c.duties == nil {
+		c.duties = make(map[uint64]epochDuties)
+	}
+	c.duties[epoch] = duties
+	c.mu.Unlock()
+	return duties, nil
+}
+
+// invalidateDuties forgets the duties of epochs whose proposer duties depend
+// on a block at or after a slot. Caller must hold c.mu.
+func (c *ConsensusClient) invalidateDuties(from uint64) {
+	if c.config == nil {
+		return
+	}
+	for epoch := range c.duties {
+		// Attester duties depend on an earlier block than proposer duties
+		if epochStartSlot(epoch, c.config.SlotsPerEpoch) > from {
+			delete(c.duties, epoch)
+		}
+	}
+}
+
+// chainConfig returns the chain config from the last successful poll, or
+// fetches it if the node has not been polled yet
+func (c *ConsensusClient) chainConfig(ctx context.Context) (*ChainConfig, error) {
+	c.mu.Lock()
+	chainConfig := c.config
+	c.mu.Unlock()
+	if chainConfig != nil {
+		return chainConfig, nil
+	}
+	return c.GetChainConfig(ctx)
+}
+
+// resolveValidators returns the indices of the configured validators, looking
+// up pubkeys on the node. Pubkeys the node does not know yet, e.g. pending
+// deposits, are returned separately and looked up again next time.
+func (c *ConsensusClient) resolveValidators(ctx context.Context) ([]uint64, []string, error) {
+	c.mu.Lock()
+	if c.validatorIndices == nil {
+		c.validatorIndices = make(map[string]uint64)
+	}
+	var lookup []string
+	for _, id := range c.validators {
+		if _, ok := c.validatorIndices[id]; ok {
+			continue
+		}
+		if index, err := strconv.ParseUint(id, 10, 64); err == nil {
+			c.validatorIndices[id] = index
+			continue
+		}
+		lookup = append(lookup, id)
+	}
+	c.mu.Unlock()
+
+	if len(lookup) > 0 {
+		validators, err := c.getValidators(ctx, "head", lookup)
+		if err != nil {
+			return nil, nil, err
+		}
+		c.mu.Lock()
+		for _, validator := range validators {
+			index, err := strconv.ParseUint(validator.Index, 10, 64)
+			if err != nil {
+				continue
+			}
+			for _, id := range lookup {
+				if strings.EqualFold(id, validator.Validator.Pubkey) {
+					c.validatorIndices[id] = index
+				}
+			}
+		}
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var indices []uint64
+	var unresolved []string
+	for _, id := range c.validators {
+		if index, ok := c.validatorIndices[id]; ok {
+			indices = append(indices, index)
+		} else {
+			unresolved = append(unresolved, id)
+		}
+	}
+	if len(unresolved) > 0 {
+		logger.Debug("[%s]: %d configured validators not found on node", c.name, len(unresolved))
+	}
+	return indices, unresolved, nil
+}
+
+// getValidators returns the validators with the given indices or pubkeys in
+// a state, e.g. head or a slot
+func (c *ConsensusClient) getValidators(ctx context.Context, stateID string, ids []string) ([]ValidatorData, error) {
+	var resp ValidatorsResponse
+	path := fmt.Sprintf("/eth/v1/beacon/states/%s/validators?id=%s", stateID, url.QueryEscape(strings.Join(ids, ",")))
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// getAttesterDuties returns the attester duties of validators in an epoch and
+// the root of the block they depend on
+func (c *ConsensusClient) getAttesterDuties(ctx context.Context, epoch uint64, indices []uint64) ([]AttesterDuty, string, error) {
+	body := make([]string, len(indices))
+	for i, index := range indices {
+		body[i] = strconv.FormatUint(index, 10)
+	}
+
+	var resp AttesterDutiesResponse
+	if err := c.post(ctx, fmt.Sprintf("/eth/v1/validator/duties/attester/%d", epoch), body, &resp); err != nil {
+		return nil, "", err
+	}
+
+	duties := make([]AttesterDuty, 0, len(resp.Data))
+	for _, data := range resp.Data {
+		slot, err := strconv.ParseUint(data.Slot, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse duty slot: %w", err)
+		}
+		index, err := strconv.ParseUint(data.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse duty validator index: %w", err)
+		}
+		committee, _ := strconv.ParseUint(data.CommitteeIndex, 10, 64)
+		duties = append(duties, AttesterDuty{Slot: slot, ValidatorIndex: index, CommitteeIndex: committee, Pubkey: data.Pubkey})
+	}
+	return duties, resp.DependentRoot, nil
+}
@@ -0,0 +1,198 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestConsensusClient_GetDuties(t *testing.T) {
+	// Current slot 100, epoch 3
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	pubkey := "0xabcdef"
+
+	var validatorLookups []string
+	dutyLookups := 0
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/eth/v1/validator/duties/") {
+			dutyLookups++
+		}
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/head/validators":
+			validatorLookups = append(validatorLookups, r.URL.Query().Get("id"))
+			_, _ = fmt.Fprintf(w, `{"data": [{"index": "7", "validator": {"pubkey": "%s"}}]}`, pubkey)
+		case "/eth/v1/validator/duties/proposer/3":
+			_, _ = fmt.Fprint(w, `{"data": [
+				{"validator_index": "1", "slot": "99"},
+				{"validator_index": "7", "slot": "110"},
+				{"validator_index": "2", "slot": "111"}
+			]}`)
+		case "/eth/v1/validator/duties/proposer/4":
+			_, _ = fmt.Fprint(w, `{"data": [{"validator_index": "5", "slot": "130"}]}`)
+		case "/eth/v1/validator/duties/attester/3", "/eth/v1/validator/duties/attester/4":
+			assert.Equal(t, http.MethodPost, r.Method)
+			var indices []string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&indices))
+			assert.ElementsMatch(t, []string{"5", "7"}, indices)
+			if r.URL.Path == "/eth/v1/validator/duties/attester/3" {
+				_, _ = fmt.Fprint(w, `{"data": [{"validator_index": "7", "committee_index": "3", "slot": "98"}, {"validator_index": "5", "committee_index": "1", "slot": "104"}]}`)
+			} else {
+				_, _ = fmt.Fprint(w, `{"data": [{"validator_index": "5", "committee_index": "2", "slot": "140"}]}`)
+			}
+		default:
+			testutil.MockHTTPEndpoints(map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": {"genesis_time": "%d"}}`, genesis.Unix())},
+				"/eth/v1/config/spec":    {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+			})(w, r)
+		}
+	})
+
+	client := NewConsensusClient("test", server.URL, WithValidators([]string{"5", pubkey, "0xunknown"}))
+	require.True(t, client.HasValidators())
+
+	duties, err := client.GetDuties(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), duties.CurrentSlot)
+	assert.Equal(t, uint64(3), duties.CurrentEpoch)
+	assert.Equal(t, []uint64{5, 7}, duties.Validators)
+	assert.Equal(t, []string{"0xunknown"}, duties.Unresolved)
+
+	require.Len(t, duties.Proposals, 2)
+	next := duties.NextProposal()
+	require.NotNil(t, next)
+	assert.Equal(t, uint64(7), next.ValidatorIndex)
+	assert.Equal(t, uint64(110), next.Slot)
+
+	require.Len(t, duties.Attestations, 3)
+	upcoming := duties.UpcomingAttestations()
+	require.Len(t, upcoming, 2, "attestations before the current slot are done")
+	assert.Equal(t, uint64(104), upcoming[0].Slot)
+	assert.Equal(t, uint64(1), upcoming[0].CommitteeIndex)
+
+	// Resolved pubkeys are cached, unresolved ones are looked up again
+	assert.Equal(t, 4, dutyLookups)
+	cached, err := client.GetDuties(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{pubkey + ",0xunknown", "0xunknown"}, validatorLookups)
+
+	// Duties are cached by epoch, except proposals ahead of their epoch
+	assert.Equal(t, 5, dutyLookups)
+	assert.Equal(t, duties.Proposals, cached.Proposals)
+	assert.Equal(t, duties.Attestations, cached.Attestations)
+}
+
+func TestConsensusClient_GetDutiesDependentRoots(t *testing.T) {
+	// Current slot 100, epoch 3
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	chainConfig := &ChainConfig{SecondsPerSlot: 12, SlotsPerEpoch: 32, GenesisTime: genesis}
+
+	lookups := make(map[string]int)
+	root := "0xa"
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		lookups[r.URL.Path]++
+		switch r.URL.Path {
+		case "/eth/v1/validator/duties/proposer/3", "/eth/v1/validator/duties/proposer/4":
+			_, _ = fmt.Fprintf(w, `{"dependent_root": "%s", "data": [{"validator_index": "7", "slot": "110"}]}`, root)
+		case "/eth/v1/validator/duties/attester/3", "/eth/v1/validator/duties/attester/4":
+			_, _ = fmt.Fprintf(w, `{"dependent_root": "%s", "data": [{"validator_index": "7", "committee_index": "3", "slot": "104"}]}`, root)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	client := NewConsensusClient("test", server.URL, WithValidators([]string{"7"}))
+	client.storeSnapshot(&ConsensusNodeInfo{Name: "test", IsConnected: true, HeadSlot: 99}, chainConfig)
+
+	_, err := client.GetDuties(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups["/eth/v1/validator/duties/proposer/3"])
+	assert.Equal(t, 1, lookups["/eth/v1/validator/duties/attester/3"])
+
+	// Heads that agree with the duties keep them cached
+	require.NotNil(t, client.applyEvent("head", []byte(`{"slot":"100","previous_duty_dependent_root":"0xa","current_duty_dependent_root":"0xa"}`)))
+	_, err = client.GetDuties(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups["/eth/v1/validator/duties/proposer/3"])
+	assert.Equal(t, 1, lookups["/eth/v1/validator/duties/attester/3"])
+	assert.Equal(t, 1, lookups["/eth/v1/validator/duties/attester/4"])
+	assert.Equal(t, 2, lookups["/eth/v1/validator/duties/proposer/4"], "proposals ahead of the epoch are not final")
+
+	// A reorg of the block the proposals and next attestations depend on
+	root = "0xb"
+	require.NotNil(t, client.applyEvent("head", []byte(`{"slot":"101","previous_duty_dependent_root":"0xa","current_duty_dependent_root":"0xb"}`)))
+	_, err = client.GetDuties(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookups["/eth/v1/validator/duties/proposer/3"])
+	assert.Equal(t, 1, lookups["/eth/v1/validator/duties/attester/3"])
+	assert.Equal(t, 2, lookups["/eth/v1/validator/duties/attester/4"])
+
+	// A reorg seen by polling drops the duties that depend on the reorged slots
+	client.mu.Lock()
+	client.handleReorg(ReorgEvent{Slot: 101, Depth: 4, OldHeadRoot: "0xc", NewHeadRoot: "0xd"})
+	assert.Contains(t, client.duties, uint64(3), "depends on slot 95, before the reorg")
+	assert.NotContains(t, client.duties, uint64(4))
+	client.mu.Unlock()
+}
+
+func TestConsensusClient_GetDutiesWithoutNextEpoch(t *testing.T) {
+	// Current slot 100, epoch 3
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	endpoints := map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/eth/v1/beacon/genesis":              {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": {"genesis_time": "%d"}}`, genesis.Unix())},
+		"/eth/v1/config/spec":                 {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+		"/eth/v1/validator/duties/proposer/3": {Status: http.StatusOK, Body: `{"data": [{"validator_index": "7", "slot": "110"}]}`},
+		"/eth/v1/validator/duties/attester/3": {Status: http.StatusOK, Body: `{"data": [{"validator_index": "7", "committee_index": "3", "slot": "104"}]}`},
+		"/eth/v1/validator/duties/proposer/4": {Status: http.StatusBadRequest, Body: `{"code": 400, "message": "epoch out of range"}`},
+	}
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(endpoints))
+	client := NewConsensusClient("test", server.URL, WithValidators([]string{"7"}))
+
+	duties, err := client.GetDuties(context.Background())
+	require.NoError(t, err)
+	require.Len(t, duties.Proposals, 1)
+	assert.Equal(t, uint64(110), duties.Proposals[0].Slot)
+	require.Len(t, duties.Attestations, 1)
+	assert.Equal(t, uint64(104), duties.Attestations[0].Slot)
+
+	// Without the current epoch's duties there is nothing to show
+	delete(endpoints, "/eth/v1/validator/duties/attester/3")
+	client = NewConsensusClient("test", server.URL, WithValidators([]string{"7"}))
+	_, err = client.GetDuties(context.Background())
+	assert.Error(t, err)
+}
+
+func TestValidatorDuties_NoProposal(t *testing.T) {
+	var duties *ValidatorDuties
+	assert.Nil(t, duties.NextProposal())
+	assert.Empty(t, duties.UpcomingAttestations())
+
+	duties = &ValidatorDuties{CurrentSlot: 50, Proposals: []ProposerDuty{{Slot: 40}}}
+	assert.Nil(t, duties.NextProposal())
+	assert.False(t, NewConsensusClient("test", "http://localhost:5052").HasValidators())
+}
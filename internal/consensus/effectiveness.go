@@ -0,0 +1,187 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EffectivenessProvider is implemented by consensus clients that can work out
+// how well the configured validators attested
+type EffectivenessProvider interface {
+	ValidatorSource
+	GetAttestationEffectiveness(ctx context.Context) (*AttestationEffectiveness, error)
+}
+
+// AttestationPerformance is how a single validator attested in an epoch,
+// derived from its rewards. A vote earns a reward only if it was correct and
+// included in time.
+type AttestationPerformance struct {
+	ValidatorIndex uint64
+	Head           bool
+	Target         bool
+	Source         bool
+	Reward         int64 // Gwei, including penalties
+	IdealReward    int64 // Gwei, for a perfect attestation with the same effective balance
+}
+
+// AttestationEffectiveness summarises the attestations of the configured
+// validators in a completed epoch
+type AttestationEffectiveness struct {
+	Epoch         uint64
+	Validators    []AttestationPerformance // Ordered by index
+	HeadCorrect   int
+	TargetCorrect int
+	SourceCorrect int
+	Reward        int64 // Gwei
+	IdealReward   int64 // Gwei
+	UpdatedAt     time.Time
+}
+
+// Effectiveness returns the reward earned as a percentage of the ideal reward
+func (e *AttestationEffectiveness) Effectiveness() float64 {
+	if e == nil || e.IdealReward <= 0 {
+		return 0
+	}
+	return float64(e.Reward) / float64(e.IdealReward) * 100
+}
+
+// effectivenessEpoch returns the most recent epoch whose attestation rewards
+// are final. Attestations for an epoch can be included until the end of the
+// next one, so rewards are only known once that has been processed.
+func effectivenessEpoch(currentEpoch uint64) (uint64, bool) {
+	if currentEpoch < 2 {
+		return 0, false
+	}
+	return currentEpoch - 2, true
+}
+
+// GetAttestationEffectiveness fetches the attestation rewards of the
+// configured validators for the most recent completed epoch. Results are
+// cached, as they do not change once the epoch has been processed.
+func (c *ConsensusClient) GetAttestationEffectiveness(ctx context.Context) (*AttestationEffectiveness, error) {
+	chainConfig, err := c.chainConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var info ConsensusNodeInfo
+	applySlotTiming(&info, chainConfig, time.Now())
+	epoch, ok := effectivenessEpoch(info.CurrentEpoch)
+	if !ok {
+		return nil, fmt.Errorf("no completed epoch yet")
+	}
+
+	c.mu.Lock()
+	cached := c.effectiveness
+	c.mu.Unlock()
+	if cached != nil && cached.Epoch == epoch {
+		return cached, nil
+	}
+
+	// Effective balances select the ideal reward, and only active validators
+	// are expected to attest
+	validators, err := c.getValidators(ctx, "head", c.validators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators: %w", err)
+	}
+	effectiveBalances := make(map[string]string, len(validators))
+	var ids []string
+	for _, validator := range validators {
+		if !strings.HasPrefix(validator.Status, "active") {
+			continue
+		}
+		effectiveBalances[validator.Index] = validator.Validator.EffectiveBalance
+		ids = append(ids, validator.Index)
+	}
+
+	effectiveness := &AttestationEffectiveness{Epoch: epoch, UpdatedAt: time.Now()}
+	if len(ids) == 0 {
+		return effectiveness, nil
+	}
+
+	var resp AttestationRewardsResponse
+	if err := c.post(ctx, fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), ids, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get attestation rewards for epoch %d: %w", epoch, err)
+	}
+
+	ideal := make(map[string]int64, len(resp.Data.IdealRewards))
+	idealHead := make(map[string]int64, len(resp.Data.IdealRewards))
+	for _, rewards := range resp.Data.IdealRewards {
+		ideal[rewards.EffectiveBalance] = parseGwei(rewards.Head) + parseGwei(rewards.Target) +
+			parseGwei(rewards.Source) + parseGwei(rewards.InclusionDelay)
+		idealHead[rewards.EffectiveBalance] = parseGwei(rewards.Head)
+	}
+
+	for _, rewards := range resp.Data.TotalRewards {
+		index, err := strconv.ParseUint(rewards.ValidatorIndex, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse validator index: %w", err)
+		}
+		effectiveBalance := effectiveBalances[rewards.ValidatorIndex]
+		performance := AttestationPerformance{
+			ValidatorIndex: index,
+			Reward: parseGwei(rewards.Head) + parseGwei(rewards.Target) + parseGwei(rewards.Source) +
+				parseGwei(rewards.InclusionDelay) + parseGwei(rewards.Inactivity),
+			IdealReward: ideal[effectiveBalance],
+		}
+		performance.Head, performance.Target, performance.Source = attestationCorrect(rewards, idealHead[effectiveBalance])
+
+		effectiveness.Validators = append(effectiveness.Validators, performance)
+		effectiveness.Reward += performance.Reward
+		effectiveness.IdealReward += performance.IdealReward
+		if performance.Head {
+			effectiveness.HeadCorrect++
+		}
+		if performance.Target {
+			effectiveness.TargetCorrect++
+		}
+		if performance.Source {
+			effectiveness.SourceCorrect++
+		}
+	}
+	sort.Slice(effectiveness.Validators, func(i, j int) bool {
+		return effectiveness.Validators[i].ValidatorIndex < effectiveness.Validators[j].ValidatorIndex
+	})
+
+	c.mu.Lock()
+	c.effectiveness = effectiveness
+	c.mu.Unlock()
+	return effectiveness, nil
+}
+
+// attestationCorrect decides which votes of a validator's attestation were
+// correct from the sign of their rewards. Missed source and target votes are
+// penalised, so zero counts as correct: it is what a correct vote earns during
+// an inactivity leak. A missed head vote is not penalised, so the head vote is
+// only known to be correct from a reward, or when correct head votes earn
+// nothing either and the target vote, which a correct head vote needs, was
+// correct.
+func attestationCorrect(rewards AttestationRewards, idealHead int64) (head, target, source bool) {
+	source = parseGwei(rewards.Source) >= 0
+	target = parseGwei(rewards.Target) >= 0
+	head = parseGwei(rewards.Head) > 0 || (idealHead == 0 && target)
+	return head, target, source
+}
+
+// parseGwei parses a signed Gwei amount, treating missing values as zero
+func parseGwei(value string) int64 {
+	gwei, _ := strconv.ParseInt(value, 10, 64)
+	return gwei
+}
@@ -0,0 +1,154 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestEffectivenessEpoch(t *testing.T) {
+	_, ok := effectivenessEpoch(1)
+	assert.False(t, ok)
+	epoch, ok := effectivenessEpoch(2)
+	require.True(t, ok)
+	assert.Equal(t, uint64(0), epoch)
+	epoch, ok = effectivenessEpoch(10)
+	require.True(t, ok)
+	assert.Equal(t, uint64(8), epoch)
+}
+
+func TestConsensusClient_GetAttestationEffectiveness(t *testing.T) {
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	rewardRequests := 0
+
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/states/head/validators":
+			_, _ = w.Write([]byte(`{"data": [
+				{"index": "9", "balance": "31000000000", "status": "active_ongoing", "validator": {"pubkey": "0xbb", "effective_balance": "31000000000"}},
+				{"index": "5", "balance": "32000000000", "status": "active_ongoing", "validator": {"pubkey": "0xaa", "effective_balance": "32000000000"}},
+				{"index": "12", "balance": "32000000000", "status": "pending_queued", "validator": {"pubkey": "0xcc", "effective_balance": "32000000000"}}
+			]}`))
+			return
+		case "/eth/v1/beacon/rewards/attestations/1":
+			rewardRequests++
+			assert.Equal(t, http.MethodPost, r.Method)
+			var ids []string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&ids))
+			assert.ElementsMatch(t, []string{"5", "9"}, ids, "pending validators are not expected to attest")
+			_, _ = w.Write([]byte(`{"data": {
+				"ideal_rewards": [
+					{"effective_balance": "31000000000", "head": "1000", "target": "3000", "source": "2000", "inactivity": "0"},
+					{"effective_balance": "32000000000", "head": "1100", "target": "3100", "source": "2100", "inactivity": "0"}
+				],
+				"total_rewards": [
+					{"validator_index": "9", "head": "0", "target": "-3000", "source": "-2000", "inactivity": "0"},
+					{"validator_index": "5", "head": "1100", "target": "3100", "source": "2100", "inactivity": "0"}
+				]
+			}}`))
+			return
+		}
+		testutil.MockHTTPEndpoints(map[string]struct {
+			Status int
+			Body   string
+		}{
+			"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": {"genesis_time": "%d"}}`, genesis.Unix())},
+			"/eth/v1/config/spec":    {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+		})(w, r)
+	})
+
+	client := NewConsensusClient("test", server.URL, WithValidators([]string{"5", "9", "0xcc"}))
+	effectiveness, err := client.GetAttestationEffectiveness(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1), effectiveness.Epoch)
+	assert.Equal(t, []AttestationPerformance{
+		{ValidatorIndex: 5, Head: true, Target: true, Source: true, Reward: 6300, IdealReward: 6300},
+		{ValidatorIndex: 9, Reward: -5000, IdealReward: 6000},
+	}, effectiveness.Validators)
+	assert.Equal(t, 1, effectiveness.HeadCorrect)
+	assert.Equal(t, 1, effectiveness.TargetCorrect)
+	assert.Equal(t, 1, effectiveness.SourceCorrect)
+	assert.Equal(t, int64(1300), effectiveness.Reward)
+	assert.Equal(t, int64(12300), effectiveness.IdealReward)
+	assert.InDelta(t, 10.57, effectiveness.Effectiveness(), 0.01)
+
+	// Rewards for a completed epoch do not change
+	_, err = client.GetAttestationEffectiveness(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, rewardRequests)
+}
+
+func TestAttestationCorrect(t *testing.T) {
+	tests := []struct {
+		name      string
+		rewards   AttestationRewards
+		idealHead int64
+		expected  [3]bool // Head, target, source
+	}{
+		{
+			name:      "all correct",
+			rewards:   AttestationRewards{Head: "1100", Target: "3100", Source: "2100"},
+			idealHead: 1100,
+			expected:  [3]bool{true, true, true},
+		},
+		{
+			name:      "missed",
+			rewards:   AttestationRewards{Head: "0", Target: "-3100", Source: "-2100"},
+			idealHead: 1100,
+		},
+		{
+			name:      "wrong head",
+			rewards:   AttestationRewards{Head: "0", Target: "3100", Source: "2100"},
+			idealHead: 1100,
+			expected:  [3]bool{false, true, true},
+		},
+		{
+			name:     "correct during an inactivity leak",
+			rewards:  AttestationRewards{Head: "0", Target: "0", Source: "0", Inactivity: "0"},
+			expected: [3]bool{true, true, true},
+		},
+		{
+			name:     "wrong target during an inactivity leak",
+			rewards:  AttestationRewards{Head: "0", Target: "-3100", Source: "0", Inactivity: "-50"},
+			expected: [3]bool{false, false, true},
+		},
+		{
+			name:    "missed during an inactivity leak",
+			rewards: AttestationRewards{Head: "0", Target: "-3100", Source: "-2100", Inactivity: "-50"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head, target, source := attestationCorrect(tt.rewards, tt.idealHead)
+			assert.Equal(t, tt.expected, [3]bool{head, target, source})
+		})
+	}
+}
+
+func TestAttestationEffectiveness_NoIdealReward(t *testing.T) {
+	var effectiveness *AttestationEffectiveness
+	assert.Zero(t, effectiveness.Effectiveness())
+	assert.Zero(t, (&AttestationEffectiveness{Reward: 100}).Effectiveness())
+}
@@ -0,0 +1,267 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// Topics subscribed to on the beacon node event stream
+var eventTopics = []string{"head", "finalized_checkpoint", "chain_reorg"}
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+	maxEventSize      = 1024 * 1024
+)
+
+// EventStreamer is implemented by consensus clients that can push node
+// updates from the beacon node event stream as they arrive
+type EventStreamer interface {
+	StreamsEvents() bool
+	StreamEvents(ctx context.Context, updates chan<- *ConsensusNodeInfo)
+}
+
+// StreamsEvents returns true if the client was configured to use the event stream
+func (c *ConsensusClient) StreamsEvents() bool {
+	return c.streamEvents
+}
+
+// StreamEvents subscribes to the beacon node event stream and sends an updated
+// node info snapshot for every event that changes it. The stream is
+// reconnected with exponential backoff until the context is cancelled; while
+// it is down the caller is expected to fall back to polling GetNodeInfo.
+func (c *ConsensusClient) StreamEvents(ctx context.Context, updates chan<- *ConsensusNodeInfo) {
+	delay := minReconnectDelay
+	for {
+		connected, err := c.consumeEvents(ctx, updates)
+		c.sendUpdate(ctx, updates, c.setStreaming(false))
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			delay = minReconnectDelay
+		}
+
+		logger.Warn("[%s]: Event stream disconnected, retrying in %s: %v", c.name, delay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// consumeEvents reads the event stream until it fails, returning whether the
+// subscription was established at all
+func (c *ConsensusClient) consumeEvents(ctx context.Context, updates chan<- *ConsensusNodeInfo) (bool, error) {
+	path := fmt.Sprintf("/eth/v1/events?topics=%s", strings.Join(eventTopics, ","))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.endpoint+path, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.streamClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Debug("Failed to close event stream body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("HTTP %d for %s", resp.StatusCode, path)
+	}
+
+	logger.Info("[%s]: Subscribed to event stream", c.name)
+	c.sendUpdate(ctx, updates, c.setStreaming(true))
+
+	err = readEvents(resp.Body, func(topic string, data []byte) {
+		c.sendUpdate(ctx, updates, c.applyEvent(topic, data))
+	})
+	return true, err
+}
+
+func (c *ConsensusClient) sendUpdate(ctx context.Context, updates chan<- *ConsensusNodeInfo, info *ConsensusNodeInfo) {
+	if info == nil {
+		return
+	}
+	select {
+	case updates <- info:
+	case <-ctx.Done():
+	}
+}
+
+// setStreaming records the stream state and returns an updated snapshot, or
+// nil if the node has not been polled successfully yet
+func (c *ConsensusClient) setStreaming(streaming bool) *ConsensusNodeInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.streaming = streaming
+	if !streaming {
+		// Only kept up to date by head events
+		c.dutyRoots = dutyRoots{}
+	}
+	if c.latest == nil {
+		return nil
+	}
+	c.latest.EventStream = streaming
+	info := *c.latest
+	return &info
+}
+
+// applyEvent applies a single event to the latest snapshot and returns the
+// updated copy, or nil if the event did not change anything
+func (c *ConsensusClient) applyEvent(topic string, data []byte) *ConsensusNodeInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.latest == nil || c.config == nil {
+		return nil
+	}
+	info := *c.latest
+
+	switch topic {
+	case "head":
+		var event HeadEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			logger.Debug("[%s]: Failed to decode head event: %v", c.name, err)
+			return nil
+		}
+		slot, err := strconv.ParseUint(event.Slot, 10, 64)
+		if err != nil {
+			return nil
+		}
+		info.HeadSlot = slot
+		info.HeadRoot = event.Block
+		info.IsOptimistic = event.ExecutionOptimistic
+		c.dutyRoots = dutyRoots{
+			epoch:    slot / c.config.SlotsPerEpoch,
+			previous: event.PreviousDutyDependentRoot,
+			current:  event.CurrentDutyDependentRoot,
+		}
+		c.arrivals.observe(slot, time.Now(), false, c.config)
+		// Only the head is known to be canonical, as blocks are imported
+		// whether or not they end up orphaned. Slots the head skipped over
+		// are checked against the canonical headers when polled.
+		c.slots.record(SlotRecord{Slot: slot, Status: SlotProposed, Root: event.Block})
+	case "finalized_checkpoint":
+		var event FinalizedCheckpointEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			logger.Debug("[%s]: Failed to decode finalized checkpoint event: %v", c.name, err)
+			return nil
+		}
+		epoch, err := strconv.ParseUint(event.Epoch, 10, 64)
+		if err != nil {
+			return nil
+		}
+		info.FinalizedEpoch = epoch
+		info.FinalizedSlot = epochStartSlot(epoch, c.config.SlotsPerEpoch)
+		info.FinalizedRoot = event.Block
+	case "chain_reorg":
+		var event ChainReorgEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			logger.Debug("[%s]: Failed to decode chain reorg event: %v", c.name, err)
+			return nil
+		}
+		slot, _ := strconv.ParseUint(event.Slot, 10, 64)
+		depth, _ := strconv.ParseUint(event.Depth, 10, 64)
+		reorg := ReorgEvent{
+			Slot:        slot,
+			Depth:       depth,
+			OldHeadRoot: event.OldHeadBlock,
+			NewHeadRoot: event.NewHeadBlock,
+			DetectedAt:  time.Now(),
+		}
+		if !c.heads.record(reorg) {
+			return nil
+		}
+		c.handleReorg(reorg)
+		c.heads.apply(&info)
+	default:
+		logger.Debug("[%s]: Received %s event", c.name, topic)
+		return nil
+	}
+
+	now := time.Now()
+	applySlotTiming(&info, c.config, now)
+	info.SyncDistance = 0
+	if info.CurrentSlot > info.HeadSlot {
+		info.SyncDistance = info.CurrentSlot - info.HeadSlot
+	}
+	info.LastUpdate = now
+	info.EventStream = true
+	c.slots.apply(&info, c.config.SlotsPerEpoch)
+	c.arrivals.apply(&info, c.config)
+
+	snapshot := info
+	c.latest = &snapshot
+	return &info
+}
+
+// readEvents parses a server-sent event stream, calling handle for each
+// complete event until the stream ends or fails
+func readEvents(r io.Reader, handle func(topic string, data []byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxEventSize)
+
+	var topic string
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				handle(topic, data.Bytes())
+			}
+			topic = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment, used by some nodes as a keep-alive
+		case strings.HasPrefix(line, "event:"):
+			topic = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.New("event stream closed")
+}
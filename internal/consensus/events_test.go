@@ -0,0 +1,148 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestReadEvents(t *testing.T) {
+	stream := ": keep-alive\n" +
+		"event: head\n" +
+		"data: {\"slot\":\"10\"}\n" +
+		"\n" +
+		"event: finalized_checkpoint\n" +
+		"data: {\"epoch\":\n" +
+		"data: \"2\"}\n" +
+		"\n"
+
+	type event struct {
+		topic string
+		data  string
+	}
+	var events []event
+	err := readEvents(strings.NewReader(stream), func(topic string, data []byte) {
+		events = append(events, event{topic: topic, data: string(data)})
+	})
+
+	assert.EqualError(t, err, "event stream closed")
+	assert.Equal(t, []event{
+		{topic: "head", data: `{"slot":"10"}`},
+		{topic: "finalized_checkpoint", data: "{\"epoch\":\n\"2\"}"},
+	}, events)
+}
+
+func TestConsensusClient_applyEvent(t *testing.T) {
+	client := NewConsensusClient("test", "http://localhost:5052")
+
+	// Events are ignored until the node has been polled
+	assert.Nil(t, client.applyEvent("head", []byte(`{"slot":"100"}`)))
+
+	client.storeSnapshot(&ConsensusNodeInfo{Name: "test", IsConnected: true, HeadSlot: 99}, &ChainConfig{
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+		GenesisTime:    time.Now().Add(-100 * 12 * time.Second),
+	})
+
+	info := client.applyEvent("head", []byte(`{"slot":"100","execution_optimistic":true}`))
+	require.NotNil(t, info)
+	assert.Equal(t, uint64(100), info.HeadSlot)
+	assert.True(t, info.IsOptimistic)
+	assert.True(t, info.EventStream)
+	assert.Equal(t, uint64(100), info.CurrentSlot)
+	assert.Equal(t, uint64(0), info.SyncDistance)
+
+	info = client.applyEvent("finalized_checkpoint", []byte(`{"epoch":"2"}`))
+	require.NotNil(t, info)
+	assert.Equal(t, uint64(2), info.FinalizedEpoch)
+	assert.Equal(t, uint64(64), info.FinalizedSlot)
+	assert.Equal(t, uint64(100), info.HeadSlot, "earlier events should be retained")
+
+	// Imported blocks may be orphaned, so only the head marks a slot proposed
+	assert.Nil(t, client.applyEvent("block", []byte(`{"slot":"101","block":"0x65"}`)))
+	info = client.applyEvent("head", []byte(`{"slot":"100","block":"0x64"}`))
+	require.NotNil(t, info)
+	require.Len(t, info.SlotHistory, trackedEpochs)
+	current := info.SlotHistory[len(info.SlotHistory)-1]
+	assert.Equal(t, uint64(3), current.Epoch)
+	assert.Equal(t, SlotProposed, current.Slots[100-96].Status)
+	assert.Equal(t, "0x64", current.Slots[100-96].Root)
+	assert.Equal(t, SlotPending, current.Slots[101-96].Status)
+
+	assert.Nil(t, client.applyEvent("head", []byte(`invalid`)))
+	assert.Nil(t, client.applyEvent("voluntary_exit", []byte(`{}`)))
+}
+
+func TestConsensusClient_StreamEvents(t *testing.T) {
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eth/v1/events" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "head,finalized_checkpoint,chain_reorg", r.URL.Query().Get("topics"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "event: head\ndata: {\"slot\":\"100\"}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	client := NewConsensusClient("test", server.URL, WithEventStream(true))
+	assert.True(t, client.StreamsEvents())
+	client.storeSnapshot(&ConsensusNodeInfo{Name: "test", IsConnected: true}, &ChainConfig{
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+		GenesisTime:    time.Now().Add(-time.Hour),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := make(chan *ConsensusNodeInfo, 4)
+	done := make(chan struct{})
+	go func() {
+		client.StreamEvents(ctx, updates)
+		close(done)
+	}()
+
+	// First update marks the stream as connected, the second carries the head
+	for _, check := range []func(*ConsensusNodeInfo){
+		func(info *ConsensusNodeInfo) { assert.True(t, info.EventStream) },
+		func(info *ConsensusNodeInfo) { assert.Equal(t, uint64(100), info.HeadSlot) },
+	} {
+		select {
+		case info := <-updates:
+			check(info)
+		case <-time.After(time.Second):
+			t.Fatal("Timeout waiting for event update")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("StreamEvents did not stop after cancellation")
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// ScheduledFork is a fork in a node's fork schedule
+type ScheduledFork struct {
+	Name       string // e.g. Deneb, empty if the version is not in the spec
+	Version    string
+	Epoch      uint64
+	Activation time.Time
+}
+
+// DisplayName returns the fork name, or its version if the name is not known
+func (f ScheduledFork) DisplayName() string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.Version
+}
+
+// ForkName returns the name of the fork with the given version, or an empty
+// string if the version is not in the spec
+func (c *ChainConfig) ForkName(version string) string {
+	for _, fork := range c.Forks {
+		if strings.EqualFold(fork.Version, version) {
+			return fork.Name
+		}
+	}
+	return ""
+}
+
+// ForkEpoch returns the epoch of the fork with the given name, and false if
+// the fork is not scheduled
+func (c *ChainConfig) ForkEpoch(name string) (uint64, bool) {
+	for _, fork := range c.Forks {
+		if strings.EqualFold(fork.Name, name) {
+			return fork.Epoch, true
+		}
+	}
+	return 0, false
+}
+
+// EpochTime returns the time at which the epoch starts
+func (c *ChainConfig) EpochTime(epoch uint64) time.Time {
+	seconds := epoch * c.SlotsPerEpoch * c.SecondsPerSlot
+	if c.SlotsPerEpoch > 0 && c.SecondsPerSlot > 0 && seconds/c.SecondsPerSlot/c.SlotsPerEpoch != epoch {
+		return time.Time{}
+	}
+	return c.GenesisTime.Add(time.Duration(seconds) * time.Second)
+}
+
+// forksFromSpec returns the scheduled forks in the spec, from the genesis
+// fork and each <NAME>_FORK_VERSION with its <NAME>_FORK_EPOCH. Forks that
+// are not scheduled yet, with the far future epoch, are left out.
+func forksFromSpec(spec map[string]string) []ScheduledFork {
+	var forks []ScheduledFork
+	if version, ok := spec["GENESIS_FORK_VERSION"]; ok {
+		forks = append(forks, ScheduledFork{Name: "Phase0", Version: version})
+	}
+	for key, value := range spec {
+		name, ok := strings.CutSuffix(key, "_FORK_VERSION")
+		if !ok || name == "GENESIS" {
+			continue
+		}
+		epochValue, ok := spec[name+"_FORK_EPOCH"]
+		if !ok {
+			continue
+		}
+		epoch, err := strconv.ParseUint(epochValue, 10, 64)
+		if err != nil || epoch == math.MaxUint64 {
+			continue
+		}
+		forks = append(forks, ScheduledFork{
+			Name:    strings.ToUpper(name[:1]) + strings.ToLower(name[1:]),
+			Version: value,
+			Epoch:   epoch,
+		})
+	}
+	sortForks(forks)
+	return forks
+}
+
+// sortForks orders forks by epoch, then by version so that forks at the same
+// epoch, e.g. several at genesis on a test network, have a stable order
+func sortForks(forks []ScheduledFork) {
+	sort.Slice(forks, func(i, j int) bool {
+		if forks[i].Epoch != forks[j].Epoch {
+			return forks[i].Epoch < forks[j].Epoch
+		}
+		return forks[i].Version < forks[j].Version
+	})
+}
+
+// getForkSchedule returns the fork schedule of the node, fetching it when the
+// cached copy has expired. The expired copy is returned if it cannot be
+// fetched again.
+func (c *ConsensusClient) getForkSchedule(ctx context.Context, chainConfig *ChainConfig) ([]ScheduledFork, error) {
+	c.mu.Lock()
+	forks, ok := c.schedule.get(time.Now())
+	c.mu.Unlock()
+	if ok {
+		return forks, nil
+	}
+
+	forks, err := c.fetchForkSchedule(ctx, chainConfig)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		if stale, ok := c.schedule.stale(); ok {
+			logger.Debug("[%s]: Using expired fork schedule: %v", c.name, err)
+			return stale, nil
+		}
+		return nil, err
+	}
+	c.schedule.set(forks, time.Now())
+	return forks, nil
+}
+
+// fetchForkSchedule fetches the fork schedule of the node, naming forks from
+// the spec. Nodes that do not serve the schedule fall back to the spec.
+func (c *ConsensusClient) fetchForkSchedule(ctx context.Context, chainConfig *ChainConfig) ([]ScheduledFork, error) {
+	var resp ForkScheduleResponse
+	var forks []ScheduledFork
+	err := c.get(ctx, "/eth/v1/config/fork_schedule", &resp)
+	var statusErr *statusError
+	switch {
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		forks = append(forks, chainConfig.Forks...)
+	case err != nil:
+		return nil, err
+	default:
+		for _, data := range resp.Data {
+			epoch, err := strconv.ParseUint(data.Epoch, 10, 64)
+			if err != nil || epoch == math.MaxUint64 {
+				continue
+			}
+			forks = append(forks, ScheduledFork{
+				Name:    chainConfig.ForkName(data.CurrentVersion),
+				Version: data.CurrentVersion,
+				Epoch:   epoch,
+			})
+		}
+	}
+
+	sortForks(forks)
+	for i := range forks {
+		forks[i].Activation = chainConfig.EpochTime(forks[i].Epoch)
+	}
+	return forks, nil
+}
+
+// applyForks fills in the fork schedule, the name of the current fork and the
+// next scheduled fork
+func applyForks(info *ConsensusNodeInfo, forks []ScheduledFork, chainConfig *ChainConfig) {
+	info.ForkSchedule = forks
+	info.NextFork = nil
+	for i := range forks {
+		if forks[i].Epoch > info.CurrentEpoch {
+			next := forks[i]
+			info.NextFork = &next
+			break
+		}
+	}
+
+	info.CurrentForkName = ""
+	if info.CurrentFork != "" {
+		info.CurrentForkName = chainConfig.ForkName(info.CurrentFork)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func testForkSpec() map[string]string {
+	return map[string]string{
+		"GENESIS_FORK_VERSION": "0x00000000",
+		"ALTAIR_FORK_VERSION":  "0x01000000",
+		"ALTAIR_FORK_EPOCH":    "74240",
+		"DENEB_FORK_VERSION":   "0x04000000",
+		"DENEB_FORK_EPOCH":     "269568",
+		"ELECTRA_FORK_VERSION": "0x05000000",
+		"ELECTRA_FORK_EPOCH":   "364032",
+		"FULU_FORK_VERSION":    "0x06000000",
+		"FULU_FORK_EPOCH":      "18446744073709551615",
+		"SLOTS_PER_EPOCH":      "32",
+	}
+}
+
+func TestForksFromSpec(t *testing.T) {
+	forks := forksFromSpec(testForkSpec())
+	require.Len(t, forks, 4, "unscheduled forks are left out")
+	assert.Equal(t, ScheduledFork{Name: "Phase0", Version: "0x00000000"}, forks[0])
+	assert.Equal(t, ScheduledFork{Name: "Altair", Version: "0x01000000", Epoch: 74240}, forks[1])
+	assert.Equal(t, "Electra", forks[3].Name)
+
+	chainConfig := &ChainConfig{Forks: forks}
+	assert.Equal(t, "Deneb", chainConfig.ForkName("0x04000000"))
+	assert.Equal(t, "", chainConfig.ForkName("0x07000000"))
+}
+
+func TestConsensusClient_GetForkSchedule(t *testing.T) {
+	chainConfig := &ChainConfig{
+		SecondsPerSlot: 12,
+		SlotsPerEpoch:  32,
+		GenesisTime:    time.Unix(1606824023, 0),
+		Forks:          forksFromSpec(testForkSpec()),
+	}
+
+	t.Run("from endpoint", func(t *testing.T) {
+		server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(map[string]struct {
+			Status int
+			Body   string
+		}{
+			"/eth/v1/config/fork_schedule": {Status: http.StatusOK, Body: `{"data": [
+				{"previous_version": "0x04000000", "current_version": "0x05000000", "epoch": "364032"},
+				{"previous_version": "0x05000000", "current_version": "0x06000000", "epoch": "411392"},
+				{"previous_version": "0x00000000", "current_version": "0x00000000", "epoch": "0"}
+			]}`},
+		}))
+
+		client := NewConsensusClient("test", server.URL)
+		forks, err := client.getForkSchedule(context.Background(), chainConfig)
+		require.NoError(t, err)
+		require.Len(t, forks, 3)
+		assert.Equal(t, "Phase0", forks[0].Name)
+		assert.Equal(t, "", forks[2].Name, "not in the spec")
+		assert.Equal(t, "0x06000000", forks[2].DisplayName())
+		assert.Equal(t, chainConfig.GenesisTime.Add(411392*32*12*time.Second), forks[2].Activation)
+
+		info := &ConsensusNodeInfo{CurrentEpoch: 400000, CurrentFork: "0x05000000"}
+		applyForks(info, forks, chainConfig)
+		assert.Equal(t, "Electra", info.CurrentForkName)
+		require.NotNil(t, info.NextFork)
+		assert.Equal(t, uint64(411392), info.NextFork.Epoch)
+
+		info = &ConsensusNodeInfo{CurrentEpoch: 411392, CurrentFork: "0x06000000"}
+		applyForks(info, forks, chainConfig)
+		assert.Nil(t, info.NextFork)
+	})
+
+	t.Run("falls back to spec", func(t *testing.T) {
+		server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(nil))
+
+		client := NewConsensusClient("test", server.URL)
+		forks, err := client.getForkSchedule(context.Background(), chainConfig)
+		require.NoError(t, err)
+		require.Len(t, forks, 4)
+		assert.Equal(t, "Electra", forks[3].Name)
+		assert.False(t, forks[3].Activation.IsZero())
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		requests := 0
+		status := http.StatusOK
+		server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"data": [{"current_version": "0x05000000", "epoch": "364032"}]}`))
+		})
+
+		// A failure is not taken for an empty schedule
+		status = http.StatusInternalServerError
+		client := NewConsensusClient("test", server.URL)
+		_, err := client.getForkSchedule(context.Background(), chainConfig)
+		assert.Error(t, err)
+
+		status = http.StatusOK
+		forks, err := client.getForkSchedule(context.Background(), chainConfig)
+		require.NoError(t, err)
+		require.Len(t, forks, 1)
+		_, err = client.getForkSchedule(context.Background(), chainConfig)
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests)
+
+		// The expired schedule is kept while it cannot be fetched
+		client.schedule.fetched = time.Now().Add(-staticDataTTL)
+		status = http.StatusInternalServerError
+		forks, err = client.getForkSchedule(context.Background(), chainConfig)
+		require.NoError(t, err)
+		assert.Len(t, forks, 1)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+)
+
+// HealthState is the health of a node as reported by /eth/v1/node/health
+type HealthState int
+
+const (
+	// HealthUnknown means the health endpoint could not be reached or returned
+	// an unexpected status
+	HealthUnknown HealthState = iota
+	// HealthReady means the node is synced and ready (200)
+	HealthReady
+	// HealthSyncing means the node is syncing but can serve incomplete data (206)
+	HealthSyncing
+	// HealthNotReady means the node is not initialized or has issues (503)
+	HealthNotReady
+)
+
+// String returns a short description of the health state
+func (h HealthState) String() string {
+	switch h {
+	case HealthReady:
+		return "ready"
+	case HealthSyncing:
+		return "syncing"
+	case HealthNotReady:
+		return "not ready"
+	default:
+		return "unknown"
+	}
+}
+
+// getHealth calls the health endpoint, which reports through the status code
+// alone
+func (c *ConsensusClient) getHealth(ctx context.Context) (HealthState, error) {
+	resp, err := c.send(ctx, http.MethodGet, "/eth/v1/node/health", "", nil)
+	if err != nil {
+		return HealthUnknown, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return HealthReady, nil
+	case http.StatusPartialContent:
+		return HealthSyncing, nil
+	case http.StatusServiceUnavailable:
+		return HealthNotReady, nil
+	default:
+		return HealthUnknown, &statusError{StatusCode: resp.StatusCode, Path: "/eth/v1/node/health"}
+	}
+}
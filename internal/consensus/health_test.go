@@ -0,0 +1,96 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestConsensusClient_GetHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		expected    HealthState
+		expectError bool
+	}{
+		{name: "ready", status: http.StatusOK, expected: HealthReady},
+		{name: "syncing", status: http.StatusPartialContent, expected: HealthSyncing},
+		{name: "not ready", status: http.StatusServiceUnavailable, expected: HealthNotReady},
+		{name: "invalid syncing status", status: http.StatusBadRequest, expected: HealthUnknown, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, testutil.MockHTTPResponse(tt.status, ""))
+			client := NewConsensusClient("test", server.URL)
+
+			health, err := client.getHealth(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, health)
+		})
+	}
+
+	client := NewConsensusClient("test", "http://127.0.0.1:1")
+	health, err := client.getHealth(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, HealthUnknown, health)
+	assert.Equal(t, "unknown", health.String())
+}
+
+func TestConsensusClient_SyncingNodeStaysConnected(t *testing.T) {
+	endpoints := map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/eth/v1/node/health": {
+			Status: http.StatusPartialContent,
+		},
+		"/eth/v1/beacon/genesis": {
+			Status: http.StatusOK,
+			Body:   `{"data": {"genesis_time": "1606824023"}}`,
+		},
+		"/eth/v1/config/spec": {
+			Status: http.StatusOK,
+			Body:   testutil.ValidChainConfigResponse,
+		},
+		"/eth/v1/node/syncing": {
+			Status: http.StatusPartialContent,
+			Body:   testutil.ValidSyncingResponse,
+		},
+		"/eth/v1/beacon/states/head/finality_checkpoints": {
+			Status: http.StatusPartialContent,
+			Body:   `{"data": {"current_justified": {"epoch": "2"}, "finalized": {"epoch": "1"}}}`,
+		},
+	}
+
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(endpoints))
+	client := NewConsensusClient("test", server.URL)
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.True(t, info.IsConnected, "206 responses are not errors")
+	assert.Equal(t, HealthSyncing, info.Health)
+	assert.True(t, info.IsSyncing)
+	assert.Equal(t, uint64(1), info.FinalizedEpoch)
+}
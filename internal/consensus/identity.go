@@ -0,0 +1,114 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Number of attestation subnets in the attnets bitvector
+	attestationSubnetCount = 64
+	// Number of sync committee subnets in the syncnets bitvector
+	syncCommitteeSubnetCount = 4
+)
+
+// NodeIdentity is how a node presents itself on the p2p network
+type NodeIdentity struct {
+	PeerID             string
+	ENR                string
+	P2PAddresses       []string
+	DiscoveryAddresses []string
+	SeqNumber          uint64
+	Attnets            []int    // Attestation subnets the node is subscribed to
+	Syncnets           []int    // Sync committee subnets the node is subscribed to
+	PrivateAddresses   []string // Advertised addresses that other peers cannot reach
+}
+
+// GetNodeIdentity fetches the p2p identity of the node and decodes its subnet
+// subscriptions
+func (c *ConsensusClient) GetNodeIdentity(ctx context.Context) (*NodeIdentity, error) {
+	var resp NodeIdentityResponse
+	if err := c.get(ctx, "/eth/v1/node/identity", &resp); err != nil {
+		return nil, err
+	}
+
+	identity := &NodeIdentity{
+		PeerID:             resp.Data.PeerID,
+		ENR:                resp.Data.Enr,
+		P2PAddresses:       resp.Data.P2PAddresses,
+		DiscoveryAddresses: resp.Data.DiscoveryAddresses,
+	}
+	identity.SeqNumber, _ = strconv.ParseUint(resp.Data.Metadata.SeqNumber, 10, 64)
+
+	var err error
+	if identity.Attnets, err = decodeSubnets(resp.Data.Metadata.Attnets, attestationSubnetCount); err != nil {
+		return nil, fmt.Errorf("failed to decode attnets: %w", err)
+	}
+	if identity.Syncnets, err = decodeSubnets(resp.Data.Metadata.Syncnets, syncCommitteeSubnetCount); err != nil {
+		return nil, fmt.Errorf("failed to decode syncnets: %w", err)
+	}
+
+	for _, addresses := range [][]string{identity.P2PAddresses, identity.DiscoveryAddresses} {
+		for _, address := range addresses {
+			if isPrivateMultiaddr(address) {
+				identity.PrivateAddresses = append(identity.PrivateAddresses, address)
+			}
+		}
+	}
+	return identity, nil
+}
+
+// decodeSubnets returns the subnets set in a hex encoded SSZ bitvector. An
+// empty value, e.g. from a node without metadata, has no subnets.
+func decodeSubnets(value string, count int) ([]int, error) {
+	value = strings.TrimPrefix(value, "0x")
+	if value == "" {
+		return nil, nil
+	}
+	bits, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var subnets []int
+	for subnet := 0; subnet < count; subnet++ {
+		if bitvectorBitSet(bits, subnet) {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets, nil
+}
+
+// isPrivateMultiaddr returns true if the multiaddr has an IP address that is
+// not publicly routable, e.g. /ip4/192.168.1.10/tcp/9000
+func isPrivateMultiaddr(address string) bool {
+	parts := strings.Split(address, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] != "ip4" && parts[i] != "ip6" {
+			continue
+		}
+		ip := net.ParseIP(parts[i+1])
+		if ip == nil {
+			return false
+		}
+		return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified()
+	}
+	return false
+}
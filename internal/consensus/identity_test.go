@@ -0,0 +1,88 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestConsensusClient_GetNodeIdentity(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		expectedAttnets  []int
+		expectedSyncnets []int
+		expectedPrivate  []string
+		expectError      bool
+	}{
+		{
+			name:            "loopback address",
+			body:            testutil.ValidNodeIdentityResponse,
+			expectedPrivate: []string{"/ip4/127.0.0.1/tcp/9001/p2p/16Uiu2HAm5JH3KzSJPg8qhVcqRn8XHZ3ZkGdkuVDYmZ6HhNqZGVYr", "/ip4/127.0.0.1/udp/9000/p2p/16Uiu2HAm5JH3KzSJPg8qhVcqRn8XHZ3ZkGdkuVDYmZ6HhNqZGVYr"},
+		},
+		{
+			name: "public address with subnets",
+			body: `{"data": {
+				"peer_id": "16Uiu2HAm",
+				"p2p_addresses": ["/ip4/203.0.113.7/tcp/9000/p2p/16Uiu2HAm", "/ip6/2001:db8::1/tcp/9000"],
+				"metadata": {"seq_number": "12", "attnets": "0x0300000000000080", "syncnets": "0x04"}
+			}}`,
+			expectedAttnets:  []int{0, 1, 63},
+			expectedSyncnets: []int{2},
+		},
+		{
+			name: "private addresses",
+			body: `{"data": {
+				"peer_id": "16Uiu2HAm",
+				"p2p_addresses": ["/ip4/192.168.1.20/tcp/9000", "/ip6/fe80::1/tcp/9000", "/dns4/beacon.example.com/tcp/9000"],
+				"metadata": {"seq_number": "1", "attnets": "0x00", "syncnets": "0x00"}
+			}}`,
+			expectedPrivate: []string{"/ip4/192.168.1.20/tcp/9000", "/ip6/fe80::1/tcp/9000"},
+		},
+		{
+			name:        "invalid attnets",
+			body:        `{"data": {"peer_id": "16Uiu2HAm", "metadata": {"attnets": "0xzz"}}}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/node/identity": {Status: http.StatusOK, Body: tt.body},
+			}))
+
+			client := NewConsensusClient("test", server.URL)
+			identity, err := client.GetNodeIdentity(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, identity.PeerID)
+			assert.Equal(t, tt.expectedAttnets, identity.Attnets)
+			assert.Equal(t, tt.expectedSyncnets, identity.Syncnets)
+			assert.Equal(t, tt.expectedPrivate, identity.PrivateAddresses)
+		})
+	}
+}
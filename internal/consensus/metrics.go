@@ -0,0 +1,177 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/watcheth/watcheth/internal/common"
+)
+
+// ClientMetrics are implementation specific metrics scraped from the node's
+// Prometheus endpoint. Values the implementation does not expose are zero.
+type ClientMetrics struct {
+	Implementation    string        // e.g. lighthouse, from the node version
+	GossipMessageRate float64       // Gossip messages received per second since the last scrape
+	HeadDelay         time.Duration // Average delay of blocks after the start of their slot
+	DBSizeBytes       uint64
+	MemoryBytes       uint64 // Resident memory of the process
+}
+
+// metricSource is where a value comes from in an implementation's metrics
+type metricSource struct {
+	Names []string // Candidate metric families, the first one present is used
+	Scale float64  // Converts the metric to the unit of the field, 1 if zero
+}
+
+// metricMapping maps the values of ClientMetrics to the metrics of an
+// implementation
+type metricMapping struct {
+	GossipMessages metricSource // Counter of gossip messages received, summed over all labels
+	HeadDelay      metricSource // Histogram or gauge of block delay, in seconds after scaling
+	DBSize         metricSource // Gauge of database size, in bytes after scaling
+	Memory         metricSource // Gauge of resident memory, in bytes after scaling
+}
+
+// Resident memory as exported by the standard process collectors
+var processMemory = metricSource{Names: []string{"process_resident_memory_bytes"}}
+
+// metricMappings holds the metrics of each implementation, keyed by the
+// lower case name the node gives in its version. Names change between
+// releases, so several candidates can be listed.
+var metricMappings = map[string]metricMapping{
+	"lighthouse": {
+		GossipMessages: metricSource{Names: []string{"gossipsub_topic_msg_recv_counts_total", "gossipsub_topic_msg_recv_counts"}},
+		HeadDelay:      metricSource{Names: []string{"beacon_block_head_slot_start_delay_time"}},
+		DBSize:         metricSource{Names: []string{"store_disk_db_size"}},
+		Memory:         processMemory,
+	},
+	"prysm": {
+		GossipMessages: metricSource{Names: []string{"p2p_message_received_total"}},
+		HeadDelay:      metricSource{Names: []string{"block_arrival_latency_milliseconds"}, Scale: 0.001},
+		DBSize:         metricSource{Names: []string{"beacondb_size_bytes"}},
+		Memory:         processMemory,
+	},
+	"teku": {
+		GossipMessages: metricSource{Names: []string{"libp2p_gossip_messages_total"}},
+		HeadDelay:      metricSource{Names: []string{"beacon_block_import_delay_latest"}, Scale: 0.001},
+		DBSize:         metricSource{Names: []string{"storage_database_size_bytes"}},
+		Memory:         metricSource{Names: []string{"process_resident_memory_bytes", "jvm_memory_used_bytes"}},
+	},
+	"nimbus": {
+		GossipMessages: metricSource{Names: []string{"libp2p_gossipsub_received_total", "libp2p_gossipsub_received"}},
+		HeadDelay:      metricSource{Names: []string{"beacon_block_delay"}},
+		DBSize:         metricSource{Names: []string{"nimbus_db_size_bytes"}},
+		Memory:         processMemory,
+	},
+}
+
+// implementationName returns the lower case implementation from a node
+// version such as Lighthouse/v4.5.0-1234567/x86_64-linux
+func implementationName(version string) string {
+	name, _, _ := strings.Cut(version, "/")
+	return strings.ToLower(name)
+}
+
+// GetClientMetrics scrapes the node's metrics endpoint and maps the metrics
+// of its implementation. Nodes of an unknown implementation only report the
+// metrics that all implementations share.
+func (c *ConsensusClient) GetClientMetrics(ctx context.Context, version string) (*ClientMetrics, error) {
+	families, err := common.FetchMetrics(ctx, c.httpClient, c.metricsEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &ClientMetrics{Implementation: implementationName(version)}
+	mapping, ok := metricMappings[metrics.Implementation]
+	if !ok {
+		mapping = metricMapping{Memory: processMemory}
+	}
+
+	if value, ok := mapping.DBSize.value(families); ok {
+		metrics.DBSizeBytes = uint64(value)
+	}
+	if value, ok := mapping.Memory.value(families); ok {
+		metrics.MemoryBytes = uint64(value)
+	}
+	if seconds, ok := mapping.HeadDelay.value(families); ok {
+		metrics.HeadDelay = time.Duration(seconds * float64(time.Second))
+	}
+
+	if total, ok := mapping.GossipMessages.value(families); ok {
+		now := time.Now()
+		c.mu.Lock()
+		metrics.GossipMessageRate = c.gossip.rate(total, now)
+		c.mu.Unlock()
+	}
+	return metrics, nil
+}
+
+// value returns the value of the first candidate metric present. Histograms
+// give their average, other metrics the sum over all label sets.
+func (s metricSource) value(families map[string]*io_prometheus_client.MetricFamily) (float64, bool) {
+	scale := s.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	for _, name := range s.Names {
+		mf, ok := families[name]
+		if !ok || len(mf.Metric) == 0 {
+			continue
+		}
+		if mf.GetType() == io_prometheus_client.MetricType_HISTOGRAM {
+			sum, count := common.HistogramSumAndCount(mf)
+			if count == 0 {
+				return 0, false
+			}
+			return sum / count * scale, true
+		}
+
+		total, found := 0.0, false
+		for _, m := range mf.Metric {
+			if value, ok := common.MetricValue(m); ok {
+				total += value
+				found = true
+			}
+		}
+		if found {
+			return total * scale, true
+		}
+	}
+	return 0, false
+}
+
+// counterRate turns successive readings of a counter into a rate
+type counterRate struct {
+	total float64
+	at    time.Time
+}
+
+// rate returns the increase per second since the last reading, or 0 for the
+// first reading and after a counter reset, e.g. a restart of the node
+func (r *counterRate) rate(total float64, now time.Time) float64 {
+	previous := *r
+	r.total, r.at = total, now
+	if previous.at.IsZero() || total < previous.total {
+		return 0
+	}
+	elapsed := now.Sub(previous.at).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return (total - previous.total) / elapsed
+}
@@ -0,0 +1,133 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+const lighthouseMetrics = `# TYPE gossipsub_topic_msg_recv_counts_total counter
+gossipsub_topic_msg_recv_counts_total{topic="beacon_block"} 100
+gossipsub_topic_msg_recv_counts_total{topic="beacon_attestation_1"} 900
+# TYPE beacon_block_head_slot_start_delay_time histogram
+beacon_block_head_slot_start_delay_time_bucket{le="+Inf"} 4
+beacon_block_head_slot_start_delay_time_sum 6
+beacon_block_head_slot_start_delay_time_count 4
+# TYPE store_disk_db_size gauge
+store_disk_db_size 2147483648
+# TYPE process_resident_memory_bytes gauge
+process_resident_memory_bytes 1073741824
+`
+
+const prysmMetrics = `# TYPE p2p_message_received_total counter
+p2p_message_received_total{topic="/eth2/beacon_block"} 50
+# TYPE block_arrival_latency_milliseconds histogram
+block_arrival_latency_milliseconds_bucket{le="+Inf"} 2
+block_arrival_latency_milliseconds_sum 3000
+block_arrival_latency_milliseconds_count 2
+# TYPE process_resident_memory_bytes gauge
+process_resident_memory_bytes 524288000
+`
+
+func TestConsensusClient_GetClientMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		body     string
+		expected *ClientMetrics
+	}{
+		{
+			name:    "lighthouse",
+			version: "Lighthouse/v5.3.0-d6ba8c3/x86_64-linux",
+			body:    lighthouseMetrics,
+			expected: &ClientMetrics{
+				Implementation: "lighthouse",
+				HeadDelay:      1500 * time.Millisecond,
+				DBSizeBytes:    2147483648,
+				MemoryBytes:    1073741824,
+			},
+		},
+		{
+			name:    "prysm",
+			version: "Prysm/v5.1.0/abcdef (linux amd64)",
+			body:    prysmMetrics,
+			expected: &ClientMetrics{
+				Implementation: "prysm",
+				HeadDelay:      1500 * time.Millisecond,
+				MemoryBytes:    524288000,
+			},
+		},
+		{
+			name:    "unknown implementation",
+			version: "Grandine/1.0.0",
+			body:    lighthouseMetrics,
+			expected: &ClientMetrics{
+				Implementation: "grandine",
+				MemoryBytes:    1073741824,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/metrics": {Status: http.StatusOK, Body: tt.body},
+			}))
+			client := NewConsensusClient("test", "http://localhost:5052", WithMetricsEndpoint(server.URL))
+
+			metrics, err := client.GetClientMetrics(context.Background(), tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metrics)
+		})
+	}
+}
+
+func TestConsensusClient_GetClientMetricsError(t *testing.T) {
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/metrics": {Status: http.StatusInternalServerError, Body: ""},
+	}))
+	client := NewConsensusClient("test", "http://localhost:5052", WithMetricsEndpoint(server.URL))
+
+	_, err := client.GetClientMetrics(context.Background(), "Lighthouse/v5.3.0")
+	assert.Error(t, err)
+}
+
+func TestCounterRate(t *testing.T) {
+	var rate counterRate
+	start := time.Unix(1700000000, 0)
+
+	assert.Zero(t, rate.rate(1000, start), "first reading")
+	assert.InDelta(t, 50.0, rate.rate(1500, start.Add(10*time.Second)), 0.001)
+	assert.Zero(t, rate.rate(200, start.Add(20*time.Second)), "counter reset")
+	assert.InDelta(t, 10.0, rate.rate(300, start.Add(30*time.Second)), 0.001)
+}
+
+func TestImplementationName(t *testing.T) {
+	assert.Equal(t, "lighthouse", implementationName("Lighthouse/v5.3.0-d6ba8c3/x86_64-linux"))
+	assert.Equal(t, "teku", implementationName("teku/v24.10.0/linux-x86_64/-eclipseadoptium-openjdk64bitservervm-java-21"))
+	assert.Equal(t, "", implementationName(""))
+}
@@ -0,0 +1,134 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// Keys that identify the network a node is on. Nodes that differ in any of
+// these are on different networks.
+var NetworkIdentityKeys = []string{
+	"GENESIS_TIME",
+	"GENESIS_VALIDATORS_ROOT",
+	"GENESIS_FORK_VERSION",
+	"DEPOSIT_CONTRACT_ADDRESS",
+	"DEPOSIT_CHAIN_ID",
+}
+
+// NetworkConfig is the genesis and complete spec of the network a node is
+// on. Neither changes while the node is running, so they are cached.
+type NetworkConfig struct {
+	GenesisTime           time.Time
+	GenesisValidatorsRoot string
+	GenesisForkVersion    string
+	DepositContract       string
+	DepositChainID        string
+	Spec                  map[string]string // Non-string values are JSON encoded
+}
+
+// Identity returns the values that identify the network, keyed by
+// NetworkIdentityKeys and normalised for comparison
+func (n *NetworkConfig) Identity() map[string]string {
+	return map[string]string{
+		"GENESIS_TIME":             strconv.FormatInt(n.GenesisTime.Unix(), 10),
+		"GENESIS_VALIDATORS_ROOT":  strings.ToLower(n.GenesisValidatorsRoot),
+		"GENESIS_FORK_VERSION":     strings.ToLower(n.GenesisForkVersion),
+		"DEPOSIT_CONTRACT_ADDRESS": strings.ToLower(n.DepositContract),
+		"DEPOSIT_CHAIN_ID":         n.DepositChainID,
+	}
+}
+
+// GetNetworkConfig returns the genesis and spec of the node, fetching them
+// when the cached copy has expired. The expired copy is returned if they
+// cannot be fetched again.
+func (c *ConsensusClient) GetNetworkConfig(ctx context.Context) (*NetworkConfig, error) {
+	c.mu.Lock()
+	network, ok := c.network.get(time.Now())
+	c.mu.Unlock()
+	if ok {
+		return network, nil
+	}
+
+	network, err := c.fetchNetworkConfig(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		if stale, ok := c.network.stale(); ok {
+			logger.Debug("[%s]: Using expired network config: %v", c.name, err)
+			return stale, nil
+		}
+		return nil, err
+	}
+	c.network.set(network, time.Now())
+	return network, nil
+}
+
+func (c *ConsensusClient) fetchNetworkConfig(ctx context.Context) (*NetworkConfig, error) {
+	genesis, err := c.getGenesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := c.getSpec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	genesisTime, err := strconv.ParseInt(genesis.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse genesis time: %w", err)
+	}
+
+	network := &NetworkConfig{
+		GenesisTime:           time.Unix(genesisTime, 0),
+		GenesisValidatorsRoot: genesis.Data.GenesisValidatorsRoot,
+		GenesisForkVersion:    genesis.Data.GenesisForkVersion,
+		Spec:                  make(map[string]string, len(spec.Data)),
+	}
+	for key, value := range spec.Data {
+		if s, ok := value.(string); ok {
+			network.Spec[key] = s
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode spec value %s: %w", key, err)
+		}
+		network.Spec[key] = string(encoded)
+	}
+
+	// Deposit contract from its own endpoint, falling back to the spec
+	var deposit DepositContractResponse
+	if err := c.get(ctx, "/eth/v1/config/deposit_contract", &deposit); err == nil {
+		network.DepositContract = deposit.Data.Address
+		network.DepositChainID = deposit.Data.ChainID
+	} else {
+		network.DepositContract = network.Spec["DEPOSIT_CONTRACT_ADDRESS"]
+		network.DepositChainID = network.Spec["DEPOSIT_CHAIN_ID"]
+	}
+	if network.GenesisForkVersion == "" {
+		network.GenesisForkVersion = network.Spec["GENESIS_FORK_VERSION"]
+	}
+
+	return network, nil
+}
@@ -0,0 +1,184 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+const testGenesisResponse = `{"data": {
+	"genesis_time": "1606824023",
+	"genesis_validators_root": "0x4B363DB94E286120D76EB905340FDD4E54BFE9F06BF33FF6CF5AD27F511BFE95",
+	"genesis_fork_version": "0x00000000"
+}}`
+
+func TestConsensusClient_GetNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints map[string]struct {
+			Status int
+			Body   string
+		}
+		expectedContract string
+		expectedChainID  string
+		expectError      bool
+	}{
+		{
+			name: "deposit contract endpoint",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: testGenesisResponse},
+				"/eth/v1/config/spec": {Status: http.StatusOK, Body: `{"data": {
+					"SECONDS_PER_SLOT": "12",
+					"SLOTS_PER_EPOCH": "32",
+					"BLOB_SCHEDULE": [{"EPOCH": "412672", "MAX_BLOBS_PER_BLOCK": "15"}]
+				}}`},
+				"/eth/v1/config/deposit_contract": {Status: http.StatusOK, Body: `{"data": {"chain_id": "1", "address": "0x00000000219ab540356cBB839Cbe05303d7705Fa"}}`},
+			},
+			expectedContract: "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+			expectedChainID:  "1",
+		},
+		{
+			name: "deposit contract from spec",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: testGenesisResponse},
+				"/eth/v1/config/spec": {Status: http.StatusOK, Body: `{"data": {
+					"SECONDS_PER_SLOT": "12",
+					"SLOTS_PER_EPOCH": "32",
+					"DEPOSIT_CHAIN_ID": "17000",
+					"DEPOSIT_CONTRACT_ADDRESS": "0x4242424242424242424242424242424242424242"
+				}}`},
+			},
+			expectedContract: "0x4242424242424242424242424242424242424242",
+			expectedChainID:  "17000",
+		},
+		{
+			name: "spec unavailable",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: testGenesisResponse},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(tt.endpoints))
+			client := NewConsensusClient("test", server.URL)
+
+			network, err := client.GetNetworkConfig(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testutil.TestGenesisTime(), network.GenesisTime)
+			assert.Equal(t, tt.expectedContract, network.DepositContract)
+			assert.Equal(t, tt.expectedChainID, network.DepositChainID)
+			assert.Equal(t, "12", network.Spec["SECONDS_PER_SLOT"])
+
+			identity := network.Identity()
+			assert.Equal(t, "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95", identity["GENESIS_VALIDATORS_ROOT"])
+			assert.Equal(t, "1606824023", identity["GENESIS_TIME"])
+		})
+	}
+}
+
+func TestConsensusClient_GetNetworkConfigCached(t *testing.T) {
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			_, _ = w.Write([]byte(testGenesisResponse))
+		case "/eth/v1/config/spec":
+			_, _ = w.Write([]byte(`{"data": {"SECONDS_PER_SLOT": "12", "SLOTS_PER_EPOCH": "32", "MAX_BLOBS_PER_BLOCK": 6}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	for i := 0; i < 3; i++ {
+		chainConfig, err := client.GetChainConfig(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, uint64(12), chainConfig.SecondsPerSlot)
+	}
+	mu.Lock()
+	assert.Equal(t, 1, requests["/eth/v1/beacon/genesis"])
+	assert.Equal(t, 1, requests["/eth/v1/config/spec"])
+	mu.Unlock()
+
+	network, err := client.GetNetworkConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "6", network.Spec["MAX_BLOBS_PER_BLOCK"], "non-string values are JSON encoded")
+}
+
+func TestConsensusClient_GetNetworkConfigExpired(t *testing.T) {
+	var mu sync.Mutex
+	available := true
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case !available:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/eth/v1/beacon/genesis":
+			_, _ = w.Write([]byte(testGenesisResponse))
+		case r.URL.Path == "/eth/v1/config/spec":
+			_, _ = w.Write([]byte(`{"data": {"SECONDS_PER_SLOT": "12", "SLOTS_PER_EPOCH": "32"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	_, err := client.GetNetworkConfig(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	available = false
+	mu.Unlock()
+	client.mu.Lock()
+	client.network.fetched = time.Now().Add(-staticDataTTL)
+	client.mu.Unlock()
+
+	// The expired config is better than none while the node is unavailable
+	network, err := client.GetNetworkConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "12", network.Spec["SECONDS_PER_SLOT"])
+
+	client = NewConsensusClient("test", server.URL)
+	_, err = client.GetNetworkConfig(context.Background())
+	assert.Error(t, err)
+}
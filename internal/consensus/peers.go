@@ -0,0 +1,143 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// Period over which peer churn is reported
+const peerChurnWindow = 10 * time.Minute
+
+// PeerChurn is the change in connected peers between two polls
+type PeerChurn struct {
+	Time   time.Time
+	Joined int
+	Left   int
+}
+
+// PeerSummary breaks down the peers of a node by state and direction
+type PeerSummary struct {
+	Connected     uint64
+	Connecting    uint64
+	Disconnected  uint64
+	Disconnecting uint64
+	Inbound       uint64      // Connected peers that dialled the node
+	Outbound      uint64      // Connected peers the node dialled
+	HasDetails    bool        // Whether the individual peers were available
+	Joined        int         // Peers connected within the churn window
+	Left          int         // Peers disconnected within the churn window
+	Churn         []PeerChurn // Per poll within the churn window, oldest first
+}
+
+// peerTracker keeps the connected peers from the last poll to work out churn
+type peerTracker struct {
+	connected map[string]bool // Nil until the peers are first seen
+	churn     []PeerChurn
+}
+
+// observe records the currently connected peers. The first observation only
+// sets the baseline, as every peer would otherwise count as joined.
+func (t *peerTracker) observe(peerIDs []string, now time.Time) {
+	connected := make(map[string]bool, len(peerIDs))
+	for _, id := range peerIDs {
+		connected[id] = true
+	}
+
+	if t.connected != nil {
+		churn := PeerChurn{Time: now}
+		for id := range connected {
+			if !t.connected[id] {
+				churn.Joined++
+			}
+		}
+		for id := range t.connected {
+			if !connected[id] {
+				churn.Left++
+			}
+		}
+		t.churn = append(t.churn, churn)
+	}
+	t.connected = connected
+	t.prune(now)
+}
+
+func (t *peerTracker) prune(now time.Time) {
+	cutoff := now.Add(-peerChurnWindow)
+	first := 0
+	for first < len(t.churn) && t.churn[first].Time.Before(cutoff) {
+		first++
+	}
+	t.churn = t.churn[first:]
+}
+
+// apply fills in the churn within the window
+func (t *peerTracker) apply(summary *PeerSummary, now time.Time) {
+	t.prune(now)
+	summary.Churn = make([]PeerChurn, len(t.churn))
+	copy(summary.Churn, t.churn)
+	for _, churn := range t.churn {
+		summary.Joined += churn.Joined
+		summary.Left += churn.Left
+	}
+}
+
+// updatePeers builds the peer breakdown from the peer count and, if the node
+// serves them, the individual connected peers
+func (c *ConsensusClient) updatePeers(ctx context.Context, count *PeerCountResponse, now time.Time) *PeerSummary {
+	summary := &PeerSummary{}
+	summary.Connected, _ = strconv.ParseUint(count.Data.Connected, 10, 64)
+	summary.Connecting, _ = strconv.ParseUint(count.Data.Connecting, 10, 64)
+	summary.Disconnected, _ = strconv.ParseUint(count.Data.Disconnected, 10, 64)
+	summary.Disconnecting, _ = strconv.ParseUint(count.Data.Disconnecting, 10, 64)
+
+	peers, err := c.getPeers(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		logger.Debug("[%s]: Failed to get peers: %v", c.name, err)
+		c.peers.apply(summary, now)
+		return summary
+	}
+
+	var ids []string
+	for _, peer := range peers.Data {
+		// Not every node applies the state filter
+		if peer.State != "connected" {
+			continue
+		}
+		ids = append(ids, peer.PeerID)
+		switch peer.Direction {
+		case "inbound":
+			summary.Inbound++
+		case "outbound":
+			summary.Outbound++
+		}
+	}
+	summary.HasDetails = true
+	c.peers.observe(ids, now)
+	c.peers.apply(summary, now)
+	return summary
+}
+
+func (c *ConsensusClient) getPeers(ctx context.Context) (*PeersResponse, error) {
+	var resp PeersResponse
+	err := c.get(ctx, "/eth/v1/node/peers?state=connected", &resp)
+	return &resp, err
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestPeerTracker(t *testing.T) {
+	var tracker peerTracker
+	start := time.Now()
+
+	// The first observation is only the baseline
+	tracker.observe([]string{"a", "b", "c"}, start)
+	var summary PeerSummary
+	tracker.apply(&summary, start)
+	assert.Empty(t, summary.Churn)
+
+	tracker.observe([]string{"a", "c", "d", "e"}, start.Add(time.Minute))
+	tracker.observe([]string{"a", "d", "e"}, start.Add(2*time.Minute))
+	summary = PeerSummary{}
+	tracker.apply(&summary, start.Add(2*time.Minute))
+	assert.Equal(t, 2, summary.Joined)
+	assert.Equal(t, 2, summary.Left)
+	require.Len(t, summary.Churn, 2)
+	assert.Equal(t, PeerChurn{Time: start.Add(time.Minute), Joined: 2, Left: 1}, summary.Churn[0])
+
+	// Churn outside the window is dropped
+	summary = PeerSummary{}
+	tracker.apply(&summary, start.Add(time.Minute+peerChurnWindow+time.Second))
+	assert.Equal(t, 0, summary.Joined)
+	assert.Equal(t, 1, summary.Left)
+	assert.Len(t, summary.Churn, 1)
+}
+
+func TestConsensusClient_UpdatePeers(t *testing.T) {
+	count := &PeerCountResponse{}
+	count.Data.Connected = "3"
+	count.Data.Connecting = "2"
+	count.Data.Disconnected = "40"
+	count.Data.Disconnecting = "1"
+
+	t.Run("with peers", func(t *testing.T) {
+		server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/eth/v1/node/peers", r.URL.Path)
+			assert.Equal(t, "connected", r.URL.Query().Get("state"))
+			_, _ = w.Write([]byte(`{"data": [
+				{"peer_id": "a", "state": "connected", "direction": "inbound"},
+				{"peer_id": "b", "state": "connected", "direction": "outbound"},
+				{"peer_id": "c", "state": "connected", "direction": "outbound"},
+				{"peer_id": "d", "state": "disconnected", "direction": "inbound"}
+			]}`))
+		})
+
+		client := NewConsensusClient("test", server.URL)
+		summary := client.updatePeers(context.Background(), count, time.Now())
+		assert.Equal(t, uint64(3), summary.Connected)
+		assert.Equal(t, uint64(2), summary.Connecting)
+		assert.Equal(t, uint64(40), summary.Disconnected)
+		assert.Equal(t, uint64(1), summary.Disconnecting)
+		assert.True(t, summary.HasDetails)
+		assert.Equal(t, uint64(1), summary.Inbound)
+		assert.Equal(t, uint64(2), summary.Outbound, "only connected peers are counted")
+	})
+
+	t.Run("peers not available", func(t *testing.T) {
+		server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(nil))
+
+		client := NewConsensusClient("test", server.URL)
+		summary := client.updatePeers(context.Background(), count, time.Now())
+		assert.Equal(t, uint64(3), summary.Connected)
+		assert.False(t, summary.HasDetails)
+	})
+}
@@ -0,0 +1,341 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+const (
+	// Number of recent blocks whose blobs are counted
+	blobTrackedBlocks = 8
+
+	// How often the attestation pool is fetched. It holds thousands of
+	// attestations, and only its size is shown.
+	attestationPoolInterval = time.Minute
+)
+
+// Operation pools, recorded in ConsensusNodeInfo.Sources as PoolSource
+const (
+	PoolAttestations          = "attestations"
+	PoolVoluntaryExits        = "voluntary_exits"
+	PoolBLSToExecutionChanges = "bls_to_execution_changes"
+	PoolAttesterSlashings     = "attester_slashings"
+	PoolProposerSlashings     = "proposer_slashings"
+)
+
+// PoolSource returns the name the outcome of fetching a pool is recorded
+// under in ConsensusNodeInfo.Sources
+func PoolSource(pool string) string {
+	return QueryPool + "/" + pool
+}
+
+// Kinds of slashing in the operation pool
+const (
+	SlashingAttester = "attester"
+	SlashingProposer = "proposer"
+)
+
+// Slashing is a slashing waiting in the node's operation pool
+type Slashing struct {
+	Kind       string   // SlashingAttester or SlashingProposer
+	Validators []uint64 // Validators that would be slashed, in ascending order
+}
+
+// Key identifies the slashing across polls and nodes
+func (s Slashing) Key() string {
+	parts := make([]string, len(s.Validators))
+	for i, index := range s.Validators {
+		parts[i] = strconv.FormatUint(index, 10)
+	}
+	return s.Kind + ":" + strings.Join(parts, ",")
+}
+
+// OperationPool holds the number of operations in each of the node's pools
+type OperationPool struct {
+	Attestations          int // As of the last fetch, at most attestationPoolInterval ago
+	VoluntaryExits        int
+	AttesterSlashings     int
+	ProposerSlashings     int
+	BLSToExecutionChanges int
+	Slashings             []Slashing
+	Failed                []string // Pools that could not be fetched, whose counts are unknown
+}
+
+// Known returns true if the pool was fetched
+func (p *OperationPool) Known(pool string) bool {
+	return !slices.Contains(p.Failed, pool)
+}
+
+// BlockBlobs is the number of blobs in a recent block, and how many of them
+// the node serves
+type BlockBlobs struct {
+	Slot      uint64
+	Root      string
+	Expected  int  // Blob KZG commitments in the block
+	Available int  // Blob sidecars served by the node
+	Known     bool // False if the sidecars could not be fetched
+}
+
+// Missing returns true if the node serves fewer blobs than the block commits
+// to
+func (b BlockBlobs) Missing() bool {
+	return b.Known && b.Available < b.Expected
+}
+
+// GetOperationPool fetches the size of each operation pool, and the
+// validators that the pending slashings are for. Each pool is fetched on its
+// own and its outcome recorded in Sources, so one failing pool leaves the
+// others known. An error is only returned if every pool failed.
+func (c *ConsensusClient) GetOperationPool(ctx context.Context) (*OperationPool, error) {
+	pool := &OperationPool{}
+	var errs []error
+	attempted := 0
+	fetch := func(name string, get func() error) {
+		attempted++
+		start := time.Now()
+		err := get()
+		c.sources.Observe(PoolSource(name), start, err)
+		if err != nil {
+			logger.Debug("[%s]: Failed to get %s pool: %v", c.name, name, err)
+			pool.Failed = append(pool.Failed, name)
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	c.mu.Lock()
+	attestations, fetched := c.attestations.stale()
+	if time.Since(c.attestations.fetched) >= attestationPoolInterval {
+		fetched = false
+	}
+	c.mu.Unlock()
+	if fetched {
+		pool.Attestations = attestations
+	} else {
+		fetch(PoolAttestations, func() error {
+			var resp PoolResponse
+			if err := c.getPool(ctx, PoolAttestations, &resp); err != nil {
+				return err
+			}
+			pool.Attestations = len(resp.Data)
+			c.mu.Lock()
+			c.attestations.set(pool.Attestations, time.Now())
+			c.mu.Unlock()
+			return nil
+		})
+	}
+
+	fetch(PoolVoluntaryExits, func() error {
+		var resp PoolResponse
+		if err := c.get(ctx, "/eth/v1/beacon/pool/voluntary_exits", &resp); err != nil {
+			return err
+		}
+		pool.VoluntaryExits = len(resp.Data)
+		return nil
+	})
+
+	fetch(PoolBLSToExecutionChanges, func() error {
+		var resp PoolResponse
+		if err := c.get(ctx, "/eth/v1/beacon/pool/bls_to_execution_changes", &resp); err != nil {
+			return err
+		}
+		pool.BLSToExecutionChanges = len(resp.Data)
+		return nil
+	})
+
+	fetch(PoolAttesterSlashings, func() error {
+		var resp AttesterSlashingsResponse
+		if err := c.getPool(ctx, PoolAttesterSlashings, &resp); err != nil {
+			return err
+		}
+		var slashings []Slashing
+		for _, slashing := range resp.Data {
+			validators, err := slashableIndices(slashing.Attestation1.AttestingIndices, slashing.Attestation2.AttestingIndices)
+			if err != nil {
+				return fmt.Errorf("failed to parse attester slashing: %w", err)
+			}
+			slashings = append(slashings, Slashing{Kind: SlashingAttester, Validators: validators})
+		}
+		pool.AttesterSlashings = len(resp.Data)
+		pool.Slashings = append(pool.Slashings, slashings...)
+		return nil
+	})
+
+	fetch(PoolProposerSlashings, func() error {
+		var resp ProposerSlashingsResponse
+		if err := c.get(ctx, "/eth/v1/beacon/pool/proposer_slashings", &resp); err != nil {
+			return err
+		}
+		var slashings []Slashing
+		for _, slashing := range resp.Data {
+			index, err := strconv.ParseUint(slashing.SignedHeader1.Message.ProposerIndex, 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse proposer slashing: %w", err)
+			}
+			slashings = append(slashings, Slashing{Kind: SlashingProposer, Validators: []uint64{index}})
+		}
+		pool.ProposerSlashings = len(resp.Data)
+		pool.Slashings = append(pool.Slashings, slashings...)
+		return nil
+	})
+
+	if len(errs) == attempted {
+		return nil, errors.Join(errs...)
+	}
+	return pool, nil
+}
+
+// getPool fetches a pool that moved to v2 with Electra, falling back to v1
+// for nodes that do not serve v2 yet
+func (c *ConsensusClient) getPool(ctx context.Context, name string, v any) error {
+	err := c.get(ctx, "/eth/v2/beacon/pool/"+name, v)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return c.get(ctx, "/eth/v1/beacon/pool/"+name, v)
+	}
+	return err
+}
+
+// slashableIndices returns the validators that attested to both attestations
+// of an attester slashing
+func slashableIndices(first, second []string) ([]uint64, error) {
+	attested := make(map[string]bool, len(first))
+	for _, index := range first {
+		attested[index] = true
+	}
+
+	var indices []uint64
+	for _, value := range second {
+		if !attested[value] {
+			continue
+		}
+		index, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices, nil
+}
+
+// updateBlobs counts the blobs of the most recent blocks that have not been
+// counted yet, and fills in the blobs of those blocks. Blocks are looked up
+// again if a reorg changed the block at their slot.
+func (c *ConsensusClient) updateBlobs(ctx context.Context, info *ConsensusNodeInfo, chainConfig *ChainConfig) {
+	c.mu.Lock()
+	var blocks []SlotRecord
+	for _, record := range c.slots.slots {
+		if record.Status == SlotProposed && record.Root != "" {
+			blocks = append(blocks, record)
+		}
+	}
+	c.mu.Unlock()
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Slot > blocks[j].Slot })
+	if len(blocks) > blobTrackedBlocks {
+		blocks = blocks[:blobTrackedBlocks]
+	}
+
+	c.mu.Lock()
+	if c.blobs == nil {
+		c.blobs = make(map[uint64]BlockBlobs)
+	}
+	var pending []SlotRecord
+	tracked := make(map[uint64]bool, len(blocks))
+	for _, block := range blocks {
+		tracked[block.Slot] = true
+		if known, ok := c.blobs[block.Slot]; !ok || known.Root != block.Root {
+			pending = append(pending, block)
+		}
+	}
+	for slot := range c.blobs {
+		if !tracked[slot] {
+			delete(c.blobs, slot)
+		}
+	}
+	c.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, block := range pending {
+		wg.Add(1)
+		go func(block SlotRecord) {
+			defer wg.Done()
+			blobs, err := c.countBlobs(ctx, block, chainConfig)
+			if err != nil {
+				logger.Debug("[%s]: Failed to count blobs at slot %d: %v", c.name, block.Slot, err)
+				return
+			}
+			c.mu.Lock()
+			c.blobs[block.Slot] = blobs
+			c.mu.Unlock()
+		}(block)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	info.Blobs = make([]BlockBlobs, 0, len(c.blobs))
+	for _, blobs := range c.blobs {
+		info.Blobs = append(info.Blobs, blobs)
+	}
+	c.mu.Unlock()
+	sort.Slice(info.Blobs, func(i, j int) bool { return info.Blobs[i].Slot < info.Blobs[j].Slot })
+}
+
+// countBlobs compares the blob commitments in the block with the blob
+// sidecars the node serves for it. Sidecars are only fetched for blocks with
+// blobs, as they carry the blobs themselves. From Fulu, nodes custody columns
+// rather than blobs, and only serve the sidecars of blobs they can rebuild, so
+// fewer sidecars than commitments leaves the blobs unknown rather than
+// missing.
+func (c *ConsensusClient) countBlobs(ctx context.Context, block SlotRecord, chainConfig *ChainConfig) (BlockBlobs, error) {
+	blobs := BlockBlobs{Slot: block.Slot, Root: block.Root}
+
+	resp := newBlockResponse(chainConfig)
+	if err := c.getSSZ(ctx, "/eth/v2/beacon/blocks/"+block.Root, resp); err != nil {
+		return blobs, err
+	}
+	blobs.Expected = len(resp.Data.Message.Body.BlobKZGCommitments)
+	if blobs.Expected == 0 {
+		blobs.Known = true
+		return blobs, nil
+	}
+
+	var sidecars BlobSidecarsResponse
+	if err := c.getSSZ(ctx, "/eth/v1/beacon/blob_sidecars/"+block.Root, &sidecars); err != nil {
+		// Not every node serves sidecars, e.g. those that only custody some
+		// columns since PeerDAS, so this does not mean the blobs are missing
+		logger.Debug("[%s]: Failed to get blob sidecars at slot %d: %v", c.name, block.Slot, err)
+		return blobs, nil
+	}
+	blobs.Available = len(sidecars.Data)
+	blobs.Known = blobs.Available >= blobs.Expected || !peerDAS(chainConfig, block.Slot)
+	return blobs, nil
+}
+
+// peerDAS returns true if the slot is at or after the Fulu fork, which
+// introduced PeerDAS
+func peerDAS(chainConfig *ChainConfig, slot uint64) bool {
+	epoch, ok := chainConfig.ForkEpoch("Fulu")
+	return ok && chainConfig.SlotsPerEpoch > 0 && slot/chainConfig.SlotsPerEpoch >= epoch
+}
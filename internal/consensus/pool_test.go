@@ -0,0 +1,241 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestConsensusClient_GetOperationPool(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints map[string]struct {
+			Status int
+			Body   string
+		}
+		expected    *OperationPool
+		expectError bool
+	}{
+		{
+			name: "v2 pools",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v2/beacon/pool/attestations":             {Status: http.StatusOK, Body: `{"version": "electra", "data": [{}, {}, {}]}`},
+				"/eth/v1/beacon/pool/voluntary_exits":          {Status: http.StatusOK, Body: `{"data": [{}]}`},
+				"/eth/v1/beacon/pool/bls_to_execution_changes": {Status: http.StatusOK, Body: `{"data": []}`},
+				"/eth/v2/beacon/pool/attester_slashings": {Status: http.StatusOK, Body: `{"version": "electra", "data": [{
+					"attestation_1": {"attesting_indices": ["7", "3", "12"]},
+					"attestation_2": {"attesting_indices": ["12", "3", "99"]}
+				}]}`},
+				"/eth/v1/beacon/pool/proposer_slashings": {Status: http.StatusOK, Body: `{"data": [{
+					"signed_header_1": {"message": {"proposer_index": "42"}}
+				}]}`},
+			},
+			expected: &OperationPool{
+				Attestations:      3,
+				VoluntaryExits:    1,
+				AttesterSlashings: 1,
+				ProposerSlashings: 1,
+				Slashings: []Slashing{
+					{Kind: SlashingAttester, Validators: []uint64{3, 12}},
+					{Kind: SlashingProposer, Validators: []uint64{42}},
+				},
+			},
+		},
+		{
+			name: "v1 fallback",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/pool/attestations":             {Status: http.StatusOK, Body: `{"data": [{}]}`},
+				"/eth/v1/beacon/pool/voluntary_exits":          {Status: http.StatusOK, Body: `{"data": []}`},
+				"/eth/v1/beacon/pool/bls_to_execution_changes": {Status: http.StatusOK, Body: `{"data": [{}, {}]}`},
+				"/eth/v1/beacon/pool/attester_slashings":       {Status: http.StatusOK, Body: `{"data": []}`},
+				"/eth/v1/beacon/pool/proposer_slashings":       {Status: http.StatusOK, Body: `{"data": []}`},
+			},
+			expected: &OperationPool{Attestations: 1, BLSToExecutionChanges: 2},
+		},
+		{
+			name: "some pools unavailable",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{
+				"/eth/v1/beacon/pool/attestations":       {Status: http.StatusOK, Body: `{"data": [{}, {}]}`},
+				"/eth/v1/beacon/pool/voluntary_exits":    {Status: http.StatusOK, Body: `{"data": [{}]}`},
+				"/eth/v1/beacon/pool/proposer_slashings": {Status: http.StatusOK, Body: `{"data": [{"signed_header_1": {"message": {"proposer_index": "x"}}}]}`},
+			},
+			expected: &OperationPool{
+				Attestations:   2,
+				VoluntaryExits: 1,
+				Failed:         []string{PoolBLSToExecutionChanges, PoolAttesterSlashings, PoolProposerSlashings},
+			},
+		},
+		{
+			name: "pools unavailable",
+			endpoints: map[string]struct {
+				Status int
+				Body   string
+			}{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(tt.endpoints))
+			client := NewConsensusClient("test", server.URL)
+
+			pool, err := client.GetOperationPool(context.Background())
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pool)
+
+			// Each pool is recorded as a source of its own
+			sources := client.sources.Snapshot()
+			for _, name := range []string{PoolAttestations, PoolVoluntaryExits, PoolBLSToExecutionChanges, PoolAttesterSlashings, PoolProposerSlashings} {
+				require.Contains(t, sources, PoolSource(name))
+				assert.Equal(t, !pool.Known(name), sources.Err(PoolSource(name)) != nil, name)
+			}
+		})
+	}
+}
+
+func TestConsensusClient_GetOperationPoolThrottlesAttestations(t *testing.T) {
+	var mu sync.Mutex
+	attestations := 0
+	endpoints := map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/eth/v1/beacon/pool/attestations":             {Status: http.StatusOK, Body: `{"data": [{}, {}, {}]}`},
+		"/eth/v1/beacon/pool/voluntary_exits":          {Status: http.StatusOK, Body: `{"data": []}`},
+		"/eth/v1/beacon/pool/bls_to_execution_changes": {Status: http.StatusOK, Body: `{"data": []}`},
+		"/eth/v1/beacon/pool/attester_slashings":       {Status: http.StatusOK, Body: `{"data": []}`},
+		"/eth/v1/beacon/pool/proposer_slashings":       {Status: http.StatusOK, Body: `{"data": []}`},
+	}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/eth/v1/beacon/pool/attestations" {
+			mu.Lock()
+			attestations++
+			mu.Unlock()
+		}
+		testutil.MockHTTPEndpoints(endpoints)(w, r)
+	}
+	server := testutil.HTTPTestServer(t, handler)
+	client := NewConsensusClient("test", server.URL)
+
+	for i := 0; i < 3; i++ {
+		pool, err := client.GetOperationPool(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, pool.Attestations)
+	}
+	mu.Lock()
+	assert.Equal(t, 1, attestations)
+	mu.Unlock()
+
+	// Fetched again once the interval has passed
+	client.attestations.fetched = time.Now().Add(-attestationPoolInterval)
+	_, err := client.GetOperationPool(context.Background())
+	require.NoError(t, err)
+	mu.Lock()
+	assert.Equal(t, 2, attestations)
+	mu.Unlock()
+}
+
+func TestSlashing_Key(t *testing.T) {
+	assert.Equal(t, "attester:3,12", Slashing{Kind: SlashingAttester, Validators: []uint64{3, 12}}.Key())
+	assert.Equal(t, "proposer:42", Slashing{Kind: SlashingProposer, Validators: []uint64{42}}.Key())
+}
+
+func TestConsensusClient_UpdateBlobs(t *testing.T) {
+	endpoints := map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/eth/v2/beacon/blocks/0x01":        {Status: http.StatusOK, Body: `{"data": {"message": {"body": {"blob_kzg_commitments": []}}}}`},
+		"/eth/v2/beacon/blocks/0x02":        {Status: http.StatusOK, Body: `{"data": {"message": {"body": {"blob_kzg_commitments": ["0xa", "0xb", "0xc"]}}}}`},
+		"/eth/v1/beacon/blob_sidecars/0x02": {Status: http.StatusOK, Body: `{"data": [{"index": "0"}, {"index": "2"}]}`},
+		"/eth/v2/beacon/blocks/0x03":        {Status: http.StatusOK, Body: `{"data": {"message": {"body": {"blob_kzg_commitments": ["0xd"]}}}}`},
+	}
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(endpoints))
+	client := NewConsensusClient("test", server.URL)
+	client.slots.record(SlotRecord{Slot: 10, Status: SlotProposed, Root: "0x01"})
+	client.slots.record(SlotRecord{Slot: 11, Status: SlotMissed})
+	client.slots.record(SlotRecord{Slot: 12, Status: SlotProposed, Root: "0x02"})
+	client.slots.record(SlotRecord{Slot: 13, Status: SlotProposed, Root: "0x03"})
+
+	info := &ConsensusNodeInfo{}
+	client.updateBlobs(context.Background(), info, &ChainConfig{SlotsPerEpoch: 32})
+
+	require.Len(t, info.Blobs, 3)
+	assert.Equal(t, BlockBlobs{Slot: 10, Root: "0x01", Known: true}, info.Blobs[0])
+	assert.Equal(t, BlockBlobs{Slot: 12, Root: "0x02", Expected: 3, Available: 2, Known: true}, info.Blobs[1])
+	assert.True(t, info.Blobs[1].Missing())
+	assert.Equal(t, BlockBlobs{Slot: 13, Root: "0x03", Expected: 1}, info.Blobs[2], "sidecars not served")
+	assert.False(t, info.Blobs[2].Missing())
+
+	// Blocks that drop out of the window are forgotten
+	for slot := uint64(20); slot < 20+blobTrackedBlocks; slot++ {
+		client.slots.record(SlotRecord{Slot: slot, Status: SlotProposed, Root: "0x01"})
+	}
+	client.updateBlobs(context.Background(), info, &ChainConfig{SlotsPerEpoch: 32})
+	require.Len(t, info.Blobs, blobTrackedBlocks)
+	assert.Equal(t, uint64(20), info.Blobs[0].Slot)
+}
+
+func TestConsensusClient_UpdateBlobsPeerDAS(t *testing.T) {
+	endpoints := map[string]struct {
+		Status int
+		Body   string
+	}{
+		"/eth/v2/beacon/blocks/0x02":        {Status: http.StatusOK, Body: `{"data": {"message": {"body": {"blob_kzg_commitments": ["0xa", "0xb", "0xc"]}}}}`},
+		"/eth/v1/beacon/blob_sidecars/0x02": {Status: http.StatusOK, Body: `{"data": [{"index": "0"}, {"index": "2"}]}`},
+		"/eth/v2/beacon/blocks/0x04":        {Status: http.StatusOK, Body: `{"data": {"message": {"body": {"blob_kzg_commitments": ["0xa", "0xb", "0xc"]}}}}`},
+		"/eth/v1/beacon/blob_sidecars/0x04": {Status: http.StatusOK, Body: `{"data": [{"index": "0"}, {"index": "2"}]}`},
+		"/eth/v2/beacon/blocks/0x05":        {Status: http.StatusOK, Body: `{"data": {"message": {"body": {"blob_kzg_commitments": ["0xd"]}}}}`},
+		"/eth/v1/beacon/blob_sidecars/0x05": {Status: http.StatusOK, Body: `{"data": [{"index": "0"}]}`},
+	}
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPEndpoints(endpoints))
+	client := NewConsensusClient("test", server.URL)
+	client.slots.record(SlotRecord{Slot: 12, Status: SlotProposed, Root: "0x02"})
+	client.slots.record(SlotRecord{Slot: 40, Status: SlotProposed, Root: "0x04"})
+	client.slots.record(SlotRecord{Slot: 41, Status: SlotProposed, Root: "0x05"})
+
+	info := &ConsensusNodeInfo{}
+	client.updateBlobs(context.Background(), info, &ChainConfig{
+		SlotsPerEpoch: 32,
+		Forks:         []ScheduledFork{{Name: "Electra", Epoch: 0}, {Name: "Fulu", Epoch: 1}},
+	})
+
+	require.Len(t, info.Blobs, 3)
+	assert.True(t, info.Blobs[0].Missing(), "before Fulu")
+	assert.Equal(t, BlockBlobs{Slot: 40, Root: "0x04", Expected: 3, Available: 2}, info.Blobs[1], "partial sidecars under PeerDAS")
+	assert.False(t, info.Blobs[1].Missing())
+	assert.Equal(t, BlockBlobs{Slot: 41, Root: "0x05", Expected: 1, Available: 1, Known: true}, info.Blobs[2])
+}
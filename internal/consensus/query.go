@@ -0,0 +1,75 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/common"
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// Names of the queries that make up a poll of a node, as recorded in
+// ConsensusNodeInfo.Sources
+const (
+	QueryHealth   = "health"
+	QueryConfig   = "config" // Genesis and spec
+	QuerySyncing  = "syncing"
+	QueryHeaders  = "headers"
+	QueryFinality = "finality"
+	QueryPeers    = "peers"
+	QueryIdentity = "identity"
+	QueryVersion  = "version"
+	QueryFork     = "fork"
+	QuerySchedule = "fork_schedule"
+	QueryPool     = "pool" // Recorded per pool, see PoolSource
+	QueryMetrics  = "metrics"
+)
+
+// queryGroup runs the independent queries of a poll concurrently and records
+// the outcome of each in the node's source statuses
+type queryGroup struct {
+	node    string
+	sources *common.SourceTracker
+	wg      sync.WaitGroup
+}
+
+// run starts a query. Queries may start further queries that depend on them.
+func (g *queryGroup) run(name string, query func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		start := time.Now()
+		err := query()
+		if err != nil {
+			logger.Debug("[%s]: Failed to get %s: %v", g.node, name, err)
+		}
+		g.sources.Observe(name, start, err)
+	}()
+}
+
+// start starts work that records its own outcome in the source statuses
+func (g *queryGroup) start(work func()) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		work()
+	}()
+}
+
+// wait waits for all queries to finish
+func (g *queryGroup) wait() {
+	g.wg.Wait()
+}
@@ -0,0 +1,169 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"time"
+)
+
+const (
+	// Number of recent heads kept to find the common ancestor of a reorg
+	headHistorySize = 64
+	// Number of parent headers fetched when a new head does not build on a known one
+	maxAncestorLookups = 8
+	// Number of reorgs kept for display
+	maxReorgHistory = 10
+)
+
+// ReorgEvent describes a head change where the new head does not descend from
+// the previous one
+type ReorgEvent struct {
+	Slot        uint64 // Slot of the new head
+	Depth       uint64 // Slots between the old head and the common ancestor
+	OldHeadRoot string
+	NewHeadRoot string
+	DetectedAt  time.Time
+}
+
+type headRecord struct {
+	Slot       uint64
+	Root       string
+	ParentRoot string
+}
+
+// headTracker keeps a rolling window of the heads seen on a node and the
+// reorgs detected between them
+type headTracker struct {
+	heads  []headRecord // Oldest first
+	reorgs []ReorgEvent // Oldest first
+	count  uint64
+}
+
+// observe records a new head. If it does not build on the previous head,
+// parents are fetched with lookup until a known head is found, and a reorg is
+// recorded unless that head was the previous one.
+func (t *headTracker) observe(head headRecord, lookup func(root string) (headRecord, error), now time.Time) *ReorgEvent {
+	if len(t.heads) == 0 {
+		t.heads = append(t.heads, head)
+		return nil
+	}
+
+	last := t.heads[len(t.heads)-1]
+	if head.Root == last.Root {
+		return nil
+	}
+
+	// Walk back from the new head until we reach a head we have seen
+	ancestor := t.indexOf(head.Root)
+	var chain []headRecord
+	if ancestor < 0 {
+		chain = []headRecord{head}
+		for {
+			current := chain[0]
+			ancestor = t.indexOf(current.ParentRoot)
+			if ancestor >= 0 || len(chain) > maxAncestorLookups {
+				break
+			}
+			parent, err := lookup(current.ParentRoot)
+			if err != nil {
+				break
+			}
+			chain = append([]headRecord{parent}, chain...)
+		}
+	}
+
+	if ancestor < 0 {
+		// Too far from anything we know, e.g. after a long outage
+		t.heads = []headRecord{head}
+		return nil
+	}
+
+	var reorg *ReorgEvent
+	if ancestor != len(t.heads)-1 {
+		depth := uint64(0)
+		if last.Slot > t.heads[ancestor].Slot {
+			depth = last.Slot - t.heads[ancestor].Slot
+		}
+		reorg = &ReorgEvent{
+			Slot:        head.Slot,
+			Depth:       depth,
+			OldHeadRoot: last.Root,
+			NewHeadRoot: head.Root,
+			DetectedAt:  now,
+		}
+	}
+
+	t.heads = append(t.heads[:ancestor+1], chain...)
+	if len(t.heads) > headHistorySize {
+		t.heads = t.heads[len(t.heads)-headHistorySize:]
+	}
+
+	if reorg != nil && !t.record(*reorg) {
+		reorg = nil
+	}
+	return reorg
+}
+
+// record adds a reorg to the history, ignoring duplicates reported both by
+// the event stream and by polling. Polling may have missed heads the stream
+// saw, so the two can report different old heads for the same reorg, which
+// is then known by the head it moved to.
+func (t *headTracker) record(reorg ReorgEvent) bool {
+	for _, existing := range t.reorgs {
+		if existing.OldHeadRoot == reorg.OldHeadRoot {
+			return false
+		}
+		if reorg.NewHeadRoot != "" && existing.NewHeadRoot == reorg.NewHeadRoot && existing.Slot == reorg.Slot {
+			return false
+		}
+	}
+
+	t.count++
+	t.reorgs = append(t.reorgs, reorg)
+	if len(t.reorgs) > maxReorgHistory {
+		t.reorgs = t.reorgs[len(t.reorgs)-maxReorgHistory:]
+	}
+	return true
+}
+
+// apply copies the reorg history onto the node info
+func (t *headTracker) apply(info *ConsensusNodeInfo) {
+	info.ReorgCount = t.count
+	info.Reorgs = make([]ReorgEvent, len(t.reorgs))
+	copy(info.Reorgs, t.reorgs)
+	if len(t.reorgs) > 0 {
+		last := t.reorgs[len(t.reorgs)-1]
+		info.LastReorg = &last
+	} else {
+		info.LastReorg = nil
+	}
+}
+
+// known returns the roots of the heads seen
+func (t *headTracker) known() map[string]bool {
+	roots := make(map[string]bool, len(t.heads))
+	for _, head := range t.heads {
+		roots[head.Root] = true
+	}
+	return roots
+}
+
+func (t *headTracker) indexOf(root string) int {
+	for i := len(t.heads) - 1; i >= 0; i-- {
+		if t.heads[i].Root == root {
+			return i
+		}
+	}
+	return -1
+}
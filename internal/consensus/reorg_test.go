@@ -0,0 +1,204 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestHeadTracker_observe(t *testing.T) {
+	// Known blocks, keyed by root, available to the ancestor lookup
+	blocks := map[string]headRecord{
+		"a":  {Slot: 1, Root: "a", ParentRoot: "0"},
+		"b":  {Slot: 2, Root: "b", ParentRoot: "a"},
+		"c":  {Slot: 3, Root: "c", ParentRoot: "b"},
+		"d":  {Slot: 4, Root: "d", ParentRoot: "c"},
+		"c2": {Slot: 3, Root: "c2", ParentRoot: "b"},
+		"d2": {Slot: 4, Root: "d2", ParentRoot: "c2"},
+		"x":  {Slot: 5, Root: "x", ParentRoot: "unknown"},
+	}
+	lookup := func(root string) (headRecord, error) {
+		if block, ok := blocks[root]; ok {
+			return block, nil
+		}
+		return headRecord{}, errors.New("not found")
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		heads         []string
+		expectedDepth []uint64 // Depth of each reorg detected, in order
+		expectedHeads []string
+	}{
+		{
+			name:          "linear chain",
+			heads:         []string{"a", "b", "c", "d"},
+			expectedHeads: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:          "missed intermediate heads are filled in",
+			heads:         []string{"a", "d"},
+			expectedHeads: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:          "single slot reorg",
+			heads:         []string{"a", "b", "c", "c2"},
+			expectedDepth: []uint64{1},
+			expectedHeads: []string{"a", "b", "c2"},
+		},
+		{
+			name:          "reorg detected through unseen parents",
+			heads:         []string{"a", "b", "c", "d", "d2"},
+			expectedDepth: []uint64{2},
+			expectedHeads: []string{"a", "b", "c2", "d2"},
+		},
+		{
+			name:          "head moves back to a known ancestor",
+			heads:         []string{"a", "b", "c", "b"},
+			expectedDepth: []uint64{1},
+			expectedHeads: []string{"a", "b"},
+		},
+		{
+			name:          "unknown ancestry resets history",
+			heads:         []string{"a", "b", "x"},
+			expectedHeads: []string{"x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tracker headTracker
+			var depths []uint64
+			for _, root := range tt.heads {
+				if reorg := tracker.observe(blocks[root], lookup, now); reorg != nil {
+					assert.Equal(t, root, reorg.NewHeadRoot)
+					depths = append(depths, reorg.Depth)
+				}
+			}
+
+			assert.Equal(t, tt.expectedDepth, depths)
+			assert.Equal(t, uint64(len(tt.expectedDepth)), tracker.count)
+
+			roots := make([]string, len(tracker.heads))
+			for i, head := range tracker.heads {
+				roots[i] = head.Root
+			}
+			assert.Equal(t, tt.expectedHeads, roots)
+		})
+	}
+}
+
+func TestHeadTracker_recordDeduplicates(t *testing.T) {
+	var tracker headTracker
+
+	assert.True(t, tracker.record(ReorgEvent{Slot: 10, Depth: 1, OldHeadRoot: "a", NewHeadRoot: "b"}))
+	assert.False(t, tracker.record(ReorgEvent{Slot: 11, Depth: 2, OldHeadRoot: "a", NewHeadRoot: "c"}))
+	// Polling missed the old head the stream reported
+	assert.False(t, tracker.record(ReorgEvent{Slot: 10, Depth: 2, OldHeadRoot: "z", NewHeadRoot: "b"}))
+	assert.Equal(t, uint64(1), tracker.count)
+
+	for i := 0; i < maxReorgHistory+5; i++ {
+		tracker.record(ReorgEvent{Slot: uint64(20 + i), OldHeadRoot: fmt.Sprintf("old-%d", i)})
+	}
+	assert.Len(t, tracker.reorgs, maxReorgHistory)
+	assert.Equal(t, uint64(maxReorgHistory+6), tracker.count)
+
+	var info ConsensusNodeInfo
+	tracker.apply(&info)
+	assert.Equal(t, tracker.count, info.ReorgCount)
+	require.NotNil(t, info.LastReorg)
+	assert.Equal(t, uint64(20+maxReorgHistory+4), info.LastReorg.Slot)
+}
+
+func TestConsensusClient_GetNodeInfoDetectsReorg(t *testing.T) {
+	header := func(slot int, root, parent string) string {
+		return fmt.Sprintf(`{"root": "%s", "header": {"message": {"slot": "%d", "parent_root": "%s"}}}`, root, slot, parent)
+	}
+
+	var mu sync.Mutex
+	var client *ConsensusClient
+	var lockedDuringLookup bool
+	head := header(100, "0xb", "0xa")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/eth/v1/beacon/headers/0xc2" {
+			// Ancestors are looked up without holding the client lock
+			if client.mu.TryLock() {
+				client.mu.Unlock()
+			} else {
+				lockedDuringLookup = true
+			}
+		}
+		endpoints := map[string]struct {
+			Status int
+			Body   string
+		}{
+			"/eth/v1/beacon/genesis":                          {Status: http.StatusOK, Body: `{"data": {"genesis_time": "1606824023"}}`},
+			"/eth/v1/config/spec":                             {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+			"/eth/v1/node/syncing":                            {Status: http.StatusOK, Body: testutil.NotSyncingResponse},
+			"/eth/v1/beacon/states/head/finality_checkpoints": {Status: http.StatusOK, Body: `{"data": {"current_justified": {"epoch": "3"}, "finalized": {"epoch": "2"}}}`},
+			"/eth/v1/beacon/headers":                          {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": [%s]}`, head)},
+			"/eth/v1/beacon/headers/0xc2":                     {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": %s}`, header(101, "0xc2", "0xb"))},
+		}
+		testutil.MockHTTPEndpoints(endpoints)(w, r)
+	}
+	server := testutil.HTTPTestServer(t, handler)
+	client = NewConsensusClient("test", server.URL)
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "0xb", info.HeadRoot)
+
+	mu.Lock()
+	head = header(101, "0xc", "0xb")
+	mu.Unlock()
+	info, err = client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "0xc", info.HeadRoot)
+	assert.Equal(t, uint64(0), info.ReorgCount)
+	assert.Nil(t, info.LastReorg)
+
+	// New head on a sibling of the previous head, via an unseen parent
+	mu.Lock()
+	head = header(102, "0xd2", "0xc2")
+	mu.Unlock()
+	info, err = client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "0xd2", info.HeadRoot)
+	assert.Equal(t, uint64(1), info.ReorgCount)
+	require.NotNil(t, info.LastReorg)
+	assert.Equal(t, "0xc", info.LastReorg.OldHeadRoot)
+	assert.Equal(t, "0xd2", info.LastReorg.NewHeadRoot)
+	assert.Equal(t, uint64(102), info.LastReorg.Slot)
+	assert.False(t, lockedDuringLookup)
+
+	// The same reorg reported by the event stream is not counted twice
+	assert.Nil(t, client.applyEvent("chain_reorg", []byte(`{"slot":"102","depth":"1","old_head_block":"0xc","new_head_block":"0xd2"}`)))
+	reorged := client.applyEvent("chain_reorg", []byte(`{"slot":"103","depth":"2","old_head_block":"0xd2","new_head_block":"0xe"}`))
+	require.NotNil(t, reorged)
+	assert.Equal(t, uint64(2), reorged.ReorgCount)
+	assert.Equal(t, uint64(2), reorged.LastReorg.Depth)
+}
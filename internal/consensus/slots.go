@@ -0,0 +1,186 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+const (
+	// Number of epochs of block production kept, including the current one
+	trackedEpochs = 3
+	// Number of slots checked per poll, so that catching up after startup
+	// does not hold up the rest of the update
+	maxSlotLookups = 16
+)
+
+type SlotStatus int
+
+const (
+	SlotPending  SlotStatus = iota // Outcome not known yet, or slot in the future
+	SlotProposed                   // Block is in the node's canonical chain
+	SlotMissed                     // No block for the slot in the node's canonical chain
+)
+
+type ProposerDuty struct {
+	Slot           uint64
+	ValidatorIndex uint64
+	Pubkey         string
+}
+
+// SlotRecord is the outcome of a single slot as seen by a node
+type SlotRecord struct {
+	Slot     uint64
+	Status   SlotStatus
+	Root     string
+	Proposer *ProposerDuty // Nil if the proposer is not known
+}
+
+// EpochSlots holds the outcome of every slot in an epoch
+type EpochSlots struct {
+	Epoch uint64
+	Slots []SlotRecord
+}
+
+// Missed returns the number of missed slots and the number of slots whose
+// outcome is known
+func (e EpochSlots) Missed() (missed, known int) {
+	for _, slot := range e.Slots {
+		switch slot.Status {
+		case SlotMissed:
+			missed++
+			known++
+		case SlotProposed:
+			known++
+		}
+	}
+	return missed, known
+}
+
+// MissedSlots returns the number of missed and known slots across epochs
+func MissedSlots(history []EpochSlots) (missed, known int) {
+	for _, epoch := range history {
+		m, k := epoch.Missed()
+		missed += m
+		known += k
+	}
+	return missed, known
+}
+
+// firstTrackedEpoch returns the oldest epoch kept in the slot history
+func firstTrackedEpoch(currentEpoch uint64) uint64 {
+	if currentEpoch < trackedEpochs-1 {
+		return 0
+	}
+	return currentEpoch - (trackedEpochs - 1)
+}
+
+// slotTracker keeps the outcome of slots and the proposer duties for the
+// tracked epochs
+type slotTracker struct {
+	slots  map[uint64]SlotRecord
+	duties map[uint64]map[uint64]ProposerDuty // Epoch, then slot
+}
+
+// prune drops everything before the first tracked epoch
+func (t *slotTracker) prune(firstEpoch, slotsPerEpoch uint64) {
+	firstSlot := epochStartSlot(firstEpoch, slotsPerEpoch)
+	for slot := range t.slots {
+		if slot < firstSlot {
+			delete(t.slots, slot)
+		}
+	}
+	for epoch := range t.duties {
+		if epoch < firstEpoch {
+			delete(t.duties, epoch)
+		}
+	}
+}
+
+// pending returns up to limit slots between first and head, inclusive, with no
+// known outcome, newest first
+func (t *slotTracker) pending(first, head uint64, limit int) []uint64 {
+	var slots []uint64
+	for slot := head; slot >= first && len(slots) < limit; slot-- {
+		if _, ok := t.slots[slot]; !ok {
+			slots = append(slots, slot)
+		}
+		if slot == 0 {
+			break
+		}
+	}
+	return slots
+}
+
+// missingDuties returns the epochs between first and last, inclusive, for
+// which proposer duties have not been fetched
+func (t *slotTracker) missingDuties(first, last uint64) []uint64 {
+	var epochs []uint64
+	for epoch := first; epoch <= last; epoch++ {
+		if _, ok := t.duties[epoch]; !ok {
+			epochs = append(epochs, epoch)
+		}
+	}
+	return epochs
+}
+
+func (t *slotTracker) record(record SlotRecord) {
+	if t.slots == nil {
+		t.slots = make(map[uint64]SlotRecord)
+	}
+	t.slots[record.Slot] = record
+}
+
+func (t *slotTracker) setDuties(epoch uint64, duties []ProposerDuty) {
+	if t.duties == nil {
+		t.duties = make(map[uint64]map[uint64]ProposerDuty)
+	}
+	bySlot := make(map[uint64]ProposerDuty, len(duties))
+	for _, duty := range duties {
+		bySlot[duty.Slot] = duty
+	}
+	t.duties[epoch] = bySlot
+}
+
+// invalidateFrom forgets the outcome of slots from the given slot onwards, so
+// that they are checked again after a reorg
+func (t *slotTracker) invalidateFrom(slot uint64) {
+	for s := range t.slots {
+		if s >= slot {
+			delete(t.slots, s)
+		}
+	}
+}
+
+// apply fills in the slot history of the tracked epochs up to the node's
+// current epoch
+func (t *slotTracker) apply(info *ConsensusNodeInfo, slotsPerEpoch uint64) {
+	first := firstTrackedEpoch(info.CurrentEpoch)
+	history := make([]EpochSlots, 0, info.CurrentEpoch-first+1)
+	for epoch := first; epoch <= info.CurrentEpoch; epoch++ {
+		start := epochStartSlot(epoch, slotsPerEpoch)
+		slots := make([]SlotRecord, slotsPerEpoch)
+		for i := range slots {
+			slot := start + uint64(i)
+			record, ok := t.slots[slot]
+			if !ok {
+				record = SlotRecord{Slot: slot, Status: SlotPending}
+			}
+			if record.Proposer == nil {
+				if duty, ok := t.duties[epoch][slot]; ok {
+					record.Proposer = &duty
+				}
+			}
+			slots[i] = record
+		}
+		history = append(history, EpochSlots{Epoch: epoch, Slots: slots})
+	}
+	info.SlotHistory = history
+}
@@ -0,0 +1,171 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestSlotTracker(t *testing.T) {
+	var tracker slotTracker
+	tracker.setDuties(1, []ProposerDuty{{Slot: 33, ValidatorIndex: 7}})
+	tracker.record(SlotRecord{Slot: 32, Status: SlotProposed, Root: "0x20"})
+	tracker.record(SlotRecord{Slot: 33, Status: SlotMissed})
+	tracker.record(SlotRecord{Slot: 5, Status: SlotProposed})
+
+	assert.Equal(t, []uint64{36, 35, 34}, tracker.pending(32, 36, 5))
+	assert.Equal(t, []uint64{36, 35}, tracker.pending(32, 36, 2))
+	assert.Equal(t, []uint64{0, 2}, tracker.missingDuties(0, 2))
+
+	tracker.prune(1, 32)
+	assert.NotContains(t, tracker.slots, uint64(5))
+	assert.Contains(t, tracker.slots, uint64(32))
+
+	info := ConsensusNodeInfo{CurrentEpoch: 1}
+	tracker.apply(&info, 32)
+	require.Len(t, info.SlotHistory, 2)
+	epoch := info.SlotHistory[1]
+	assert.Equal(t, uint64(1), epoch.Epoch)
+	require.Len(t, epoch.Slots, 32)
+	assert.Equal(t, SlotProposed, epoch.Slots[0].Status)
+	assert.Equal(t, SlotMissed, epoch.Slots[1].Status)
+	require.NotNil(t, epoch.Slots[1].Proposer)
+	assert.Equal(t, uint64(7), epoch.Slots[1].Proposer.ValidatorIndex)
+	assert.Equal(t, SlotPending, epoch.Slots[2].Status)
+
+	missed, known := MissedSlots(info.SlotHistory)
+	assert.Equal(t, 1, missed)
+	assert.Equal(t, 2, known)
+
+	tracker.invalidateFrom(33)
+	assert.Contains(t, tracker.slots, uint64(32))
+	assert.NotContains(t, tracker.slots, uint64(33))
+}
+
+func TestFirstTrackedEpoch(t *testing.T) {
+	assert.Equal(t, uint64(0), firstTrackedEpoch(0))
+	assert.Equal(t, uint64(0), firstTrackedEpoch(trackedEpochs-1))
+	assert.Equal(t, uint64(8), firstTrackedEpoch(8+trackedEpochs-1))
+}
+
+func TestConsensusClient_GetNodeInfoTracksSlots(t *testing.T) {
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	header := func(slot int) string {
+		return fmt.Sprintf(`{"root": "0x%x", "header": {"message": {"slot": "%d", "proposer_index": "%d", "parent_root": "0x%x"}}}`,
+			slot, slot, 1000+slot, slot-1)
+	}
+
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		endpoints := map[string]struct {
+			Status int
+			Body   string
+		}{
+			"/eth/v1/beacon/genesis":                          {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": {"genesis_time": "%d"}}`, genesis.Unix())},
+			"/eth/v1/config/spec":                             {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+			"/eth/v1/node/syncing":                            {Status: http.StatusOK, Body: testutil.NotSyncingResponse},
+			"/eth/v1/beacon/states/head/finality_checkpoints": {Status: http.StatusOK, Body: `{"data": {"current_justified": {"epoch": "2"}, "finalized": {"epoch": "1"}}}`},
+			"/eth/v1/beacon/headers":                          {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": [%s]}`, header(100))},
+			"/eth/v1/validator/duties/proposer/3":             {Status: http.StatusOK, Body: `{"data": [{"pubkey": "0xaa", "validator_index": "42", "slot": "98"}]}`},
+		}
+		if slot, ok := strings.CutPrefix(r.URL.Path, "/eth/v1/beacon/headers/"); ok {
+			n, _ := strconv.Atoi(slot)
+			if n == 98 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, header(n))
+			return
+		}
+		testutil.MockHTTPEndpoints(endpoints)(w, r)
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	require.True(t, info.IsConnected)
+	require.Len(t, info.SlotHistory, trackedEpochs)
+
+	current := info.SlotHistory[trackedEpochs-1]
+	assert.Equal(t, uint64(3), current.Epoch)
+	slot := func(n uint64) SlotRecord { return current.Slots[n-current.Epoch*32] }
+
+	assert.Equal(t, SlotMissed, slot(98).Status)
+	require.NotNil(t, slot(98).Proposer)
+	assert.Equal(t, uint64(42), slot(98).Proposer.ValidatorIndex)
+	assert.Equal(t, SlotProposed, slot(99).Status)
+	assert.Equal(t, uint64(1099), slot(99).Proposer.ValidatorIndex)
+	assert.Equal(t, SlotPending, slot(101).Status, "future slots are pending")
+
+	// Slots are checked a limited number at a time, newest first
+	missed, known := MissedSlots(info.SlotHistory)
+	assert.Equal(t, 1, missed)
+	assert.Equal(t, maxSlotLookups, known)
+
+	info, err = client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	_, known = MissedSlots(info.SlotHistory)
+	assert.Equal(t, 2*maxSlotLookups, known)
+}
+
+func TestConsensusClient_GetNodeInfoSlowDuties(t *testing.T) {
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	header := func(slot int) string {
+		return fmt.Sprintf(`{"root": "0x%x", "header": {"message": {"slot": "%d", "proposer_index": "%d", "parent_root": "0x%x"}}}`,
+			slot, slot, 1000+slot, slot-1)
+	}
+
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		endpoints := map[string]struct {
+			Status int
+			Body   string
+		}{
+			"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": {"genesis_time": "%d"}}`, genesis.Unix())},
+			"/eth/v1/config/spec":    {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+			"/eth/v1/node/syncing":   {Status: http.StatusOK, Body: testutil.NotSyncingResponse},
+			"/eth/v1/beacon/headers": {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": [%s]}`, header(100))},
+		}
+		if strings.HasPrefix(r.URL.Path, "/eth/v1/validator/duties/proposer/") {
+			// Answers only once the poll has given up
+			<-r.Context().Done()
+			return
+		}
+		if slot, ok := strings.CutPrefix(r.URL.Path, "/eth/v1/beacon/headers/"); ok {
+			n, _ := strconv.Atoi(slot)
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, header(n))
+			return
+		}
+		testutil.MockHTTPEndpoints(endpoints)(w, r)
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	info, err := client.GetNodeInfo(ctx)
+	require.NoError(t, err)
+	require.True(t, info.IsConnected)
+
+	// Slots are checked while the duties are outstanding
+	_, known := MissedSlots(info.SlotHistory)
+	assert.Equal(t, maxSlotLookups, known)
+}
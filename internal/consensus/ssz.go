@@ -0,0 +1,176 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+const (
+	// Prefer SSZ, but take JSON from nodes that do not serve it
+	acceptSSZ = "application/octet-stream;q=1.0,application/json;q=0.9"
+
+	// Size of a blob sidecar: index, blob, KZG commitment and proof, signed
+	// block header and commitment inclusion proof
+	sszBlobSidecarSize = 8 + 131072 + 48 + 48 + (112 + 96) + 17*32
+
+	// Offset of the block in a signed block, ahead of the signature
+	sszSignedBlockFixedSize = 4 + 96
+	// Size of the fixed part of a block: slot, proposer index, parent and
+	// state roots, and the offset of the body
+	sszBlockFixedSize = 8 + 8 + 32 + 32 + 4
+	// Offset of the sync aggregate in a block body, after the RANDAO reveal,
+	// eth1 data, graffiti and the offsets of the five operation lists
+	sszSyncAggregateOffset = 96 + (32 + 8 + 32) + 32 + 5*4
+	// The sync aggregate is the committee bits, whose size depends on the
+	// preset, followed by the signature
+	sszSyncCommitteeSignatureSize = 96
+	sszKZGCommitmentSize          = 48
+)
+
+// sszUnmarshaler is implemented by responses that can also be decoded from
+// SSZ, which encodes the data of the JSON response
+type sszUnmarshaler interface {
+	UnmarshalSSZ(data []byte) error
+}
+
+// getSSZ asks for the response in SSZ, which is far cheaper to decode than
+// JSON for large payloads, and decodes it as JSON if the node does not serve
+// SSZ for the endpoint. SSZ that cannot be decoded, e.g. of a fork we do not
+// know the layout of, is asked for again in JSON.
+func (c *ConsensusClient) getSSZ(ctx context.Context, path string, v sszUnmarshaler) error {
+	resp, err := c.send(ctx, http.MethodGet, path, acceptSSZ, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return &statusError{StatusCode: resp.StatusCode, Path: path}
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(resp.ContentType); err == nil && mediaType == "application/octet-stream" {
+		err := v.UnmarshalSSZ(resp.Body)
+		if err == nil {
+			return nil
+		}
+		logger.Debug("[%s]: Failed to decode SSZ from %s, asking for JSON: %v", c.name, path, err)
+		return c.get(ctx, path, v)
+	}
+	return c.decodeJSON(path, resp.Body, v)
+}
+
+// newBlockResponse returns a response to decode a block of the chain into,
+// as the SSZ layout of blocks depends on the size of its sync committee
+func newBlockResponse(chainConfig *ChainConfig) *BlockResponse {
+	return &BlockResponse{syncCommitteeSize: chainConfig.SyncCommitteeSize}
+}
+
+// UnmarshalSSZ decodes the slot, sync aggregate and blob KZG commitments of
+// a signed block from Altair on, skipping everything else. Blocks and blinded
+// blocks share the layout of these fields. The response is left as it was if
+// the block cannot be decoded.
+func (r *BlockResponse) UnmarshalSSZ(data []byte) error {
+	syncCommitteeSize := r.syncCommitteeSize
+	if syncCommitteeSize == 0 {
+		syncCommitteeSize = defaultSyncCommitteeSize
+	}
+	syncBitsSize := int(syncCommitteeSize+7) / 8
+	// Size of the fixed part of an Altair block body, which ends with the
+	// sync aggregate. Each fork since adds the offset of a field: execution
+	// payload, BLS to execution changes, blob KZG commitments and execution
+	// requests.
+	altairBodyFixedSize := sszSyncAggregateOffset + syncBitsSize + sszSyncCommitteeSignatureSize
+
+	if len(data) < sszSignedBlockFixedSize+sszBlockFixedSize {
+		return fmt.Errorf("block of %d bytes is too short", len(data))
+	}
+	if offset := binary.LittleEndian.Uint32(data[0:4]); offset != sszSignedBlockFixedSize {
+		return fmt.Errorf("unexpected block offset %d", offset)
+	}
+	block := data[sszSignedBlockFixedSize:]
+	if offset := binary.LittleEndian.Uint32(block[80:84]); offset != sszBlockFixedSize {
+		return fmt.Errorf("unexpected body offset %d", offset)
+	}
+	body := block[sszBlockFixedSize:]
+	if len(body) < altairBodyFixedSize {
+		return fmt.Errorf("block body of %d bytes is too short", len(body))
+	}
+
+	// The first variable field starts where the fixed part ends, which tells
+	// how many fields the fork added after the sync aggregate
+	fixedSize := int(binary.LittleEndian.Uint32(body[sszSyncAggregateOffset-5*4:]))
+	extra := fixedSize - altairBodyFixedSize
+	if extra < 0 || extra%4 != 0 || extra/4 > 4 || fixedSize > len(body) {
+		return fmt.Errorf("unexpected block body layout with %d fixed bytes", fixedSize)
+	}
+
+	decoded := BlockResponse{syncCommitteeSize: r.syncCommitteeSize}
+	message := &decoded.Data.Message
+	message.Slot = strconv.FormatUint(binary.LittleEndian.Uint64(block[0:8]), 10)
+	message.Body.SyncAggregate.SyncCommitteeBits = hexBytes(body[sszSyncAggregateOffset : sszSyncAggregateOffset+syncBitsSize])
+
+	// Blob KZG commitments from Deneb, the third field after the sync
+	// aggregate, up to the execution requests from Electra or the end
+	fields := extra / 4
+	if fields < 3 {
+		*r = decoded
+		return nil
+	}
+	field := func(i int) int {
+		return int(binary.LittleEndian.Uint32(body[altairBodyFixedSize+i*4:]))
+	}
+	start, end := field(2), len(body)
+	if fields > 3 {
+		end = field(3)
+	}
+	if start < fixedSize || start > end || end > len(body) || (end-start)%sszKZGCommitmentSize != 0 {
+		return fmt.Errorf("invalid blob KZG commitments from %d to %d", start, end)
+	}
+	message.Body.BlobKZGCommitments = make([]string, 0, (end-start)/sszKZGCommitmentSize)
+	for i := start; i < end; i += sszKZGCommitmentSize {
+		message.Body.BlobKZGCommitments = append(message.Body.BlobKZGCommitments, hexBytes(body[i:i+sszKZGCommitmentSize]))
+	}
+	*r = decoded
+	return nil
+}
+
+// UnmarshalSSZ decodes a list of blob sidecars, keeping only their indices
+func (r *BlobSidecarsResponse) UnmarshalSSZ(data []byte) error {
+	if len(data)%sszBlobSidecarSize != 0 {
+		return fmt.Errorf("blob sidecars of %d bytes are not a multiple of %d", len(data), sszBlobSidecarSize)
+	}
+
+	r.Data = make([]BlobSidecar, len(data)/sszBlobSidecarSize)
+	for i := range r.Data {
+		r.Data[i].Index = strconv.FormatUint(binary.LittleEndian.Uint64(data[i*sszBlobSidecarSize:]), 10)
+	}
+	return nil
+}
+
+// hexBytes returns bytes as 0x-prefixed hex, as the beacon API encodes them
+// in JSON
+func hexBytes(data []byte) string {
+	encoded := make([]byte, 2+hex.EncodedLen(len(data)))
+	encoded[0], encoded[1] = '0', 'x'
+	hex.Encode(encoded[2:], data)
+	return string(encoded)
+}
@@ -0,0 +1,396 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+// sszField is a field of an SSZ container, encoded in place if fixed size or
+// after the fixed part with an offset in its place if variable size
+type sszField struct {
+	data     []byte
+	variable bool
+}
+
+func fixed(data []byte) sszField    { return sszField{data: data} }
+func variable(data []byte) sszField { return sszField{data: data, variable: true} }
+
+// sszContainer encodes a container from its fields, in spec order
+func sszContainer(fields ...sszField) []byte {
+	fixedSize := 0
+	for _, field := range fields {
+		if field.variable {
+			fixedSize += 4
+		} else {
+			fixedSize += len(field.data)
+		}
+	}
+	var head, tail []byte
+	for _, field := range fields {
+		if field.variable {
+			head = binary.LittleEndian.AppendUint32(head, uint32(fixedSize+len(tail)))
+			tail = append(tail, field.data...)
+		} else {
+			head = append(head, field.data...)
+		}
+	}
+	return append(head, tail...)
+}
+
+// sszList encodes a list of variable size elements, which is prefixed with
+// the offsets of the elements. Lists of fixed size elements are their
+// concatenation.
+func sszList(elements ...[]byte) []byte {
+	fields := make([]sszField, len(elements))
+	for i, element := range elements {
+		fields[i] = variable(element)
+	}
+	return sszContainer(fields...)
+}
+
+func sszUint64(v uint64) []byte { return binary.LittleEndian.AppendUint64(nil, v) }
+
+// filled returns size bytes of a repeated value, standing in for roots,
+// signatures and the like
+func filled(size int, value byte) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = value
+	}
+	return data
+}
+
+// testBlock holds the values of a block that end up in both its JSON and SSZ
+// encoding
+type testBlock struct {
+	fork         string // deneb or electra
+	slot         uint64
+	syncBits     []byte
+	commitments  [][]byte
+	transactions [][]byte
+	attestations int
+}
+
+// ssz encodes the block as a SignedBeaconBlock of the consensus specs
+func (b testBlock) ssz() []byte {
+	attestations := make([][]byte, b.attestations)
+	for i := range attestations {
+		// Electra attestation: aggregation bits, data, signature, committee bits
+		aggregationBits := append(filled(64, 0xff), 0x01)
+		data := sszContainer(
+			fixed(sszUint64(b.slot-1)), fixed(sszUint64(uint64(i))), fixed(filled(32, 0x11)),
+			fixed(append(sszUint64(100), filled(32, 0x12)...)),
+			fixed(append(sszUint64(101), filled(32, 0x13)...)),
+		)
+		attestations[i] = sszContainer(variable(aggregationBits), fixed(data), fixed(filled(96, 0x14)), fixed(filled(8, 0x01)))
+	}
+	var withdrawals []byte
+	for i := 0; i < 16; i++ {
+		withdrawals = append(withdrawals, sszContainer(
+			fixed(sszUint64(uint64(i))), fixed(sszUint64(uint64(1000+i))), fixed(filled(20, 0x21)), fixed(sszUint64(12345)),
+		)...)
+	}
+	payload := sszContainer(
+		fixed(filled(32, 0x31)), fixed(filled(20, 0x32)), fixed(filled(32, 0x33)), fixed(filled(32, 0x34)),
+		fixed(filled(256, 0x35)), fixed(filled(32, 0x36)),
+		fixed(sszUint64(21000000)), fixed(sszUint64(36000000)), fixed(sszUint64(18000000)), fixed(sszUint64(1700000000)),
+		variable([]byte("watcheth")), fixed(filled(32, 0x37)), fixed(filled(32, 0x38)),
+		variable(sszList(b.transactions...)), variable(withdrawals),
+		fixed(sszUint64(393216)), fixed(sszUint64(0)),
+	)
+	var commitments []byte
+	for _, commitment := range b.commitments {
+		commitments = append(commitments, commitment...)
+	}
+
+	eth1Data := sszContainer(fixed(filled(32, 0x42)), fixed(sszUint64(5)), fixed(filled(32, 0x43)))
+	syncAggregate := append(append([]byte{}, b.syncBits...), filled(96, 0x45)...)
+	bodyFields := []sszField{
+		fixed(filled(96, 0x41)),            // randao_reveal
+		fixed(eth1Data),                    // eth1_data
+		fixed(filled(32, 0x44)),            // graffiti
+		variable(nil),                      // proposer_slashings
+		variable(nil),                      // attester_slashings
+		variable(sszList(attestations...)), // attestations
+		variable(nil),                      // deposits
+		variable(nil),                      // voluntary_exits
+		fixed(syncAggregate),               // sync_aggregate
+		variable(payload),                  // execution_payload
+		variable(nil),                      // bls_to_execution_changes
+		variable(commitments),              // blob_kzg_commitments
+	}
+	if b.fork == "electra" {
+		// execution_requests: deposits, withdrawals and consolidations
+		bodyFields = append(bodyFields, variable(sszContainer(variable(nil), variable(nil), variable(nil))))
+	}
+	block := sszContainer(
+		fixed(sszUint64(b.slot)), fixed(sszUint64(42)), fixed(filled(32, 0x51)), fixed(filled(32, 0x52)),
+		variable(sszContainer(bodyFields...)),
+	)
+	return sszContainer(variable(block), fixed(filled(96, 0x53)))
+}
+
+// json encodes the block as the beacon API does
+func (b testBlock) json() []byte {
+	commitments := make([]string, len(b.commitments))
+	for i, commitment := range b.commitments {
+		commitments[i] = hexBytes(commitment)
+	}
+	transactions := make([]string, len(b.transactions))
+	for i, transaction := range b.transactions {
+		transactions[i] = hexBytes(transaction)
+	}
+	attestations := make([]map[string]any, b.attestations)
+	for i := range attestations {
+		attestations[i] = map[string]any{
+			"aggregation_bits": hexBytes(append(filled(64, 0xff), 0x01)),
+			"data": map[string]any{
+				"slot": fmt.Sprint(b.slot - 1), "index": fmt.Sprint(i), "beacon_block_root": hexBytes(filled(32, 0x11)),
+				"source": map[string]any{"epoch": "100", "root": hexBytes(filled(32, 0x12))},
+				"target": map[string]any{"epoch": "101", "root": hexBytes(filled(32, 0x13))},
+			},
+			"signature":      hexBytes(filled(96, 0x14)),
+			"committee_bits": hexBytes(filled(8, 0x01)),
+		}
+	}
+	data, err := json.Marshal(map[string]any{
+		"version": b.fork,
+		"data": map[string]any{
+			"message": map[string]any{
+				"slot": fmt.Sprint(b.slot), "proposer_index": "42",
+				"parent_root": hexBytes(filled(32, 0x51)), "state_root": hexBytes(filled(32, 0x52)),
+				"body": map[string]any{
+					"randao_reveal":  hexBytes(filled(96, 0x41)),
+					"attestations":   attestations,
+					"sync_aggregate": map[string]any{"sync_committee_bits": hexBytes(b.syncBits), "sync_committee_signature": hexBytes(filled(96, 0x45))},
+					"execution_payload": map[string]any{
+						"block_number": "21000000", "extra_data": hexBytes([]byte("watcheth")), "transactions": transactions,
+					},
+					"blob_kzg_commitments": commitments,
+				},
+			},
+			"signature": hexBytes(filled(96, 0x53)),
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// mainnetBlock returns a block the size of a busy mainnet block, with 200
+// transactions, 8 aggregate attestations and 6 blobs
+func mainnetBlock(fork string) testBlock {
+	block := testBlock{fork: fork, slot: 11000000, syncBits: filled(64, 0xfe), attestations: 8}
+	for i := 0; i < 6; i++ {
+		block.commitments = append(block.commitments, filled(48, byte(0xa0+i)))
+	}
+	for i := 0; i < 200; i++ {
+		block.transactions = append(block.transactions, filled(400+i*4, byte(i)))
+	}
+	return block
+}
+
+func TestBlockResponse_UnmarshalSSZ(t *testing.T) {
+	for _, fork := range []string{"deneb", "electra"} {
+		t.Run(fork, func(t *testing.T) {
+			block := mainnetBlock(fork)
+
+			var expected BlockResponse
+			require.NoError(t, json.Unmarshal(block.json(), &expected))
+
+			var resp BlockResponse
+			require.NoError(t, resp.UnmarshalSSZ(block.ssz()))
+			assert.Equal(t, expected, resp)
+			assert.Equal(t, "11000000", resp.Data.Message.Slot)
+			assert.Len(t, resp.Data.Message.Body.BlobKZGCommitments, 6)
+		})
+	}
+
+	block := mainnetBlock("electra")
+	block.commitments = nil
+	var resp BlockResponse
+	require.NoError(t, resp.UnmarshalSSZ(block.ssz()))
+	assert.Empty(t, resp.Data.Message.Body.BlobKZGCommitments)
+
+	// The minimal preset has a sync committee of 32, so 4 bytes of bits
+	block = mainnetBlock("electra")
+	block.syncBits = filled(4, 0x0f)
+	minimal := newBlockResponse(&ChainConfig{SyncCommitteeSize: 32})
+	require.NoError(t, minimal.UnmarshalSSZ(block.ssz()))
+	assert.Equal(t, "0x0f0f0f0f", minimal.Data.Message.Body.SyncAggregate.SyncCommitteeBits)
+	assert.Len(t, minimal.Data.Message.Body.BlobKZGCommitments, 6)
+
+	data := mainnetBlock("electra").ssz()
+	assert.Error(t, resp.UnmarshalSSZ(data[:200]), "truncated")
+	data[0] = 0
+	assert.Error(t, resp.UnmarshalSSZ(data), "bad offset")
+	assert.Equal(t, "11000000", resp.Data.Message.Slot, "left as it was")
+}
+
+func TestBlobSidecarsResponse_UnmarshalSSZ(t *testing.T) {
+	// BlobSidecar: index, blob, KZG commitment and proof, signed block header
+	// and commitment inclusion proof
+	sidecar := func(index uint64) []byte {
+		header := sszContainer(
+			fixed(sszUint64(100)), fixed(sszUint64(42)), fixed(filled(32, 1)), fixed(filled(32, 2)), fixed(filled(32, 3)),
+		)
+		return sszContainer(
+			fixed(sszUint64(index)), fixed(filled(131072, 0x61)), fixed(filled(48, 0x62)), fixed(filled(48, 0x63)),
+			fixed(append(header, filled(96, 0x64)...)), fixed(filled(17*32, 0x65)),
+		)
+	}
+	var data []byte
+	for _, index := range []uint64{0, 2, 5} {
+		data = append(data, sidecar(index)...)
+	}
+
+	var resp BlobSidecarsResponse
+	require.NoError(t, resp.UnmarshalSSZ(data))
+	assert.Equal(t, []BlobSidecar{{Index: "0"}, {Index: "2"}, {Index: "5"}}, resp.Data)
+
+	assert.Error(t, resp.UnmarshalSSZ(data[:sszBlobSidecarSize+1]))
+}
+
+func TestConsensusClient_GetSSZ(t *testing.T) {
+	block := mainnetBlock("electra")
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{name: "ssz", contentType: "application/octet-stream", body: block.ssz()},
+		{name: "json fallback", contentType: "application/json; charset=utf-8", body: block.json()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/eth/v2/beacon/blocks/11000000", r.URL.Path)
+				assert.True(t, strings.HasPrefix(r.Header.Get("Accept"), "application/octet-stream"))
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(tt.body)
+			})
+			client := NewConsensusClient("test", server.URL)
+
+			var resp BlockResponse
+			require.NoError(t, client.getSSZ(context.Background(), "/eth/v2/beacon/blocks/11000000", &resp))
+			assert.Equal(t, "11000000", resp.Data.Message.Slot)
+			assert.Len(t, resp.Data.Message.Body.BlobKZGCommitments, 6)
+		})
+	}
+
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPResponse(http.StatusNotFound, ""))
+	client := NewConsensusClient("test", server.URL)
+	var resp BlockResponse
+	err := client.getSSZ(context.Background(), "/eth/v2/beacon/blocks/1", &resp)
+	var statusErr *statusError
+	assert.ErrorAs(t, err, &statusErr)
+}
+
+func TestConsensusClient_GetSSZUndecodable(t *testing.T) {
+	block := mainnetBlock("electra")
+	var accepts []string
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		accepts = append(accepts, r.Header.Get("Accept"))
+		if strings.HasPrefix(r.Header.Get("Accept"), "application/octet-stream") {
+			// A fork whose layout we do not know
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(block.ssz()[:200])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(block.json())
+	})
+	client := NewConsensusClient("test", server.URL)
+
+	var resp BlockResponse
+	require.NoError(t, client.getSSZ(context.Background(), "/eth/v2/beacon/blocks/11000000", &resp))
+	assert.Equal(t, "11000000", resp.Data.Message.Slot)
+	assert.Len(t, resp.Data.Message.Body.BlobKZGCommitments, 6)
+	assert.Equal(t, []string{acceptSSZ, ""}, accepts, "asked again for JSON")
+}
+
+// BenchmarkBlockResponse compares decoding a busy mainnet block from JSON and
+// from SSZ, where the transactions and attestations are skipped rather than
+// parsed
+func BenchmarkBlockResponse(b *testing.B) {
+	block := mainnetBlock("electra")
+	jsonData, sszData := block.json(), block.ssz()
+
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(jsonData)))
+		for i := 0; i < b.N; i++ {
+			var resp BlockResponse
+			if err := json.Unmarshal(jsonData, &resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("ssz", func(b *testing.B) {
+		b.ReportAllocs()
+		b.SetBytes(int64(len(sszData)))
+		for i := 0; i < b.N; i++ {
+			var resp BlockResponse
+			if err := resp.UnmarshalSSZ(sszData); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkValidatorsResponse decodes the validators of a large operator from
+// JSON, which is the only encoding the beacon API serves them in
+func BenchmarkValidatorsResponse(b *testing.B) {
+	for _, count := range []int{1000, 10000} {
+		validators := make([]map[string]any, count)
+		for i := range validators {
+			validators[i] = map[string]any{
+				"index": fmt.Sprint(400000 + i), "balance": "32001234567", "status": "active_ongoing",
+				"validator": map[string]any{
+					"pubkey": hexBytes(filled(48, byte(i))), "withdrawal_credentials": hexBytes(filled(32, 0x01)),
+					"effective_balance": "32000000000", "slashed": false,
+					"activation_eligibility_epoch": "1000", "activation_epoch": "1001",
+					"exit_epoch": "18446744073709551615", "withdrawable_epoch": "18446744073709551615",
+				},
+			}
+		}
+		data, err := json.Marshal(map[string]any{"execution_optimistic": false, "finalized": false, "data": validators})
+		require.NoError(b, err)
+
+		b.Run(fmt.Sprint(count), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				var resp ValidatorsResponse
+				if err := json.Unmarshal(data, &resp); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+const (
+	// Used if the node does not report EPOCHS_PER_SYNC_COMMITTEE_PERIOD
+	defaultEpochsPerSyncCommitteePeriod = 256
+	// Used if the node does not report SYNC_COMMITTEE_SIZE
+	defaultSyncCommitteeSize = 512
+	// Number of recent slots kept for sync committee participation
+	syncTrackedSlots = 32
+)
+
+// SyncCommitteeProvider is implemented by consensus clients that can look up
+// sync committee membership and participation of the configured validators
+type SyncCommitteeProvider interface {
+	ValidatorSource
+	GetSyncCommittees(ctx context.Context) (*SyncCommitteeStatus, error)
+}
+
+// SyncCommitteeMember is one of our validators in a sync committee. A
+// validator can hold more than one position in the same committee.
+type SyncCommitteeMember struct {
+	ValidatorIndex uint64
+	Positions      []int
+}
+
+// SyncCommitteePeriod is a sync committee period with our validators in it
+type SyncCommitteePeriod struct {
+	Period     uint64
+	StartEpoch uint64
+	EndEpoch   uint64 // First epoch of the following period
+	Members    []SyncCommitteeMember
+}
+
+// SyncSlotParticipation is how our sync committee members did in the sync
+// aggregate of a block
+type SyncSlotParticipation struct {
+	Slot         uint64
+	Block        bool     // False if there is no block, and so no aggregate, for the slot
+	Participated int      // Positions held by our validators that signed
+	Expected     int      // Positions held by our validators
+	Missed       []uint64 // Validators with at least one position that did not sign
+}
+
+// SyncCommitteeStatus holds the sync committee duties of the configured
+// validators, and their recent participation while in the current committee
+type SyncCommitteeStatus struct {
+	CurrentSlot   uint64
+	CurrentEpoch  uint64
+	SlotsPerEpoch uint64
+	Current       *SyncCommitteePeriod    // Nil if none of our validators is in the current committee
+	Next          *SyncCommitteePeriod    // Nil if none is in the next committee, or it is not known yet
+	Participation []SyncSlotParticipation // Oldest first
+	UpdatedAt     time.Time
+}
+
+// ParticipationRate returns the number of positions that signed and that
+// were expected to sign across the recent blocks
+func (s *SyncCommitteeStatus) ParticipationRate() (participated, expected int) {
+	if s == nil {
+		return 0, 0
+	}
+	for _, slot := range s.Participation {
+		participated += slot.Participated
+		expected += slot.Expected
+	}
+	return participated, expected
+}
+
+// GetSyncCommittees looks up the configured validators in the current and
+// next sync committee and, while any are in the current one, checks the sync
+// aggregates of recent blocks for their signatures
+func (c *ConsensusClient) GetSyncCommittees(ctx context.Context) (*SyncCommitteeStatus, error) {
+	chainConfig, err := c.chainConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	indices, _, err := c.resolveValidators(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve validators: %w", err)
+	}
+
+	var info ConsensusNodeInfo
+	now := time.Now()
+	applySlotTiming(&info, chainConfig, now)
+
+	status := &SyncCommitteeStatus{
+		CurrentSlot:   info.CurrentSlot,
+		CurrentEpoch:  info.CurrentEpoch,
+		SlotsPerEpoch: chainConfig.SlotsPerEpoch,
+		UpdatedAt:     now,
+	}
+	if len(indices) == 0 {
+		return status, nil
+	}
+
+	epochsPerPeriod := chainConfig.EpochsPerSyncCommitteePeriod
+	if epochsPerPeriod == 0 {
+		epochsPerPeriod = defaultEpochsPerSyncCommitteePeriod
+	}
+	period := info.CurrentEpoch / epochsPerPeriod
+
+	current, err := c.syncCommittee(ctx, period, epochsPerPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync committee for period %d: %w", period, err)
+	}
+	status.Current = syncCommitteeMembership(period, epochsPerPeriod, current, indices)
+
+	// The next committee is only known once the current period has started,
+	// and not every node serves it
+	next, err := c.syncCommittee(ctx, period+1, epochsPerPeriod)
+	if err != nil {
+		logger.Debug("[%s]: failed to get next sync committee: %v", c.name, err)
+	} else {
+		status.Next = syncCommitteeMembership(period+1, epochsPerPeriod, next, indices)
+	}
+
+	if status.Current != nil {
+		firstSlot := epochStartSlot(status.Current.StartEpoch, chainConfig.SlotsPerEpoch)
+		if err := c.updateSyncParticipation(ctx, status.Current, firstSlot, chainConfig); err != nil {
+			logger.Debug("[%s]: failed to update sync committee participation: %v", c.name, err)
+		}
+	}
+
+	c.mu.Lock()
+	for p := range c.syncCommittees {
+		if p < period {
+			delete(c.syncCommittees, p)
+		}
+	}
+	if status.Current != nil {
+		for _, slot := range c.syncSlots {
+			status.Participation = append(status.Participation, slot)
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(status.Participation, func(i, j int) bool {
+		return status.Participation[i].Slot < status.Participation[j].Slot
+	})
+	return status, nil
+}
+
+// syncCommittee returns the validator indices of the sync committee for the
+// period, in committee order. Committees do not change during a period, so
+// they are cached.
+func (c *ConsensusClient) syncCommittee(ctx context.Context, period, epochsPerPeriod uint64) ([]uint64, error) {
+	c.mu.Lock()
+	committee, ok := c.syncCommittees[period]
+	c.mu.Unlock()
+	if ok {
+		return committee, nil
+	}
+
+	var resp SyncCommitteeResponse
+	path := fmt.Sprintf("/eth/v1/beacon/states/head/sync_committees?epoch=%d", period*epochsPerPeriod)
+	if err := c.get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+
+	committee = make([]uint64, len(resp.Data.Validators))
+	for i, validator := range resp.Data.Validators {
+		index, err := strconv.ParseUint(validator, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sync committee member: %w", err)
+		}
+		committee[i] = index
+	}
+
+	c.mu.Lock()
+	if c.syncCommittees == nil {
+		c.syncCommittees = make(map[uint64][]uint64)
+	}
+	c.syncCommittees[period] = committee
+	c.mu.Unlock()
+	return committee, nil
+}
+
+// syncCommitteeMembership returns the positions of our validators in the
+// committee, or nil if none of them is in it
+func syncCommitteeMembership(period, epochsPerPeriod uint64, committee, indices []uint64) *SyncCommitteePeriod {
+	ours := make(map[uint64]bool, len(indices))
+	for _, index := range indices {
+		ours[index] = true
+	}
+
+	positions := make(map[uint64][]int)
+	for position, index := range committee {
+		if ours[index] {
+			positions[index] = append(positions[index], position)
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	membership := &SyncCommitteePeriod{
+		Period:     period,
+		StartEpoch: period * epochsPerPeriod,
+		EndEpoch:   (period + 1) * epochsPerPeriod,
+	}
+	for index, p := range positions {
+		membership.Members = append(membership.Members, SyncCommitteeMember{ValidatorIndex: index, Positions: p})
+	}
+	sort.Slice(membership.Members, func(i, j int) bool {
+		return membership.Members[i].ValidatorIndex < membership.Members[j].ValidatorIndex
+	})
+	return membership
+}
+
+// updateSyncParticipation checks the sync aggregates of recent blocks in the
+// period that have not been checked yet, newest first
+func (c *ConsensusClient) updateSyncParticipation(ctx context.Context, committee *SyncCommitteePeriod, periodStartSlot uint64, chainConfig *ChainConfig) error {
+	header, err := c.getHeader(ctx, "head")
+	if err != nil {
+		return err
+	}
+	head, err := strconv.ParseUint(header.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse head slot: %w", err)
+	}
+
+	first := periodStartSlot
+	if head >= syncTrackedSlots && head-syncTrackedSlots+1 > first {
+		first = head - syncTrackedSlots + 1
+	}
+
+	c.mu.Lock()
+	if c.syncSlots == nil {
+		c.syncSlots = make(map[uint64]SyncSlotParticipation)
+	}
+	for slot := range c.syncSlots {
+		if slot < first {
+			delete(c.syncSlots, slot)
+		}
+	}
+	var pending []uint64
+	for slot := head; slot >= first && len(pending) < maxSlotLookups; slot-- {
+		if _, ok := c.syncSlots[slot]; !ok {
+			pending = append(pending, slot)
+		}
+		if slot == 0 {
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	for _, slot := range pending {
+		participation, err := c.checkSyncAggregate(ctx, slot, committee, chainConfig)
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.syncSlots[slot] = participation
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// checkSyncAggregate works out which of our committee positions signed the
+// sync aggregate in the block at the slot
+func (c *ConsensusClient) checkSyncAggregate(ctx context.Context, slot uint64, committee *SyncCommitteePeriod, chainConfig *ChainConfig) (SyncSlotParticipation, error) {
+	participation := SyncSlotParticipation{Slot: slot}
+
+	resp := newBlockResponse(chainConfig)
+	err := c.getSSZ(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%d", slot), resp)
+	var statusErr *statusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return participation, nil
+	}
+	if err != nil {
+		return participation, err
+	}
+
+	bits, err := hex.DecodeString(strings.TrimPrefix(resp.Data.Message.Body.SyncAggregate.SyncCommitteeBits, "0x"))
+	if err != nil {
+		return participation, fmt.Errorf("failed to parse sync committee bits: %w", err)
+	}
+
+	participation.Block = true
+	for _, member := range committee.Members {
+		missed := false
+		for _, position := range member.Positions {
+			participation.Expected++
+			if bitvectorBitSet(bits, position) {
+				participation.Participated++
+			} else {
+				missed = true
+			}
+		}
+		if missed {
+			participation.Missed = append(participation.Missed, member.ValidatorIndex)
+		}
+	}
+	return participation, nil
+}
+
+// bitvectorBitSet returns whether the bit at the position is set in an SSZ
+// bitvector, which stores the lowest bit of each byte first
+func bitvectorBitSet(bits []byte, position int) bool {
+	if position < 0 || position/8 >= len(bits) {
+		return false
+	}
+	return bits[position/8]&(1<<(position%8)) != 0
+}
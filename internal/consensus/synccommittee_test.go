@@ -0,0 +1,121 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestBitvectorBitSet(t *testing.T) {
+	bits := []byte{0x0a, 0x80}
+	assert.False(t, bitvectorBitSet(bits, 0))
+	assert.True(t, bitvectorBitSet(bits, 1))
+	assert.True(t, bitvectorBitSet(bits, 3))
+	assert.True(t, bitvectorBitSet(bits, 15))
+	assert.False(t, bitvectorBitSet(bits, 16), "out of range")
+	assert.False(t, bitvectorBitSet(bits, -1))
+}
+
+func TestConsensusClient_GetSyncCommittees(t *testing.T) {
+	genesis := time.Now().Add(-100 * 12 * time.Second)
+	var blocks []string
+
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/eth/v1/beacon/states/head/sync_committees":
+			switch r.URL.Query().Get("epoch") {
+			case "0":
+				// Validator 5 holds two positions
+				_, _ = w.Write([]byte(`{"data": {"validators": ["1", "5", "7", "5"]}}`))
+			case "256":
+				_, _ = w.Write([]byte(`{"data": {"validators": ["9", "2"]}}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+			return
+		case r.URL.Path == "/eth/v1/beacon/headers/head":
+			_, _ = w.Write([]byte(`{"data": {"root": "0x01", "header": {"message": {"slot": "99"}}}}`))
+			return
+		case strings.HasPrefix(r.URL.Path, "/eth/v2/beacon/blocks/"):
+			slot := strings.TrimPrefix(r.URL.Path, "/eth/v2/beacon/blocks/")
+			blocks = append(blocks, slot)
+			bits := "0x0a"
+			switch slot {
+			case "98":
+				w.WriteHeader(http.StatusNotFound)
+				return
+			case "97":
+				bits = "0x02"
+			}
+			_, _ = fmt.Fprintf(w, `{"data": {"message": {"slot": "%s", "body": {"sync_aggregate": {"sync_committee_bits": "%s"}}}}}`, slot, bits)
+			return
+		}
+		testutil.MockHTTPEndpoints(map[string]struct {
+			Status int
+			Body   string
+		}{
+			"/eth/v1/beacon/genesis": {Status: http.StatusOK, Body: fmt.Sprintf(`{"data": {"genesis_time": "%d"}}`, genesis.Unix())},
+			"/eth/v1/config/spec":    {Status: http.StatusOK, Body: testutil.ValidChainConfigResponse},
+		})(w, r)
+	})
+
+	client := NewConsensusClient("test", server.URL, WithValidators([]string{"5", "9"}))
+	status, err := client.GetSyncCommittees(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, status.Current)
+	assert.Equal(t, uint64(0), status.Current.Period)
+	assert.Equal(t, uint64(256), status.Current.EndEpoch)
+	assert.Equal(t, []SyncCommitteeMember{{ValidatorIndex: 5, Positions: []int{1, 3}}}, status.Current.Members)
+	require.NotNil(t, status.Next)
+	assert.Equal(t, uint64(256), status.Next.StartEpoch)
+	assert.Equal(t, []SyncCommitteeMember{{ValidatorIndex: 9, Positions: []int{0}}}, status.Next.Members)
+
+	// Newest blocks are checked first, a limited number per call
+	require.Len(t, blocks, maxSlotLookups)
+	assert.Equal(t, "99", blocks[0])
+	require.Len(t, status.Participation, maxSlotLookups)
+	assert.Equal(t, uint64(84), status.Participation[0].Slot)
+	assert.Equal(t, SyncSlotParticipation{Slot: 97, Block: true, Participated: 1, Expected: 2, Missed: []uint64{5}}, status.Participation[13])
+	assert.Equal(t, SyncSlotParticipation{Slot: 98}, status.Participation[14])
+	participated, expected := status.ParticipationRate()
+	assert.Equal(t, 29, participated)
+	assert.Equal(t, 30, expected)
+
+	// Committees are cached and checked blocks are not fetched again
+	blocks = nil
+	status, err = client.GetSyncCommittees(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, blocks, syncTrackedSlots-maxSlotLookups)
+	assert.Len(t, status.Participation, syncTrackedSlots)
+	assert.Equal(t, uint64(68), status.Participation[0].Slot)
+}
+
+func TestSyncCommitteeStatus_NotInCommittee(t *testing.T) {
+	var status *SyncCommitteeStatus
+	participated, expected := status.ParticipationRate()
+	assert.Zero(t, participated)
+	assert.Zero(t, expected)
+
+	assert.Nil(t, syncCommitteeMembership(1, 256, []uint64{1, 2, 3}, []uint64{4}))
+}
@@ -14,7 +14,10 @@
 package consensus
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/watcheth/watcheth/internal/common"
 )
 
 type ConsensusNodeInfo struct {
@@ -22,8 +25,11 @@ type ConsensusNodeInfo struct {
 	Endpoint        string
 	CurrentSlot     uint64
 	HeadSlot        uint64
+	HeadRoot        string
 	JustifiedSlot   uint64
 	FinalizedSlot   uint64
+	JustifiedRoot   string
+	FinalizedRoot   string
 	CurrentEpoch    uint64
 	JustifiedEpoch  uint64
 	FinalizedEpoch  uint64
@@ -34,11 +40,29 @@ type ConsensusNodeInfo struct {
 	TimeToNextSlot  time.Duration
 	TimeToNextEpoch time.Duration
 	IsConnected     bool
+	Health          HealthState // From /eth/v1/node/health
 	LastError       error
 	LastUpdate      time.Time
 	PeerCount       uint64
 	NodeVersion     string
 	CurrentFork     string
+	EventStream     bool // Whether updates are being pushed by the event stream
+	ReorgCount      uint64
+	LastReorg       *ReorgEvent
+	Reorgs          []ReorgEvent      // Most recent reorgs, oldest first
+	SlotHistory     []EpochSlots      // Block production in recent epochs, oldest first
+	Peers           *PeerSummary      // Nil if the peer count is not available
+	Identity        *NodeIdentity     // Nil if the identity is not available
+	CurrentForkName string            // Name of CurrentFork, empty if not known
+	ForkSchedule    []ScheduledFork   // Scheduled forks, oldest first
+	NextFork        *ScheduledFork    // Nil if no fork is scheduled after the current epoch
+	Network         *NetworkConfig    // Genesis and spec, nil if not fetched yet
+	Pool            *OperationPool    // Nil if the pools are not available
+	Blobs           []BlockBlobs      // Blobs in recent blocks, oldest first
+	Metrics         *ClientMetrics    // Nil if no metrics endpoint is configured or it failed
+	HeadArrivals    []HeadArrival     // When recent heads were first seen, oldest first
+	HeadArrival     *HeadArrivalStats // Nil until a head has been seen arriving
+	Sources         common.Sources    // Outcome of each query, by Query name
 }
 
 type GenesisResponse struct {
@@ -50,22 +74,30 @@ type GenesisResponse struct {
 }
 
 type HeadersResponse struct {
-	ExecutionOptimistic bool `json:"execution_optimistic"`
-	Finalized           bool `json:"finalized"`
-	Data                []struct {
-		Root      string `json:"root"`
-		Canonical bool   `json:"canonical"`
-		Header    struct {
-			Message struct {
-				Slot          string `json:"slot"`
-				ProposerIndex string `json:"proposer_index"`
-				ParentRoot    string `json:"parent_root"`
-				StateRoot     string `json:"state_root"`
-				BodyRoot      string `json:"body_root"`
-			} `json:"message"`
-			Signature string `json:"signature"`
-		} `json:"header"`
-	} `json:"data"`
+	ExecutionOptimistic bool         `json:"execution_optimistic"`
+	Finalized           bool         `json:"finalized"`
+	Data                []HeaderData `json:"data"`
+}
+
+type HeaderResponse struct {
+	ExecutionOptimistic bool       `json:"execution_optimistic"`
+	Finalized           bool       `json:"finalized"`
+	Data                HeaderData `json:"data"`
+}
+
+type HeaderData struct {
+	Root      string `json:"root"`
+	Canonical bool   `json:"canonical"`
+	Header    struct {
+		Message struct {
+			Slot          string `json:"slot"`
+			ProposerIndex string `json:"proposer_index"`
+			ParentRoot    string `json:"parent_root"`
+			StateRoot     string `json:"state_root"`
+			BodyRoot      string `json:"body_root"`
+		} `json:"message"`
+		Signature string `json:"signature"`
+	} `json:"header"`
 }
 
 type FinalityCheckpointsResponse struct {
@@ -91,6 +123,67 @@ type SpecResponse struct {
 	Data map[string]any `json:"data"`
 }
 
+type PoolResponse struct {
+	Data []json.RawMessage `json:"data"`
+}
+
+type AttesterSlashingsResponse struct {
+	Data []struct {
+		Attestation1 struct {
+			AttestingIndices []string `json:"attesting_indices"`
+		} `json:"attestation_1"`
+		Attestation2 struct {
+			AttestingIndices []string `json:"attesting_indices"`
+		} `json:"attestation_2"`
+	} `json:"data"`
+}
+
+type ProposerSlashingsResponse struct {
+	Data []struct {
+		SignedHeader1 struct {
+			Message struct {
+				ProposerIndex string `json:"proposer_index"`
+			} `json:"message"`
+		} `json:"signed_header_1"`
+	} `json:"data"`
+}
+
+// BlockResponse holds the parts of a signed block that are looked at: the
+// sync aggregate for sync committee participation, and the blob KZG
+// commitments for blob availability
+type BlockResponse struct {
+	syncCommitteeSize uint64 // Of the chain's preset, to decode SSZ; mainnet's if zero
+
+	Data struct {
+		Message struct {
+			Slot string `json:"slot"`
+			Body struct {
+				SyncAggregate struct {
+					SyncCommitteeBits string `json:"sync_committee_bits"`
+				} `json:"sync_aggregate"`
+				BlobKZGCommitments []string `json:"blob_kzg_commitments"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// BlobSidecarsResponse leaves out the blobs themselves, only the sidecars are
+// counted
+type BlobSidecarsResponse struct {
+	Data []BlobSidecar `json:"data"`
+}
+
+type BlobSidecar struct {
+	Index string `json:"index"`
+}
+
+type DepositContractResponse struct {
+	Data struct {
+		ChainID string `json:"chain_id"`
+		Address string `json:"address"`
+	} `json:"data"`
+}
+
 type SyncingResponse struct {
 	Data struct {
 		HeadSlot     string `json:"head_slot"`
@@ -101,6 +194,20 @@ type SyncingResponse struct {
 	} `json:"data"`
 }
 
+type NodeIdentityResponse struct {
+	Data struct {
+		PeerID             string   `json:"peer_id"`
+		Enr                string   `json:"enr"`
+		P2PAddresses       []string `json:"p2p_addresses"`
+		DiscoveryAddresses []string `json:"discovery_addresses"`
+		Metadata           struct {
+			SeqNumber string `json:"seq_number"`
+			Attnets   string `json:"attnets"`
+			Syncnets  string `json:"syncnets"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
 type NodeVersionResponse struct {
 	Data struct {
 		Version string `json:"version"`
@@ -117,10 +224,22 @@ type PeerCountResponse struct {
 }
 
 type PeersResponse struct {
+	Data []PeerData `json:"data"`
+}
+
+type PeerData struct {
+	PeerID             string `json:"peer_id"`
+	Enr                string `json:"enr"`
+	LastSeenP2PAddress string `json:"last_seen_p2p_address"`
+	State              string `json:"state"`     // connected, connecting, disconnected or disconnecting
+	Direction          string `json:"direction"` // inbound or outbound
+}
+
+type ForkScheduleResponse struct {
 	Data []struct {
-		PeerID    string `json:"peer_id"`
-		State     string `json:"state"`
-		Direction string `json:"direction"`
+		PreviousVersion string `json:"previous_version"`
+		CurrentVersion  string `json:"current_version"`
+		Epoch           string `json:"epoch"`
 	} `json:"data"`
 }
 
@@ -133,8 +252,132 @@ type ForkResponse struct {
 	} `json:"data"`
 }
 
+type ProposerDutiesResponse struct {
+	DependentRoot       string `json:"dependent_root"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Data                []struct {
+		Pubkey         string `json:"pubkey"`
+		ValidatorIndex string `json:"validator_index"`
+		Slot           string `json:"slot"`
+	} `json:"data"`
+}
+
+type AttesterDutiesResponse struct {
+	DependentRoot       string `json:"dependent_root"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+	Data                []struct {
+		Pubkey                  string `json:"pubkey"`
+		ValidatorIndex          string `json:"validator_index"`
+		CommitteeIndex          string `json:"committee_index"`
+		CommitteeLength         string `json:"committee_length"`
+		CommitteesAtSlot        string `json:"committees_at_slot"`
+		ValidatorCommitteeIndex string `json:"validator_committee_index"`
+		Slot                    string `json:"slot"`
+	} `json:"data"`
+}
+
+type ValidatorsResponse struct {
+	ExecutionOptimistic bool            `json:"execution_optimistic"`
+	Finalized           bool            `json:"finalized"`
+	Data                []ValidatorData `json:"data"`
+}
+
+type ValidatorData struct {
+	Index     string `json:"index"`
+	Balance   string `json:"balance"`
+	Status    string `json:"status"`
+	Validator struct {
+		Pubkey                     string `json:"pubkey"`
+		WithdrawalCredentials      string `json:"withdrawal_credentials"`
+		EffectiveBalance           string `json:"effective_balance"`
+		Slashed                    bool   `json:"slashed"`
+		ActivationEligibilityEpoch string `json:"activation_eligibility_epoch"`
+		ActivationEpoch            string `json:"activation_epoch"`
+		ExitEpoch                  string `json:"exit_epoch"`
+		WithdrawableEpoch          string `json:"withdrawable_epoch"`
+	} `json:"validator"`
+}
+
+type AttestationRewardsResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                struct {
+		IdealRewards []IdealAttestationRewards `json:"ideal_rewards"`
+		TotalRewards []AttestationRewards      `json:"total_rewards"`
+	} `json:"data"`
+}
+
+// IdealAttestationRewards are the rewards for a perfect attestation by a
+// validator with the given effective balance, all amounts in Gwei
+type IdealAttestationRewards struct {
+	EffectiveBalance string `json:"effective_balance"`
+	Head             string `json:"head"`
+	Target           string `json:"target"`
+	Source           string `json:"source"`
+	InclusionDelay   string `json:"inclusion_delay"` // Phase 0 only
+	Inactivity       string `json:"inactivity"`
+}
+
+// AttestationRewards are the rewards earned by a validator, all amounts in
+// Gwei and negative for penalties
+type AttestationRewards struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	InclusionDelay string `json:"inclusion_delay"` // Phase 0 only
+	Inactivity     string `json:"inactivity"`
+}
+
+type SyncCommitteeResponse struct {
+	ExecutionOptimistic bool `json:"execution_optimistic"`
+	Finalized           bool `json:"finalized"`
+	Data                struct {
+		Validators []string `json:"validators"`
+	} `json:"data"`
+}
+
+type HeadEvent struct {
+	Slot                      string `json:"slot"`
+	Block                     string `json:"block"`
+	State                     string `json:"state"`
+	EpochTransition           bool   `json:"epoch_transition"`
+	PreviousDutyDependentRoot string `json:"previous_duty_dependent_root"`
+	CurrentDutyDependentRoot  string `json:"current_duty_dependent_root"`
+	ExecutionOptimistic       bool   `json:"execution_optimistic"`
+}
+
+type FinalizedCheckpointEvent struct {
+	Block               string `json:"block"`
+	State               string `json:"state"`
+	Epoch               string `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
+type ChainReorgEvent struct {
+	Slot                string `json:"slot"`
+	Depth               string `json:"depth"`
+	OldHeadBlock        string `json:"old_head_block"`
+	NewHeadBlock        string `json:"new_head_block"`
+	OldHeadState        string `json:"old_head_state"`
+	NewHeadState        string `json:"new_head_state"`
+	Epoch               string `json:"epoch"`
+	ExecutionOptimistic bool   `json:"execution_optimistic"`
+}
+
 type ChainConfig struct {
-	SecondsPerSlot uint64
-	SlotsPerEpoch  uint64
-	GenesisTime    time.Time
+	SecondsPerSlot               uint64
+	SlotsPerEpoch                uint64
+	EpochsPerSyncCommitteePeriod uint64
+	SyncCommitteeSize            uint64
+	GenesisTime                  time.Time
+	Forks                        []ScheduledFork // Forks scheduled in the spec, oldest first
+}
+
+// ShortRoot abbreviates a root for display, e.g. 0x1234…cdef
+func ShortRoot(root string) string {
+	if len(root) <= 14 {
+		return root
+	}
+	return root[:6] + "…" + root[len(root)-4:]
 }
@@ -0,0 +1,156 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// rpcCall is one call of a batch
+type rpcCall struct {
+	Method string
+	Params []interface{}
+	Result interface{} // Response is decoded into this
+}
+
+// rpcRequest is a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+// newRPCRequest returns a request, with empty rather than null params as
+// some nodes insist on an array
+func newRPCRequest(method string, params []interface{}, id int) rpcRequest {
+	if params == nil {
+		params = []interface{}{}
+	}
+	return rpcRequest{JSONRPC: "2.0", Method: method, Params: params, ID: id}
+}
+
+// errBatchUnsupported is returned by nodes that do not answer a batch with an
+// array of responses
+var errBatchUnsupported = errors.New("batch requests not supported")
+
+// How long a node that rejected a batch is sent individual requests before
+// batches are tried again, e.g. in case it was upgraded or reconfigured
+const batchRetryInterval = 30 * time.Minute
+
+// batchRejectedStatuses are the HTTP statuses with which nodes and proxies
+// reject a batch, as opposed to failing to answer at all
+var batchRejectedStatuses = map[int]bool{
+	http.StatusBadRequest:            true,
+	http.StatusMethodNotAllowed:      true,
+	http.StatusRequestEntityTooLarge: true,
+}
+
+// callAll makes the calls in a single batch request, falling back to
+// individual requests for nodes that reject batches until batchRetryInterval
+// has passed. The outcome of each call is recorded against its method in the
+// node's source statuses, and returned in the order of the calls.
+func (c *executionClient) callAll(ctx context.Context, calls []rpcCall) []error {
+	if rejected := c.batchRejected.Load(); rejected == 0 || time.Since(time.Unix(0, rejected)) >= batchRetryInterval {
+		start := time.Now()
+		errs, err := c.callBatch(ctx, calls)
+		if err == nil {
+			for i, call := range calls {
+				c.sources.Observe(call.Method, start, errs[i])
+			}
+			return errs
+		}
+		if !errors.Is(err, errBatchUnsupported) {
+			// The node could not be reached, so individual calls would fail too
+			errs = make([]error, len(calls))
+			for i, call := range calls {
+				errs[i] = err
+				c.sources.Observe(call.Method, start, err)
+			}
+			return errs
+		}
+		logger.Debug("[%s]: Falling back to individual requests: %v", c.name, err)
+		c.batchRejected.Store(time.Now().UnixNano())
+	}
+
+	errs := make([]error, len(calls))
+	for i, call := range calls {
+		errs[i] = c.call(ctx, call.Method, call.Params, call.Result)
+	}
+	return errs
+}
+
+// callBatch sends the calls as a batch and decodes each response, matched by
+// id, into the result of its call. Returns errBatchUnsupported if the node
+// rejected the batch with one of batchRejectedStatuses, or answered with
+// anything other than an array of responses.
+func (c *executionClient) callBatch(ctx context.Context, calls []rpcCall) ([]error, error) {
+	requests := make([]rpcRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = newRPCRequest(call.Method, call.Params, i+1)
+	}
+
+	body, err := c.roundTrip(ctx, requests)
+	if err != nil {
+		var statusErr *httpStatusError
+		if errors.As(err, &statusErr) && batchRejectedStatuses[statusErr.StatusCode] {
+			return nil, fmt.Errorf("%w: %v", errBatchUnsupported, err)
+		}
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil, errBatchUnsupported
+	}
+
+	var responses []json.RawMessage
+	if err := json.Unmarshal(body, &responses); err != nil {
+		return nil, fmt.Errorf("%w: %v", errBatchUnsupported, err)
+	}
+
+	// Responses may come in any order
+	byID := make(map[int]json.RawMessage, len(responses))
+	for _, response := range responses {
+		var envelope struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.Unmarshal(response, &envelope); err != nil {
+			continue
+		}
+		id, err := strconv.Atoi(string(envelope.ID))
+		if err != nil {
+			continue
+		}
+		byID[id] = response
+	}
+
+	errs := make([]error, len(calls))
+	for i, call := range calls {
+		response, ok := byID[i+1]
+		if !ok {
+			errs[i] = fmt.Errorf("no response to %s in batch", call.Method)
+			continue
+		}
+		errs[i] = decodeResponse(response, call.Result)
+	}
+	return errs, nil
+}
@@ -0,0 +1,200 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+// batchHandler answers batches with the result of each method, in reverse
+// order to check that responses are matched by id. Methods without a result
+// are left out of the response. Single requests are passed to single.
+func batchHandler(t *testing.T, results map[string]string, single http.HandlerFunc, batches *int) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if !strings.HasPrefix(strings.TrimSpace(string(body)), "[") {
+			r.Body = io.NopCloser(strings.NewReader(string(body)))
+			single(w, r)
+			return
+		}
+
+		mu.Lock()
+		*batches++
+		mu.Unlock()
+
+		var requests []rpcRequest
+		require.NoError(t, json.Unmarshal(body, &requests))
+		var responses []string
+		for i := len(requests) - 1; i >= 0; i-- {
+			if result, ok := results[requests[i].Method]; ok {
+				responses = append(responses, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, requests[i].ID, result))
+			}
+		}
+		_, _ = fmt.Fprintf(w, "[%s]", strings.Join(responses, ","))
+	}
+}
+
+func TestExecutionClient_GetNodeInfoBatch(t *testing.T) {
+	results := map[string]string{
+		"eth_syncing":          `false`,
+		"eth_blockNumber":      `"0x1234"`,
+		"net_peerCount":        `"0x19"`,
+		"eth_chainId":          `"0x1"`,
+		"eth_gasPrice":         `"0x3b9aca00"`,
+		"web3_clientVersion":   `"Geth/v1.13.0-stable-1234567/linux-amd64/go1.21.0"`,
+		"eth_getBlockByNumber": `{"number":"0x1234","timestamp":"0x65000000","hash":"0xabc","parentHash":"0xdef"}`,
+	}
+	batches := 0
+	single := func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected single request")
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	server := testutil.HTTPTestServer(t, batchHandler(t, results, single, &batches))
+	client := NewClient("test", server.URL)
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, batches, "one round trip")
+	assert.True(t, info.IsConnected)
+	assert.Equal(t, uint64(0x1234), info.CurrentBlock)
+	assert.Equal(t, uint64(25), info.PeerCount)
+	assert.Equal(t, big.NewInt(1), info.ChainID)
+	assert.Equal(t, big.NewInt(1000000000), info.GasPrice)
+	assert.Equal(t, "Geth/v1.13.0-stable-1234567/linux-amd64/go1.21.0", info.NodeVersion)
+	assert.False(t, info.LastBlockTime.IsZero())
+
+	// net_version was left out of the batch response
+	assert.Empty(t, info.NetworkID)
+	assert.Equal(t, []string{"net_version"}, info.Sources.Failed())
+}
+
+func TestExecutionClient_GetNodeInfoBatchFallback(t *testing.T) {
+	tests := []struct {
+		name   string
+		reject http.HandlerFunc
+	}{
+		{
+			name: "error object",
+			reject: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = fmt.Fprint(w, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch requests are disabled"}}`)
+			},
+		},
+		{
+			name: "bad request",
+			reject: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+		},
+		{
+			name: "method not allowed",
+			reject: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			},
+		},
+		{
+			name: "too large",
+			reject: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			batches := 0
+			single := createMockHandler(map[string]string{
+				"eth_syncing":        testutil.NotSyncingRPCResponse,
+				"eth_blockNumber":    `{"jsonrpc":"2.0","id":1,"result":"0x1234"}`,
+				"web3_clientVersion": testutil.ValidClientVersionResponse,
+			})
+			server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				if strings.HasPrefix(string(body), "[") {
+					mu.Lock()
+					batches++
+					mu.Unlock()
+					tt.reject(w, r)
+					return
+				}
+				r.Body = io.NopCloser(strings.NewReader(string(body)))
+				single(w, r)
+			})
+			client := NewClient("test", server.URL).(*executionClient)
+
+			for i := 0; i < 2; i++ {
+				info, err := client.GetNodeInfo(context.Background())
+				require.NoError(t, err)
+				assert.True(t, info.IsConnected)
+				assert.Equal(t, uint64(0x1234), info.CurrentBlock)
+				assert.Equal(t, "Geth/v1.13.0-stable-1234567/linux-amd64/go1.21.0", info.NodeVersion)
+			}
+			mu.Lock()
+			assert.Equal(t, 1, batches, "batches not retried once rejected")
+			mu.Unlock()
+
+			// Tried again after a while
+			client.batchRejected.Store(time.Now().Add(-batchRetryInterval).UnixNano())
+			_, err := client.GetNodeInfo(context.Background())
+			require.NoError(t, err)
+			mu.Lock()
+			assert.Equal(t, 2, batches)
+			mu.Unlock()
+		})
+	}
+}
+
+func TestExecutionClient_GetNodeInfoBatchFailed(t *testing.T) {
+	// A batch failing with any other status is not taken as a rejection
+	batches := 0
+	server := testutil.HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		batches++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client := NewClient("test", server.URL).(*executionClient)
+	info, err := client.GetNodeInfo(context.Background())
+	assert.Error(t, err)
+	assert.False(t, info.IsConnected)
+	assert.Equal(t, 1, batches, "no individual requests")
+	assert.Zero(t, client.batchRejected.Load())
+}
+
+func TestExecutionClient_GetNodeInfoBatchUnreachable(t *testing.T) {
+	server := testutil.HTTPTestServer(t, testutil.MockHTTPResponse(http.StatusOK, ""))
+	endpoint := server.URL
+	server.Close()
+
+	client := NewClient("test", endpoint).(*executionClient)
+	info, err := client.GetNodeInfo(context.Background())
+	assert.Error(t, err)
+	assert.False(t, info.IsConnected)
+	assert.Zero(t, client.batchRejected.Load(), "an unreachable node has not rejected batches")
+}
@@ -17,12 +17,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/watcheth/watcheth/internal/common"
@@ -38,14 +41,63 @@ type executionClient struct {
 	endpoint   string
 	name       string
 	httpClient *http.Client
+	sources    common.SourceTracker
+
+	batchRejected atomic.Int64 // When a batch was last rejected, in Unix nanoseconds, zero if never
+
+	// Persistent connection for ws:// and ipc:// endpoints, nil for HTTP
+	dialStream func(ctx context.Context) (messageConn, error)
+	stream     *streamTransport
+
+	mu        sync.Mutex
+	latest    *ExecutionNodeInfo // Last snapshot, updated by polling and new heads
+	streaming bool               // Whether the new heads subscription is live
+	heads     headTracker
+
+	engine *engineAPI // Nil if no engine endpoint is configured
 }
 
-func NewClient(name, endpoint string) Client {
-	return &executionClient{
+// Option configures optional behaviour of an execution client
+type Option func(*executionClient)
+
+// WithEngineAPI checks the engine API of the node at endpoint, authenticating
+// with the JWT secret in the file at secretPath. An empty endpoint leaves the
+// check off.
+func WithEngineAPI(endpoint, secretPath string) Option {
+	return func(c *executionClient) {
+		if endpoint == "" {
+			return
+		}
+		c.engine = &engineAPI{
+			endpoint:   strings.TrimRight(endpoint, "/"),
+			secretPath: secretPath,
+			httpClient: common.NewHTTPClient(10 * time.Second),
+		}
+	}
+}
+
+// NewClient returns a client for an http(s):// endpoint, or for a ws(s)://
+// or ipc:///path/to/socket endpoint over which new heads are also subscribed
+// to
+func NewClient(name, endpoint string, opts ...Option) Client {
+	c := &executionClient{
 		name:       name,
 		endpoint:   strings.TrimRight(endpoint, "/"),
 		httpClient: common.NewHTTPClient(30 * time.Second),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	switch {
+	case strings.HasPrefix(c.endpoint, "ws://"), strings.HasPrefix(c.endpoint, "wss://"):
+		c.dialStream = dialWebSocket(c.endpoint)
+	case strings.HasPrefix(c.endpoint, "ipc://"):
+		c.dialStream = dialIPC(strings.TrimPrefix(c.endpoint, "ipc://"))
+	}
+	if c.dialStream != nil {
+		c.stream = &streamTransport{dial: c.dialStream}
+	}
+	return c
 }
 
 func (c *executionClient) GetEndpoint() string {
@@ -64,17 +116,58 @@ func (c *executionClient) GetNodeInfo(ctx context.Context) (*ExecutionNodeInfo,
 		LastUpdate:  time.Now(),
 	}
 
-	// Get sync status
-	syncResp, err := c.callRPC(ctx, "eth_syncing", []interface{}{})
-	if err != nil {
-		info.LastError = fmt.Errorf("eth_syncing: %w", err)
-		return info, err
+	// The engine API is a separate endpoint, so check it alongside
+	engine := make(chan *EngineStatus, 1)
+	if c.engine != nil {
+		go func() {
+			engine <- c.engine.check(ctx, &c.sources)
+		}()
+	} else {
+		engine <- nil
 	}
 
-	// Parse sync status
-	var syncData SyncingResponse
-	if err := json.Unmarshal(syncResp, &syncData); err != nil {
-		info.LastError = fmt.Errorf("parse sync response: %w", err)
+	// Everything is fetched in one round trip, so some of it may go unused.
+	// The head comes from the subscription while it is live.
+	streaming := c.headsLive()
+	var (
+		syncData   SyncingResponse
+		blockNum   BlockNumberResponse
+		peerCount  PeerCountResponse
+		chainID    ChainIDResponse
+		gasPrice   GasPriceResponse
+		version    ClientVersionResponse
+		netVersion NetVersionResponse
+		block      BlockResponse
+	)
+	calls := []rpcCall{
+		{Method: "eth_syncing", Result: &syncData},
+		{Method: "net_peerCount", Result: &peerCount},
+		{Method: "eth_chainId", Result: &chainID},
+		{Method: "eth_gasPrice", Result: &gasPrice},
+		{Method: "web3_clientVersion", Result: &version},
+		{Method: "net_version", Result: &netVersion},
+	}
+	if streaming {
+		// Failures from before the subscription went live no longer matter
+		c.sources.Forget("eth_blockNumber", "eth_getBlockByNumber")
+	} else {
+		calls = append(calls,
+			rpcCall{Method: "eth_blockNumber", Result: &blockNum},
+			rpcCall{Method: "eth_getBlockByNumber", Params: []interface{}{"latest", false}, Result: &block},
+		)
+	}
+	errs := make(map[string]error, len(calls))
+	for i, err := range c.callAll(ctx, calls) {
+		errs[calls[i].Method] = err
+	}
+	// A node that answers eth_syncing with an error is up, so it is only
+	// degraded, with its sync status unknown
+	var transportErr *transportError
+	if err := errs["eth_syncing"]; errors.As(err, &transportErr) {
+		info.LastError = fmt.Errorf("eth_syncing: %w", err)
+		info.Engine = <-engine
+		info.Sources = c.sources.Snapshot()
+		c.setLatest(nil)
 		return info, err
 	}
 
@@ -103,100 +196,116 @@ func (c *executionClient) GetNodeInfo(ctx context.Context) (*ExecutionNodeInfo,
 		}
 	}
 
-	// Get current block number if not syncing
-	if !info.IsSyncing {
-		blockResp, err := c.callRPC(ctx, "eth_blockNumber", []interface{}{})
-		if err == nil {
-			var blockNum BlockNumberResponse
-			if err := json.Unmarshal(blockResp, &blockNum); err == nil {
-				info.CurrentBlock = parseHexUint64(blockNum.Result)
-				info.HighestBlock = info.CurrentBlock
-			}
-		}
+	if errs["net_peerCount"] == nil {
+		info.PeerCount = parseHexUint64(peerCount.Result)
 	}
-
-	// Get peer count
-	peerResp, err := c.callRPC(ctx, "net_peerCount", []interface{}{})
-	if err == nil {
-		var peerCount PeerCountResponse
-		if err := json.Unmarshal(peerResp, &peerCount); err == nil {
-			info.PeerCount = parseHexUint64(peerCount.Result)
-		}
+	if errs["eth_chainId"] == nil {
+		info.ChainID = parseHexBigInt(chainID.Result)
 	}
-
-	// Get chain ID
-	chainResp, err := c.callRPC(ctx, "eth_chainId", []interface{}{})
-	if err == nil {
-		var chainID ChainIDResponse
-		if err := json.Unmarshal(chainResp, &chainID); err == nil {
-			info.ChainID = parseHexBigInt(chainID.Result)
-		}
+	if errs["eth_gasPrice"] == nil {
+		info.GasPrice = parseHexBigInt(gasPrice.Result)
 	}
-
-	// Get gas price
-	gasResp, err := c.callRPC(ctx, "eth_gasPrice", []interface{}{})
-	if err == nil {
-		var gasPrice GasPriceResponse
-		if err := json.Unmarshal(gasResp, &gasPrice); err == nil {
-			info.GasPrice = parseHexBigInt(gasPrice.Result)
-		}
+	if errs["web3_clientVersion"] == nil {
+		info.NodeVersion = version.Result
 	}
-
-	// Get client version
-	versionResp, err := c.callRPC(ctx, "web3_clientVersion", []interface{}{})
-	if err == nil {
-		var version ClientVersionResponse
-		if err := json.Unmarshal(versionResp, &version); err == nil {
-			info.NodeVersion = version.Result
-		}
+	if errs["net_version"] == nil {
+		info.NetworkID = netVersion.Result
 	}
 
-	// Get network ID
-	netResp, err := c.callRPC(ctx, "net_version", []interface{}{})
-	if err == nil {
-		var netVersion NetVersionResponse
-		if err := json.Unmarshal(netResp, &netVersion); err == nil {
-			info.NetworkID = netVersion.Result
+	if !streaming {
+		// Get current block number if not syncing
+		if !info.IsSyncing && errs["eth_blockNumber"] == nil {
+			info.CurrentBlock = parseHexUint64(blockNum.Result)
+			info.HighestBlock = info.CurrentBlock
 		}
-	}
 
-	// Get latest block to calculate block time
-	if info.CurrentBlock > 0 {
-		blockResp, err := c.callRPC(ctx, "eth_getBlockByNumber", []interface{}{"latest", false})
-		if err == nil {
-			var block BlockResponse
-			if err := json.Unmarshal(blockResp, &block); err == nil && block.Result != nil {
-				timestamp := parseHexUint64(block.Result.Timestamp)
-				info.LastBlockTime = time.Unix(int64(timestamp), 0)
-				info.BlockTime = time.Since(info.LastBlockTime)
-			}
+		// Latest block to calculate block time
+		if info.CurrentBlock > 0 && errs["eth_getBlockByNumber"] == nil && block.Result != nil {
+			timestamp := parseHexUint64(block.Result.Timestamp)
+			info.LastBlockTime = time.Unix(int64(timestamp), 0)
+			info.BlockTime = time.Since(info.LastBlockTime)
 		}
 	}
 
+	info.Engine = <-engine
+	info.Sources = c.sources.Snapshot()
+	c.setLatest(info)
 	return info, nil
 }
 
-func (c *executionClient) callRPC(ctx context.Context, method string, params []interface{}) ([]byte, error) {
-	payload := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"method":  method,
-		"params":  params,
-		"id":      1,
+// call calls a method and decodes the response into v, recording the outcome
+// against the method in the node's source statuses
+func (c *executionClient) call(ctx context.Context, method string, params []interface{}, v interface{}) error {
+	start := time.Now()
+	resp, err := c.callRPC(ctx, method, params)
+	if err == nil {
+		err = decodeResponse(resp, v)
 	}
+	c.sources.Observe(method, start, err)
+	return err
+}
 
+func (c *executionClient) callRPC(ctx context.Context, method string, params []interface{}) ([]byte, error) {
+	return c.roundTrip(ctx, newRPCRequest(method, params, 1))
+}
+
+// roundTrip sends a request, or a batch of them, over the node's transport
+// and returns the response body
+func (c *executionClient) roundTrip(ctx context.Context, payload interface{}) ([]byte, error) {
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
+	var body []byte
+	if c.stream != nil {
+		body, err = c.stream.roundTrip(ctx, jsonData)
+	} else {
+		body, err = post(ctx, c.httpClient, c.endpoint, jsonData, "")
+	}
+	if err != nil {
+		return nil, &transportError{err: err}
+	}
+	return body, nil
+}
+
+// transportError is returned when a request got no JSON-RPC response at all,
+// as opposed to the node answering with an error
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(jsonData))
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// httpStatusError is returned for responses with a status other than 200
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("http status %d: %s", e.StatusCode, e.Body)
+}
+
+// post sends an encoded request over HTTP, with a bearer token if given, and
+// returns the response body
+func post(ctx context.Context, client *http.Client, endpoint string, jsonData []byte, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http request: %w", err)
 	}
@@ -212,7 +321,7 @@ func (c *executionClient) callRPC(ctx context.Context, method string, params []i
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("http status %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return body, nil
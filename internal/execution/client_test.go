@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/watcheth/watcheth/internal/testutil"
 )
 
@@ -233,13 +234,22 @@ func TestExecutionClient_GetNodeInfo(t *testing.T) {
 			},
 		},
 		{
-			name:      "connection failure",
+			name: "sync status unavailable",
 			responses: map[string]string{
-				// eth_syncing will return error - empty responses means method not found
+				// The mock answers eth_syncing with a "Method not found" error
+				"eth_blockNumber": `{"jsonrpc":"2.0","id":1,"result":"0x1234"}`,
 			},
 			validate: func(t *testing.T, info *ExecutionNodeInfo) {
-				assert.True(t, info.IsConnected) // Actually gets marked as connected after successful RPC call
-				// The mock returns a "Method not found" error which gets parsed successfully
+				// The node answered, so it is up, and a method it does not
+				// serve is not a failure
+				assert.True(t, info.IsConnected)
+				assert.NoError(t, info.LastError)
+				assert.False(t, info.Sources.Degraded())
+				assert.True(t, info.Sources["eth_syncing"].Unsupported)
+				var rpcErr *RPCError
+				require.ErrorAs(t, info.Sources.Err("eth_syncing"), &rpcErr)
+				assert.Equal(t, -32601, rpcErr.Code)
+				assert.Equal(t, uint64(0x1234), info.CurrentBlock)
 			},
 		},
 		{
@@ -248,7 +258,7 @@ func TestExecutionClient_GetNodeInfo(t *testing.T) {
 				"eth_syncing":        testutil.NotSyncingRPCResponse,
 				"eth_blockNumber":    `{"jsonrpc":"2.0","id":1,"result":"0x1234"}`,
 				"web3_clientVersion": testutil.ValidClientVersionResponse,
-				// When methods return JSON-RPC errors, parseHexBigInt returns big.NewInt(0)
+				// Methods that return JSON-RPC errors are unknown rather than zero
 				"eth_chainId":  `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`,
 				"eth_gasPrice": `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"Method not found"}}`,
 			},
@@ -259,9 +269,13 @@ func TestExecutionClient_GetNodeInfo(t *testing.T) {
 				assert.Equal(t, "Geth/v1.13.0-stable-1234567/linux-amd64/go1.21.0", info.NodeVersion)
 				// Optional fields should have zero values
 				assert.Equal(t, uint64(0), info.PeerCount)
-				// When RPC methods return errors, the parse functions return zero values
-				assert.Equal(t, big.NewInt(0), info.ChainID)
-				assert.Equal(t, big.NewInt(0), info.GasPrice)
+				// Methods that return errors are recorded against the method
+				assert.Nil(t, info.ChainID)
+				assert.Nil(t, info.GasPrice)
+				assert.True(t, IsMethodNotFound(info.Sources.Err("eth_chainId")))
+				assert.True(t, IsMethodNotFound(info.Sources.Err("eth_gasPrice")))
+				assert.True(t, IsMethodNotFound(info.Sources.Err("net_peerCount")))
+				assert.False(t, info.Sources.Degraded(), "disabled methods are not failures")
 			},
 		},
 	}
@@ -272,20 +286,33 @@ func TestExecutionClient_GetNodeInfo(t *testing.T) {
 			client := NewClient("test", server.URL)
 
 			info, err := client.GetNodeInfo(context.Background())
-			assert.NotNil(t, info)
-
-			// Check for expected errors
-			if tt.name == "connection failure" {
-				// Connection doesn't actually fail with our mock; it returns JSON-RPC errors
-				// The client will still be marked as connected if HTTP succeeds
-				assert.NoError(t, err)
-			}
-
+			require.NoError(t, err)
 			tt.validate(t, info)
 		})
 	}
 }
 
+func TestExecutionClient_GetNodeInfoSources(t *testing.T) {
+	server := testutil.HTTPTestServer(t, createMockHandler(map[string]string{
+		"eth_syncing":        testutil.NotSyncingRPCResponse,
+		"eth_blockNumber":    `{"jsonrpc":"2.0","id":1,"result":"0x1234"}`,
+		"net_peerCount":      `not json`,
+		"web3_clientVersion": testutil.ValidClientVersionResponse,
+	}))
+	client := NewClient("test", server.URL)
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.True(t, info.IsConnected)
+	assert.True(t, info.Sources.Degraded())
+	assert.Contains(t, info.Sources.Failed(), "net_peerCount")
+	assert.False(t, IsMethodNotFound(info.Sources.Err("net_peerCount")), "malformed rather than disabled")
+	assert.False(t, info.Sources["net_peerCount"].Unsupported)
+	assert.True(t, IsMethodNotFound(info.Sources.Err("eth_chainId")))
+	assert.False(t, info.Sources["web3_clientVersion"].LastSuccess.IsZero())
+	assert.True(t, info.Sources["net_peerCount"].LastSuccess.IsZero())
+}
+
 func TestParseHexUint64(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,206 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/common"
+	"github.com/watcheth/watcheth/internal/version"
+)
+
+const (
+	EngineExchangeCapabilities = "engine_exchangeCapabilities"
+	EngineGetClientVersion     = "engine_getClientVersionV1"
+)
+
+// requiredEngineMethods are the method families a consensus client needs to
+// drive the node, of which any version will do
+var requiredEngineMethods = []string{
+	"engine_newPayload",
+	"engine_forkchoiceUpdated",
+	"engine_getPayload",
+}
+
+// EngineStatus is the outcome of checking the engine API of a node
+type EngineStatus struct {
+	Endpoint       string
+	Reachable      bool     // The engine API answered
+	Authenticated  bool     // The JWT was accepted
+	Capable        bool     // Every required method is supported
+	Capabilities   []string // Methods the node supports, sorted
+	Missing        []string // Required method families the node lacks
+	ClientVersions []EngineClientVersion
+	SecretError    error // The JWT secret could not be read, so the node was not asked
+	Error          error
+}
+
+// EngineClientVersion identifies the client behind the engine API, as
+// returned by engine_getClientVersionV1
+type EngineClientVersion struct {
+	Code    string `json:"code"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
+// engineAPI checks the authenticated engine API of a node
+type engineAPI struct {
+	endpoint   string
+	secretPath string
+	httpClient *http.Client
+}
+
+// check asks the node for its capabilities and client version, recording
+// the outcome of each method in sources. A secret that cannot be read is our
+// own problem rather than the node's, so the node is not asked.
+func (e *engineAPI) check(ctx context.Context, sources *common.SourceTracker) *EngineStatus {
+	status := &EngineStatus{Endpoint: e.endpoint}
+
+	secret, err := readJWTSecret(e.secretPath)
+	if err != nil {
+		status.SecretError = err
+		sources.Forget(EngineExchangeCapabilities, EngineGetClientVersion)
+		return status
+	}
+
+	var capabilities struct {
+		Result []string `json:"result"`
+	}
+	start := time.Now()
+	err = e.call(ctx, secret, EngineExchangeCapabilities, []interface{}{[]string{EngineGetClientVersion}}, &capabilities)
+	sources.Observe(EngineExchangeCapabilities, start, err)
+	var statusErr *httpStatusError
+	switch {
+	case errors.As(err, &statusErr):
+		status.Reachable = true
+		status.Authenticated = statusErr.StatusCode != http.StatusUnauthorized && statusErr.StatusCode != http.StatusForbidden
+	case err == nil:
+		status.Reachable = true
+		status.Authenticated = true
+	default:
+		var rpcErr *RPCError
+		status.Reachable = errors.As(err, &rpcErr)
+		status.Authenticated = status.Reachable
+	}
+	if err != nil {
+		status.Error = fmt.Errorf("%s: %w", EngineExchangeCapabilities, err)
+		return status
+	}
+
+	status.Capabilities = capabilities.Result
+	sort.Strings(status.Capabilities)
+	for _, family := range requiredEngineMethods {
+		if !supportsMethod(status.Capabilities, family) {
+			status.Missing = append(status.Missing, family)
+		}
+	}
+	status.Capable = len(status.Missing) == 0
+
+	// Older nodes do not identify themselves, which is not an error, so only
+	// an answer is recorded
+	var clientVersions struct {
+		Result []EngineClientVersion `json:"result"`
+	}
+	start = time.Now()
+	if err := e.call(ctx, secret, EngineGetClientVersion, []interface{}{watchethClientVersion()}, &clientVersions); err != nil {
+		sources.Forget(EngineGetClientVersion)
+	} else {
+		sources.Observe(EngineGetClientVersion, start, nil)
+		status.ClientVersions = clientVersions.Result
+	}
+
+	return status
+}
+
+// call calls an engine method with a fresh token, as tokens are only valid
+// for a minute around the time they are issued
+func (e *engineAPI) call(ctx context.Context, secret []byte, method string, params []interface{}, v interface{}) error {
+	jsonData, err := json.Marshal(newRPCRequest(method, params, 1))
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+	body, err := post(ctx, e.httpClient, e.endpoint, jsonData, jwtToken(secret, time.Now()))
+	if err != nil {
+		return err
+	}
+	return decodeResponse(body, v)
+}
+
+// supportsMethod returns true if any version of a method family is in the
+// capabilities, e.g. engine_newPayloadV4 for engine_newPayload
+func supportsMethod(capabilities []string, family string) bool {
+	for _, method := range capabilities {
+		if strings.HasPrefix(method, family+"V") {
+			return true
+		}
+	}
+	return false
+}
+
+// readJWTSecret reads a hex encoded 32 byte secret, as shared by the
+// consensus and execution clients
+func readJWTSecret(path string) ([]byte, error) {
+	if path == "" {
+		return nil, errors.New("no jwt_secret_path configured")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("%s holds %d bytes rather than 32", path, len(secret))
+	}
+	return secret, nil
+}
+
+// jwtToken returns an HS256 token issued at a time, which is the only claim
+// the engine API requires
+func jwtToken(secret []byte, issued time.Time) string {
+	encoding := base64.RawURLEncoding
+	header := encoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := encoding.EncodeToString([]byte(fmt.Sprintf(`{"iat":%d}`, issued.Unix())))
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(header + "." + claims))
+	return header + "." + claims + "." + encoding.EncodeToString(mac.Sum(nil))
+}
+
+// watchethClientVersion identifies watcheth to the node, which nodes only
+// log
+func watchethClientVersion() EngineClientVersion {
+	commit := "0x00000000"
+	if len(version.CommitHash) >= 8 {
+		if _, err := hex.DecodeString(version.CommitHash[:8]); err == nil {
+			commit = "0x" + version.CommitHash[:8]
+		}
+	}
+	return EngineClientVersion{Code: "WE", Name: "watcheth", Version: version.Version, Commit: commit}
+}
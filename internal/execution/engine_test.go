@@ -0,0 +1,268 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/common"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+const testJWTSecret = "0x5c1f4e8d2a9b3c7e6f0a1d4b8c2e9f3a7d6b0c5e1f4a8d2b9c3e7f6a0d1b4c8e"
+
+// writeJWTSecret writes a secret file as the clients create it
+func writeJWTSecret(t *testing.T, secret string) string {
+	path := filepath.Join(t.TempDir(), "jwt.hex")
+	require.NoError(t, os.WriteFile(path, []byte(secret+"\n"), 0o600))
+	return path
+}
+
+// verifyJWT checks the bearer token of a request against the secret as a node
+// does, returning false if it is missing or invalid
+func verifyJWT(t *testing.T, r *http.Request, secret []byte) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		return false
+	}
+
+	claimsData, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		IssuedAt int64 `json:"iat"`
+	}
+	require.NoError(t, json.Unmarshal(claimsData, &claims))
+	return time.Since(time.Unix(claims.IssuedAt, 0)).Abs() < time.Minute
+}
+
+// engineHandler serves the engine API methods with the given results, after
+// checking the token against the secret
+func engineHandler(t *testing.T, secret string, results map[string]string) http.HandlerFunc {
+	key, err := readJWTSecret(writeJWTSecret(t, secret))
+	require.NoError(t, err)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !verifyJWT(t, r, key) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("invalid token"))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request rpcRequest
+		require.NoError(t, json.Unmarshal(body, &request))
+
+		result, ok := results[request.Method]
+		if !ok {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"the method %s does not exist/is not available"}}`, request.ID, request.Method)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%s}`, request.ID, result)
+	}
+}
+
+func TestReadJWTSecret(t *testing.T) {
+	secret, err := readJWTSecret(writeJWTSecret(t, testJWTSecret))
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	secret, err = readJWTSecret(writeJWTSecret(t, strings.TrimPrefix(testJWTSecret, "0x")))
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = readJWTSecret(writeJWTSecret(t, "0x1234"))
+	assert.ErrorContains(t, err, "2 bytes")
+
+	_, err = readJWTSecret(writeJWTSecret(t, "not hex"))
+	assert.Error(t, err)
+
+	_, err = readJWTSecret(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	_, err = readJWTSecret("")
+	assert.Error(t, err)
+}
+
+func TestEngineAPI_Check(t *testing.T) {
+	capabilities := `["engine_newPayloadV3","engine_newPayloadV4","engine_forkchoiceUpdatedV3","engine_getPayloadV4","engine_getBlobsV1"]`
+	clientVersion := `[{"code":"GE","name":"Geth","version":"1.15.0","commit":"0xabcdef12"}]`
+	tests := []struct {
+		name          string
+		results       map[string]string
+		secret        string
+		reachable     bool
+		authenticated bool
+		capable       bool
+		missing       []string
+		versions      int
+		err           bool
+	}{
+		{
+			name:          "healthy",
+			results:       map[string]string{EngineExchangeCapabilities: capabilities, EngineGetClientVersion: clientVersion},
+			secret:        testJWTSecret,
+			reachable:     true,
+			authenticated: true,
+			capable:       true,
+			versions:      1,
+		},
+		{
+			name:          "no client version",
+			results:       map[string]string{EngineExchangeCapabilities: capabilities},
+			secret:        testJWTSecret,
+			reachable:     true,
+			authenticated: true,
+			capable:       true,
+		},
+		{
+			name:          "missing methods",
+			results:       map[string]string{EngineExchangeCapabilities: `["engine_newPayloadV4","engine_getBlobsV1"]`},
+			secret:        testJWTSecret,
+			reachable:     true,
+			authenticated: true,
+			missing:       []string{"engine_forkchoiceUpdated", "engine_getPayload"},
+		},
+		{
+			name:      "wrong secret",
+			results:   map[string]string{EngineExchangeCapabilities: capabilities},
+			secret:    "0x" + strings.Repeat("11", 32),
+			reachable: true,
+			err:       true,
+		},
+		{
+			name:          "rpc error",
+			results:       map[string]string{},
+			secret:        testJWTSecret,
+			reachable:     true,
+			authenticated: true,
+			err:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testutil.HTTPTestServer(t, engineHandler(t, testJWTSecret, tt.results))
+			client := NewClient("test", "http://localhost:1", WithEngineAPI(server.URL, writeJWTSecret(t, tt.secret))).(*executionClient)
+
+			var sources common.SourceTracker
+			status := client.engine.check(context.Background(), &sources)
+			assert.Equal(t, server.URL, status.Endpoint)
+			assert.Equal(t, tt.reachable, status.Reachable)
+			assert.Equal(t, tt.authenticated, status.Authenticated)
+			assert.Equal(t, tt.capable, status.Capable)
+			assert.Equal(t, tt.missing, status.Missing)
+			assert.Len(t, status.ClientVersions, tt.versions)
+			assert.Equal(t, tt.err, status.Error != nil)
+			if tt.capable {
+				assert.Equal(t, []string{"engine_forkchoiceUpdatedV3", "engine_getBlobsV1", "engine_getPayloadV4", "engine_newPayloadV3", "engine_newPayloadV4"}, status.Capabilities)
+			}
+			assert.Contains(t, sources.Snapshot(), EngineExchangeCapabilities)
+			// Older nodes not identifying themselves does not count as a failure
+			_, identified := sources.Snapshot()[EngineGetClientVersion]
+			assert.Equal(t, tt.versions > 0, identified)
+		})
+	}
+}
+
+func TestEngineAPI_CheckUnreachable(t *testing.T) {
+	server := testutil.HTTPTestServer(t, engineHandler(t, testJWTSecret, nil))
+	endpoint := server.URL
+	server.Close()
+
+	client := NewClient("test", "http://localhost:1", WithEngineAPI(endpoint, writeJWTSecret(t, testJWTSecret))).(*executionClient)
+	var sources common.SourceTracker
+	status := client.engine.check(context.Background(), &sources)
+	assert.False(t, status.Reachable)
+	assert.False(t, status.Authenticated)
+	assert.Error(t, status.Error)
+
+	// A missing secret is reported without asking the node
+	client = NewClient("test", "http://localhost:1", WithEngineAPI(endpoint, "")).(*executionClient)
+	status = client.engine.check(context.Background(), &sources)
+	assert.ErrorContains(t, status.SecretError, "jwt_secret_path")
+	assert.NoError(t, status.Error)
+	assert.Empty(t, sources.Snapshot(), "the node was not asked")
+
+	// No engine endpoint leaves the check off
+	client = NewClient("test", "http://localhost:1", WithEngineAPI("", "")).(*executionClient)
+	assert.Nil(t, client.engine)
+}
+
+func TestExecutionClient_GetNodeInfoEngine(t *testing.T) {
+	engine := testutil.HTTPTestServer(t, engineHandler(t, testJWTSecret, map[string]string{
+		EngineExchangeCapabilities: `["engine_newPayloadV4","engine_forkchoiceUpdatedV3","engine_getPayloadV4"]`,
+		EngineGetClientVersion:     `[{"code":"GE","name":"Geth","version":"1.15.0","commit":"0xabcdef12"}]`,
+	}))
+
+	// The engine API is checked even when the RPC endpoint is down
+	client := NewClient("test", "http://localhost:1", WithEngineAPI(engine.URL, writeJWTSecret(t, testJWTSecret)))
+	info, err := client.GetNodeInfo(context.Background())
+	assert.Error(t, err)
+	require.NotNil(t, info.Engine)
+	assert.True(t, info.Engine.Capable)
+	require.Len(t, info.Engine.ClientVersions, 1)
+	assert.Equal(t, "Geth", info.Engine.ClientVersions[0].Name)
+	assert.NoError(t, info.Sources.Err(EngineExchangeCapabilities))
+	assert.NoError(t, info.Sources.Err(EngineGetClientVersion))
+
+	client = NewClient("test", "http://localhost:1")
+	info, _ = client.GetNodeInfo(context.Background())
+	assert.Nil(t, info.Engine)
+}
+
+func TestJWTToken(t *testing.T) {
+	secret, err := readJWTSecret(writeJWTSecret(t, testJWTSecret))
+	require.NoError(t, err)
+	issued := time.Unix(1700000000, 0)
+
+	token := jwtToken(secret, issued)
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"alg":"HS256","typ":"JWT"}`, string(header))
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"iat":1700000000}`, string(claims))
+
+	req, err := http.NewRequest(http.MethodPost, "http://localhost", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+jwtToken(secret, time.Now()))
+	assert.True(t, verifyJWT(t, req, secret))
+	req.Header.Set("Authorization", "Bearer "+jwtToken([]byte("other"), time.Now()))
+	assert.False(t, verifyJWT(t, req, secret))
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// JSON-RPC 2.0 error code for a method the node does not serve
+const codeMethodNotFound = -32601
+
+// RPCError is the error object of a JSON-RPC response
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("rpc error %d: %s (%s)", e.Code, e.Message, e.Data)
+	}
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// MethodNotFound returns true if the node does not serve the method, e.g.
+// because its namespace is disabled. Not every client uses the standard
+// code, so the message is checked too.
+func (e *RPCError) MethodNotFound() bool {
+	if e.Code == codeMethodNotFound {
+		return true
+	}
+	message := strings.ToLower(e.Message)
+	return strings.Contains(message, "method not found") ||
+		(strings.Contains(message, "method") && strings.Contains(message, "does not exist"))
+}
+
+// Unsupported returns true if the node does not serve the method, so that
+// it does not count as a failed source
+func (e *RPCError) Unsupported() bool {
+	return e.MethodNotFound()
+}
+
+// IsMethodNotFound returns true if err is an RPCError for a method the node
+// does not serve
+func IsMethodNotFound(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr) && rpcErr.MethodNotFound()
+}
+
+// decodeResponse decodes a response into v, returning its error object if
+// it has one rather than leaving v empty
+func decodeResponse(body []byte, v interface{}) error {
+	var envelope struct {
+		Error *RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	if envelope.Error != nil {
+		return envelope.Error
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("parse response: %w", err)
+	}
+	return nil
+}
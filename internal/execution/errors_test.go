@@ -0,0 +1,91 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expected       string
+		expectedErr    *RPCError
+		methodNotFound bool
+		expectParseErr bool
+	}{
+		{
+			name:     "result",
+			body:     testutil.ValidChainIDResponse,
+			expected: "0x1",
+		},
+		{
+			name:           "error fixture",
+			body:           testutil.RPCErrorResponse,
+			expectedErr:    &RPCError{Code: -32000, Message: "Method not found"},
+			methodNotFound: true,
+		},
+		{
+			name:           "standard method not found",
+			body:           `{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"the method net_peerCount does not exist/is not available"}}`,
+			expectedErr:    &RPCError{Code: -32601, Message: "the method net_peerCount does not exist/is not available"},
+			methodNotFound: true,
+		},
+		{
+			name:        "error with data",
+			body:        `{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted","data":"0x08c379a0"}}`,
+			expectedErr: &RPCError{Code: 3, Message: "execution reverted", Data: json.RawMessage(`"0x08c379a0"`)},
+		},
+		{
+			name:           "malformed",
+			body:           `not json`,
+			expectParseErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp ChainIDResponse
+			err := decodeResponse([]byte(tt.body), &resp)
+			switch {
+			case tt.expectParseErr:
+				require.Error(t, err)
+				var rpcErr *RPCError
+				assert.False(t, errors.As(err, &rpcErr))
+			case tt.expectedErr != nil:
+				var rpcErr *RPCError
+				require.ErrorAs(t, err, &rpcErr)
+				assert.Equal(t, tt.expectedErr, rpcErr)
+				assert.Equal(t, tt.methodNotFound, IsMethodNotFound(fmt.Errorf("wrapped: %w", err)))
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, resp.Result)
+			}
+		})
+	}
+}
+
+func TestRPCError_Error(t *testing.T) {
+	assert.Equal(t, "rpc error -32601: method not found", (&RPCError{Code: -32601, Message: "method not found"}).Error())
+	assert.Equal(t, `rpc error 3: execution reverted ("0x08")`,
+		(&RPCError{Code: 3, Message: "execution reverted", Data: json.RawMessage(`"0x08"`)}).Error())
+}
@@ -0,0 +1,286 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = time.Minute
+)
+
+// HeadStreamer is implemented by execution clients that can push node
+// updates from a new heads subscription as blocks arrive
+type HeadStreamer interface {
+	StreamsHeads() bool
+	StreamHeads(ctx context.Context, updates chan<- *ExecutionNodeInfo)
+}
+
+// BlockGap is a run of blocks that the new heads subscription skipped
+type BlockGap struct {
+	From uint64    // First block not seen
+	To   uint64    // Last block not seen
+	Seen time.Time // When the head after the gap arrived
+}
+
+// Blocks returns the number of blocks skipped
+func (g *BlockGap) Blocks() uint64 {
+	return g.To - g.From + 1
+}
+
+// headTracker follows the heads pushed by the subscription
+type headTracker struct {
+	number    uint64    // Last head, zero if none has been seen
+	timestamp time.Time // Of the last head
+	arrival   time.Duration
+	missed    uint64
+	lastGap   *BlockGap
+}
+
+// observe records a new head seen at a time, returning the gap since the
+// previous head if any blocks were skipped. Heads at or below the previous
+// one replace it, as after a reorg.
+func (t *headTracker) observe(number uint64, timestamp, seen time.Time) *BlockGap {
+	var gap *BlockGap
+	if t.number > 0 && number > t.number+1 {
+		gap = &BlockGap{From: t.number + 1, To: number - 1, Seen: seen}
+		t.missed += gap.Blocks()
+		t.lastGap = gap
+	}
+	t.number = number
+	t.timestamp = timestamp
+	t.arrival = seen.Sub(timestamp)
+	if t.arrival < 0 {
+		// Clocks disagree, the block cannot have arrived before it was built
+		t.arrival = 0
+	}
+	return gap
+}
+
+// apply fills in the head fields of the node info
+func (t *headTracker) apply(info *ExecutionNodeInfo, now time.Time) {
+	info.MissedBlocks = t.missed
+	info.LastGap = t.lastGap
+	if t.number == 0 {
+		return
+	}
+	info.BlockArrival = t.arrival
+	if !info.IsSyncing {
+		info.CurrentBlock = t.number
+		info.HighestBlock = t.number
+		info.LastBlockTime = t.timestamp
+		info.BlockTime = now.Sub(t.timestamp)
+	}
+}
+
+// StreamsHeads returns true for ws:// and ipc:// endpoints, which support
+// subscriptions
+func (c *executionClient) StreamsHeads() bool {
+	return c.dialStream != nil
+}
+
+// StreamHeads subscribes to new heads and sends an updated node info snapshot
+// for every block. The subscription is renewed with exponential backoff until
+// the context is cancelled; while it is down the node is polled as usual.
+func (c *executionClient) StreamHeads(ctx context.Context, updates chan<- *ExecutionNodeInfo) {
+	delay := minReconnectDelay
+	for {
+		subscribed, err := c.consumeHeads(ctx, updates)
+		c.sendUpdate(ctx, updates, c.setStreaming(false))
+		if ctx.Err() != nil {
+			return
+		}
+		if subscribed {
+			delay = minReconnectDelay
+		}
+
+		logger.Warn("[%s]: New heads subscription lost, retrying in %s: %v", c.name, delay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+// consumeHeads subscribes to new heads on a connection of its own and reads
+// them until it fails, returning whether the subscription was established
+func (c *executionClient) consumeHeads(ctx context.Context, updates chan<- *ExecutionNodeInfo) (bool, error) {
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	conn, err := c.dialStream(dialCtx)
+	cancel()
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+	// Unblock the read below once the context is done
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	subscription, err := subscribe(conn, "newHeads")
+	if err != nil {
+		return false, err
+	}
+
+	logger.Info("[%s]: Subscribed to new heads", c.name)
+	c.sendUpdate(ctx, updates, c.setStreaming(true))
+
+	for {
+		message, err := conn.ReadMessage()
+		if err != nil {
+			return true, err
+		}
+
+		var notification struct {
+			Method string `json:"method"`
+			Params struct {
+				Subscription string `json:"subscription"`
+				Result       Block  `json:"result"`
+			} `json:"params"`
+		}
+		if err := json.Unmarshal(message, &notification); err != nil {
+			logger.Debug("[%s]: Failed to decode notification: %v", c.name, err)
+			continue
+		}
+		if notification.Method != "eth_subscription" || notification.Params.Subscription != subscription {
+			continue
+		}
+		c.sendUpdate(ctx, updates, c.applyHead(notification.Params.Result, time.Now()))
+	}
+}
+
+// subscribe sends eth_subscribe and returns the id of the subscription
+func subscribe(conn messageConn, kind string) (string, error) {
+	request, err := json.Marshal(newRPCRequest("eth_subscribe", []interface{}{kind}, 1))
+	if err != nil {
+		return "", fmt.Errorf("marshal request: %w", err)
+	}
+	if err := conn.WriteMessage(request); err != nil {
+		return "", err
+	}
+
+	for {
+		message, err := conn.ReadMessage()
+		if err != nil {
+			return "", err
+		}
+		if isNotification(message) {
+			continue
+		}
+		var resp struct {
+			Result string `json:"result"`
+		}
+		if err := decodeResponse(message, &resp); err != nil {
+			return "", fmt.Errorf("eth_subscribe: %w", err)
+		}
+		if resp.Result == "" {
+			return "", errors.New("eth_subscribe: no subscription id")
+		}
+		return resp.Result, nil
+	}
+}
+
+// headsLive returns true while the subscription is live and has pushed a
+// head, which then takes the place of polling for the latest block
+func (c *executionClient) headsLive() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.streaming && c.heads.number > 0
+}
+
+// setLatest fills in the head fields of a polled snapshot and keeps it for
+// pushed updates, or forgets the last snapshot if the poll failed
+func (c *executionClient) setLatest(info *ExecutionNodeInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if info == nil {
+		c.latest = nil
+		return
+	}
+	info.HeadStream = c.streaming
+	if c.streaming {
+		c.heads.apply(info, info.LastUpdate)
+	} else {
+		info.MissedBlocks = c.heads.missed
+		info.LastGap = c.heads.lastGap
+	}
+	latest := *info
+	c.latest = &latest
+}
+
+func (c *executionClient) sendUpdate(ctx context.Context, updates chan<- *ExecutionNodeInfo, info *ExecutionNodeInfo) {
+	if info == nil {
+		return
+	}
+	select {
+	case updates <- info:
+	case <-ctx.Done():
+	}
+}
+
+// setStreaming records the subscription state and returns an updated
+// snapshot, or nil if the node has not been polled successfully yet
+func (c *executionClient) setStreaming(streaming bool) *ExecutionNodeInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.streaming = streaming
+	if streaming {
+		// Blocks polled while the subscription was down are not gaps
+		c.heads.number = 0
+	}
+	if c.latest == nil {
+		return nil
+	}
+	c.latest.HeadStream = streaming
+	info := *c.latest
+	return &info
+}
+
+// applyHead records a new head and returns an updated snapshot, or nil if
+// the node has not been polled successfully yet
+func (c *executionClient) applyHead(block Block, seen time.Time) *ExecutionNodeInfo {
+	number := parseHexUint64(block.Number)
+	timestamp := time.Unix(int64(parseHexUint64(block.Timestamp)), 0)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gap := c.heads.observe(number, timestamp, seen); gap != nil {
+		logger.Warn("[%s]: New heads skipped blocks %d to %d", c.name, gap.From, gap.To)
+	}
+	if c.latest == nil {
+		return nil
+	}
+	c.heads.apply(c.latest, seen)
+	c.latest.LastUpdate = seen
+	info := *c.latest
+	return &info
+}
@@ -0,0 +1,243 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/common"
+	"github.com/watcheth/watcheth/internal/testutil"
+)
+
+// headNotification returns a new heads notification for a block
+func headNotification(subscription string, number uint64, timestamp time.Time) []byte {
+	return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"%s","result":{"number":"0x%x","timestamp":"0x%x","hash":"0xabc"}}}`,
+		subscription, number, timestamp.Unix()))
+}
+
+// rpcConnHandler answers requests, single or batched, with the result of each
+// method and, once subscribed to new heads, pushes heads until the connection
+// is dropped
+func rpcConnHandler(t *testing.T, results map[string]string, heads <-chan uint64, subscriptions *atomic.Int32) func(conn messageConn) {
+	respond := func(request rpcRequest) string {
+		result, ok := results[request.Method]
+		if !ok {
+			result = "null"
+		}
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, request.ID, result)
+	}
+
+	return func(conn messageConn) {
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			// Notifications for other subscriptions may come first
+			_ = conn.WriteMessage(headNotification("0xother", 1, time.Now()))
+
+			if message[0] == '[' {
+				var requests []rpcRequest
+				require.NoError(t, json.Unmarshal(message, &requests))
+				responses := make([]string, len(requests))
+				for i, request := range requests {
+					responses[i] = respond(request)
+				}
+				_ = conn.WriteMessage([]byte("[" + strings.Join(responses, ",") + "]"))
+				continue
+			}
+
+			var request rpcRequest
+			require.NoError(t, json.Unmarshal(message, &request))
+			if request.Method != "eth_subscribe" {
+				_ = conn.WriteMessage([]byte(respond(request)))
+				continue
+			}
+
+			assert.Equal(t, []interface{}{"newHeads"}, request.Params)
+			subscriptions.Add(1)
+			_ = conn.WriteMessage([]byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0xsub"}`, request.ID)))
+			for number := range heads {
+				if number == 0 {
+					// Drop the connection
+					return
+				}
+				_ = conn.WriteMessage(headNotification("0xsub", number, time.Now().Add(-500*time.Millisecond)))
+			}
+			return
+		}
+	}
+}
+
+// wsHandler serves a handler over WebSocket
+func wsHandler(handler func(conn messageConn)) func(conn *common.WebSocketConn) {
+	return func(conn *common.WebSocketConn) {
+		handler(conn)
+	}
+}
+
+func TestHeadTracker(t *testing.T) {
+	now := time.Now()
+	var tracker headTracker
+
+	assert.Nil(t, tracker.observe(100, now.Add(-time.Second), now))
+	assert.Equal(t, time.Second, tracker.arrival)
+
+	assert.Nil(t, tracker.observe(101, now, now))
+	gap := tracker.observe(105, now, now.Add(-time.Second))
+	assert.Equal(t, &BlockGap{From: 102, To: 104, Seen: now.Add(-time.Second)}, gap)
+	assert.Equal(t, uint64(3), gap.Blocks())
+	assert.Equal(t, uint64(3), tracker.missed)
+	assert.Zero(t, tracker.arrival, "arrival before the timestamp")
+
+	// A reorg to a lower block is not a gap
+	assert.Nil(t, tracker.observe(104, now, now))
+	assert.Equal(t, uint64(3), tracker.missed)
+
+	info := &ExecutionNodeInfo{IsConnected: true}
+	tracker.apply(info, now.Add(2*time.Second))
+	assert.Equal(t, uint64(104), info.CurrentBlock)
+	assert.Equal(t, uint64(104), info.HighestBlock)
+	assert.Equal(t, 2*time.Second, info.BlockTime)
+	assert.Equal(t, uint64(3), info.MissedBlocks)
+	assert.Equal(t, gap, info.LastGap)
+
+	// A syncing node keeps its sync status
+	info = &ExecutionNodeInfo{IsConnected: true, IsSyncing: true, CurrentBlock: 50, HighestBlock: 104}
+	tracker.apply(info, now)
+	assert.Equal(t, uint64(50), info.CurrentBlock)
+}
+
+func TestExecutionClient_GetNodeInfoWebSocket(t *testing.T) {
+	results := map[string]string{
+		"eth_syncing":          `false`,
+		"eth_blockNumber":      `"0x1234"`,
+		"net_peerCount":        `"0x19"`,
+		"eth_chainId":          `"0x1"`,
+		"eth_gasPrice":         `"0x3b9aca00"`,
+		"web3_clientVersion":   `"Geth/v1.13.0"`,
+		"eth_getBlockByNumber": `{"number":"0x1234","timestamp":"0x65000000","hash":"0xabc","parentHash":"0xdef"}`,
+	}
+	var subscriptions atomic.Int32
+	_, endpoint := testutil.WebSocketTestServer(t, wsHandler(rpcConnHandler(t, results, nil, &subscriptions)))
+	client := NewClient("test", endpoint)
+
+	streamer, ok := client.(HeadStreamer)
+	require.True(t, ok)
+	assert.True(t, streamer.StreamsHeads())
+
+	// Polls share one connection, with batches as over HTTP
+	for i := 0; i < 2; i++ {
+		info, err := client.GetNodeInfo(context.Background())
+		require.NoError(t, err)
+		assert.True(t, info.IsConnected)
+		assert.Equal(t, uint64(0x1234), info.CurrentBlock)
+		assert.Equal(t, uint64(25), info.PeerCount)
+		assert.Equal(t, "Geth/v1.13.0", info.NodeVersion)
+		assert.Equal(t, time.Unix(0x65000000, 0), info.LastBlockTime)
+		assert.False(t, info.HeadStream)
+	}
+
+	httpClient := NewClient("test", "http://localhost:8545").(HeadStreamer)
+	assert.False(t, httpClient.StreamsHeads())
+}
+
+func TestExecutionClient_StreamHeads(t *testing.T) {
+	results := map[string]string{
+		"eth_syncing":        `false`,
+		"eth_blockNumber":    `"0x64"`,
+		"net_peerCount":      `"0x19"`,
+		"web3_clientVersion": `"Geth/v1.13.0"`,
+		// Fails until the subscription takes over
+		"eth_getBlockByNumber": `"not a block"`,
+	}
+	heads := make(chan uint64)
+	var subscriptions atomic.Int32
+	_, endpoint := testutil.WebSocketTestServer(t, wsHandler(rpcConnHandler(t, results, heads, &subscriptions)))
+	client := NewClient("test", endpoint).(*executionClient)
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Error(t, info.Sources.Err("eth_getBlockByNumber"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan *ExecutionNodeInfo, 1)
+	go client.StreamHeads(ctx, updates)
+
+	next := func() *ExecutionNodeInfo {
+		select {
+		case info := <-updates:
+			return info
+		case <-time.After(5 * time.Second):
+			t.Fatal("no update")
+			return nil
+		}
+	}
+
+	// Subscribed
+	info = next()
+	assert.True(t, info.HeadStream)
+
+	heads <- 101
+	info = next()
+	assert.Equal(t, uint64(101), info.CurrentBlock)
+	assert.InDelta(t, 500*time.Millisecond, info.BlockArrival, float64(time.Second))
+	assert.Equal(t, "Geth/v1.13.0", info.NodeVersion, "polled fields are kept")
+	assert.Contains(t, info.Sources, "eth_syncing")
+
+	// Skipped blocks are counted
+	heads <- 104
+	info = next()
+	assert.Equal(t, uint64(104), info.CurrentBlock)
+	assert.Equal(t, uint64(2), info.MissedBlocks)
+	require.NotNil(t, info.LastGap)
+	assert.Equal(t, uint64(102), info.LastGap.From)
+	assert.Equal(t, uint64(103), info.LastGap.To)
+	assert.Equal(t, info.LastUpdate, info.LastGap.Seen)
+
+	// Polls take the head from the subscription
+	info, err = client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(104), info.CurrentBlock)
+	assert.True(t, info.HeadStream)
+	assert.Contains(t, info.Sources, "eth_syncing")
+	assert.NotContains(t, info.Sources, "eth_blockNumber", "block number is not polled")
+	assert.NotContains(t, info.Sources, "eth_getBlockByNumber", "failed before the subscription, so no longer counts")
+
+	// A dropped subscription is renewed
+	heads <- 0
+	info = next()
+	assert.False(t, info.HeadStream)
+	info = next()
+	assert.True(t, info.HeadStream)
+	assert.Equal(t, int32(2), subscriptions.Load())
+
+	// Heads polled while the subscription was down are not gaps
+	heads <- 110
+	info = next()
+	assert.Equal(t, uint64(110), info.CurrentBlock)
+	assert.Equal(t, uint64(2), info.MissedBlocks)
+
+	cancel()
+	close(heads)
+}
@@ -0,0 +1,67 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"sync"
+	"time"
+)
+
+// ipcConn carries JSON-RPC over a Unix domain socket. Messages are not
+// framed, so they are split by decoding one JSON value at a time.
+type ipcConn struct {
+	conn    net.Conn
+	decoder *json.Decoder
+
+	writeMu sync.Mutex
+}
+
+// dialIPC connects to the IPC socket of a node at path
+func dialIPC(path string) func(ctx context.Context) (messageConn, error) {
+	return func(ctx context.Context) (messageConn, error) {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "unix", path)
+		if err != nil {
+			return nil, err
+		}
+		return &ipcConn{conn: conn, decoder: json.NewDecoder(conn)}, nil
+	}
+}
+
+func (c *ipcConn) ReadMessage() ([]byte, error) {
+	var message json.RawMessage
+	if err := c.decoder.Decode(&message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func (c *ipcConn) WriteMessage(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	// Nodes do not need the newline, but it keeps the stream readable
+	_, err := c.conn.Write(append(data, '\n'))
+	return err
+}
+
+func (c *ipcConn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+func (c *ipcConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,120 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ipcTestServer serves a handler on a Unix domain socket and returns its
+// ipc:// endpoint
+func ipcTestServer(t *testing.T, handler func(conn messageConn)) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "geth.ipc")
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				ipc := &ipcConn{conn: conn, decoder: json.NewDecoder(conn)}
+				defer func() { _ = ipc.Close() }()
+				handler(ipc)
+			}()
+		}
+	}()
+	return "ipc://" + path
+}
+
+func TestExecutionClient_GetNodeInfoIPC(t *testing.T) {
+	results := map[string]string{
+		"eth_syncing":          `false`,
+		"eth_blockNumber":      `"0x1234"`,
+		"net_peerCount":        `"0x19"`,
+		"eth_chainId":          `"0x1"`,
+		"web3_clientVersion":   `"Geth/v1.13.0"`,
+		"eth_getBlockByNumber": `{"number":"0x1234","timestamp":"0x65000000","hash":"0xabc","parentHash":"0xdef"}`,
+	}
+	var subscriptions atomic.Int32
+	endpoint := ipcTestServer(t, rpcConnHandler(t, results, nil, &subscriptions))
+	client := NewClient("test", endpoint)
+	assert.Equal(t, endpoint, client.GetEndpoint())
+
+	info, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+	assert.True(t, info.IsConnected)
+	assert.Equal(t, uint64(0x1234), info.CurrentBlock)
+	assert.Equal(t, uint64(25), info.PeerCount)
+	assert.Equal(t, int64(1), info.ChainID.Int64())
+	assert.Equal(t, "Geth/v1.13.0", info.NodeVersion)
+	assert.Equal(t, time.Unix(0x65000000, 0), info.LastBlockTime)
+
+	// Single calls go over the same socket
+	var version ClientVersionResponse
+	require.NoError(t, client.(*executionClient).call(context.Background(), "web3_clientVersion", nil, &version))
+	assert.Equal(t, "Geth/v1.13.0", version.Result)
+}
+
+func TestExecutionClient_StreamHeadsIPC(t *testing.T) {
+	results := map[string]string{
+		"eth_syncing":     `false`,
+		"eth_blockNumber": `"0x64"`,
+	}
+	heads := make(chan uint64)
+	defer close(heads)
+	var subscriptions atomic.Int32
+	endpoint := ipcTestServer(t, rpcConnHandler(t, results, heads, &subscriptions))
+	client := NewClient("test", endpoint)
+
+	streamer, ok := client.(HeadStreamer)
+	require.True(t, ok)
+	require.True(t, streamer.StreamsHeads())
+
+	_, err := client.GetNodeInfo(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan *ExecutionNodeInfo, 1)
+	go streamer.StreamHeads(ctx, updates)
+
+	info := <-updates
+	assert.True(t, info.HeadStream)
+	heads <- 101
+	info = <-updates
+	assert.Equal(t, uint64(101), info.CurrentBlock)
+}
+
+func TestExecutionClient_IPCUnreachable(t *testing.T) {
+	client := NewClient("test", "ipc://"+filepath.Join(t.TempDir(), "missing.ipc"))
+
+	info, err := client.GetNodeInfo(context.Background())
+	assert.Error(t, err)
+	assert.False(t, info.IsConnected)
+	assert.Error(t, info.LastError)
+}
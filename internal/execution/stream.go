@@ -0,0 +1,105 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/common"
+)
+
+// messageConn is a persistent connection that carries whole JSON-RPC
+// messages, such as a WebSocket
+type messageConn interface {
+	ReadMessage() ([]byte, error)
+	WriteMessage(data []byte) error
+	SetDeadline(t time.Time) error
+	Close() error
+}
+
+// dialWebSocket connects to a ws:// or wss:// endpoint
+func dialWebSocket(endpoint string) func(ctx context.Context) (messageConn, error) {
+	return func(ctx context.Context) (messageConn, error) {
+		return common.DialWebSocket(ctx, endpoint)
+	}
+}
+
+// streamTransport sends requests over a persistent connection, one at a
+// time, dialling it on first use and again after it fails
+type streamTransport struct {
+	dial func(ctx context.Context) (messageConn, error)
+
+	mu   sync.Mutex
+	conn messageConn
+}
+
+// roundTrip sends a request, or a batch of them, and returns the response.
+// Notifications that arrive in the meantime are skipped.
+func (t *streamTransport) roundTrip(ctx context.Context, payload []byte) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.conn == nil {
+		conn, err := t.dial(ctx)
+		if err != nil {
+			return nil, err
+		}
+		t.conn = conn
+	}
+	conn := t.conn
+
+	// Unblock reads and writes once the context is done
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := conn.WriteMessage(payload); err != nil {
+		t.reset()
+		return nil, err
+	}
+	for {
+		message, err := conn.ReadMessage()
+		if err != nil {
+			t.reset()
+			return nil, err
+		}
+		if !isNotification(message) {
+			return message, nil
+		}
+	}
+}
+
+// reset drops a connection that failed. Caller must hold t.mu.
+func (t *streamTransport) reset() {
+	_ = t.conn.Close()
+	t.conn = nil
+}
+
+// isNotification returns true for subscription notifications, which carry a
+// method rather than answering a request
+func isNotification(message []byte) bool {
+	var envelope struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(message, &envelope); err != nil {
+		return false
+	}
+	return envelope.Method != ""
+}
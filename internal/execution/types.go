@@ -16,6 +16,8 @@ package execution
 import (
 	"math/big"
 	"time"
+
+	"github.com/watcheth/watcheth/internal/common"
 )
 
 type ExecutionNodeInfo struct {
@@ -37,6 +39,12 @@ type ExecutionNodeInfo struct {
 	ProtocolVersion string
 	BlockTime       time.Duration // Time since last block
 	LastBlockTime   time.Time
+	Sources         common.Sources // Outcome of each RPC method, by method
+	HeadStream      bool           // Whether heads are pushed by a live subscription
+	BlockArrival    time.Duration  // Delay of the last pushed head after its timestamp
+	MissedBlocks    uint64         // Blocks skipped by the subscription since startup
+	LastGap         *BlockGap      // Most recent run of skipped blocks
+	Engine          *EngineStatus  // Nil if no engine endpoint is configured
 }
 
 type SyncingResponse struct {
@@ -0,0 +1,98 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+	"github.com/watcheth/watcheth/internal/execution"
+)
+
+// Number of heads a node must have seen arrive before it is judged late
+const minArrivalSamples = 8
+
+// lateArrivalEvents returns a warning for nodes whose median head arrives
+// after the attestation deadline, which usually means network or peering
+// trouble. Arrivals seen by polling may be up to a refresh interval late, so
+// they must miss the deadline by more than that. Warned nodes are tracked in
+// warned until heads arrive on time again.
+func lateArrivalEvents(infos []*consensus.ConsensusNodeInfo, warned map[string]bool, refresh time.Duration) []Event {
+	var events []Event
+	for _, info := range infos {
+		if info == nil || !info.IsConnected || info.HeadArrival == nil {
+			continue
+		}
+		stats := info.HeadArrival
+		if stats.Samples < minArrivalSamples {
+			continue
+		}
+		deadline := stats.Deadline
+		if stats.Polled {
+			deadline += refresh
+		}
+		late := stats.Median > deadline
+		switch {
+		case late && !warned[info.Name]:
+			warned[info.Name] = true
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: EventWarning,
+				Message: fmt.Sprintf("Heads arriving late: median %s into the slot, %d of the last %d after %s",
+					formatArrival(stats.Median), stats.Late, stats.Samples, formatArrival(stats.Deadline)),
+			})
+		case !late && warned[info.Name]:
+			delete(warned, info.Name)
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: EventInfo,
+				Message:  "Heads arriving on time again",
+			})
+		}
+	}
+	return events
+}
+
+// blockGapEvents returns a warning for each run of blocks that the new heads
+// subscription of a node skipped. The last gap reported for each node is
+// tracked in reported.
+func blockGapEvents(infos []*execution.ExecutionNodeInfo, reported map[string]execution.BlockGap) []Event {
+	var events []Event
+	for _, info := range infos {
+		if info == nil || info.LastGap == nil {
+			continue
+		}
+		gap := *info.LastGap
+		if previous, ok := reported[info.Name]; ok && previous == gap {
+			continue
+		}
+		reported[info.Name] = gap
+		blocks := fmt.Sprintf("block %d", gap.From)
+		if gap.Blocks() > 1 {
+			blocks = fmt.Sprintf("blocks %d to %d", gap.From, gap.To)
+		}
+		events = append(events, Event{
+			Node:     info.Name,
+			Severity: EventWarning,
+			Message:  "New heads subscription skipped " + blocks,
+		})
+	}
+	return events
+}
+
+// formatArrival returns a delay in seconds with one decimal, e.g. 1.5s
+func formatArrival(delay time.Duration) string {
+	return fmt.Sprintf("%.1fs", delay.Seconds())
+}
@@ -0,0 +1,121 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+	"github.com/watcheth/watcheth/internal/execution"
+)
+
+func nodeWithArrival(name string, samples int, median time.Duration) *consensus.ConsensusNodeInfo {
+	return &consensus.ConsensusNodeInfo{
+		Name:        name,
+		IsConnected: true,
+		HeadArrival: &consensus.HeadArrivalStats{
+			Samples:  samples,
+			Median:   median,
+			P90:      median + time.Second,
+			Deadline: 4 * time.Second,
+			Late:     samples / 2,
+		},
+	}
+}
+
+func TestLateArrivalEvents(t *testing.T) {
+	warned := make(map[string]bool)
+
+	// Too few heads seen to judge
+	assert.Empty(t, lateArrivalEvents([]*consensus.ConsensusNodeInfo{nodeWithArrival("a", 3, 5*time.Second)}, warned, 2*time.Second))
+
+	events := lateArrivalEvents([]*consensus.ConsensusNodeInfo{
+		nodeWithArrival("a", 20, 4500*time.Millisecond),
+		nodeWithArrival("b", 20, time.Second),
+	}, warned, 2*time.Second)
+	require.Len(t, events, 1)
+	assert.Equal(t, "a", events[0].Node)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "Heads arriving late: median 4.5s into the slot, 10 of the last 20 after 4.0s", events[0].Message)
+
+	// Reported once
+	assert.Empty(t, lateArrivalEvents([]*consensus.ConsensusNodeInfo{nodeWithArrival("a", 20, 5*time.Second)}, warned, 2*time.Second))
+
+	events = lateArrivalEvents([]*consensus.ConsensusNodeInfo{nodeWithArrival("a", 20, 2*time.Second)}, warned, 2*time.Second)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInfo, events[0].Severity)
+	assert.Empty(t, warned)
+
+	// Polled arrivals are only late once past the deadline by a refresh
+	polled := nodeWithArrival("a", 20, 5*time.Second)
+	polled.HeadArrival.Polled = true
+	assert.Empty(t, lateArrivalEvents([]*consensus.ConsensusNodeInfo{polled}, warned, 2*time.Second))
+	polled.HeadArrival.Median = 6500 * time.Millisecond
+	events = lateArrivalEvents([]*consensus.ConsensusNodeInfo{polled}, warned, 2*time.Second)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventWarning, events[0].Severity)
+}
+
+func TestBlockGapEvents(t *testing.T) {
+	reported := make(map[string]execution.BlockGap)
+	now := time.Now()
+	infos := func(gap *execution.BlockGap) []*execution.ExecutionNodeInfo {
+		return []*execution.ExecutionNodeInfo{
+			{Name: "geth", IsConnected: true, LastGap: gap},
+			{Name: "reth", IsConnected: true}, // No blocks skipped
+			nil,
+		}
+	}
+
+	assert.Empty(t, blockGapEvents(infos(nil), reported))
+
+	events := blockGapEvents(infos(&execution.BlockGap{From: 102, To: 104, Seen: now}), reported)
+	require.Len(t, events, 1)
+	assert.Equal(t, "geth", events[0].Node)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "New heads subscription skipped blocks 102 to 104", events[0].Message)
+
+	// Reported once
+	assert.Empty(t, blockGapEvents(infos(&execution.BlockGap{From: 102, To: 104, Seen: now}), reported))
+
+	events = blockGapEvents(infos(&execution.BlockGap{From: 110, To: 110, Seen: now}), reported)
+	require.Len(t, events, 1)
+	assert.Equal(t, "New heads subscription skipped block 110", events[0].Message)
+}
+
+func TestFormatBlockArrival(t *testing.T) {
+	now := time.Now()
+	info := &execution.ExecutionNodeInfo{
+		IsConnected:   true,
+		HeadStream:    true,
+		LastBlockTime: now,
+		BlockArrival:  1500 * time.Millisecond,
+		MissedBlocks:  10,
+	}
+
+	// Blocks skipped long ago are not flagged
+	info.LastGap = &execution.BlockGap{From: 102, To: 104, Seen: now.Add(-recentGapWindow)}
+	text, color := formatBlockArrival(info, now)
+	assert.Equal(t, "1.5s", text)
+	assert.Equal(t, tcell.ColorWhite, color)
+
+	info.LastGap = &execution.BlockGap{From: 102, To: 104, Seen: now.Add(-time.Minute)}
+	text, color = formatBlockArrival(info, now)
+	assert.Equal(t, "1.5s ⚠3", text)
+	assert.Equal(t, tcell.ColorYellow, color)
+}
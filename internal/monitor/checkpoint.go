@@ -0,0 +1,290 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// How often the checkpoint sync providers are asked for their checkpoint.
+// Finality only moves once per epoch, so there is no need to ask every poll.
+const checkpointInterval = time.Minute
+
+// How long a round of checkpoint checks may take in all, asking the
+// providers and looking their roots up on the nodes
+const checkpointTimeout = 15 * time.Second
+
+// checkpointProvider is a trusted checkpoint sync endpoint
+type checkpointProvider struct {
+	name   string
+	source consensus.CheckpointSource
+}
+
+// ProviderCheckpoint is the finalized checkpoint of a checkpoint sync
+// provider
+type ProviderCheckpoint struct {
+	Name       string
+	Checkpoint *consensus.Checkpoint // Nil if the provider could not be reached
+	Error      string
+}
+
+// CheckpointComparison is the outcome of checking a node's finalized
+// checkpoint against a provider's
+type CheckpointComparison struct {
+	Node               string
+	Provider           string
+	NodeCheckpoint     consensus.Checkpoint
+	ProviderCheckpoint consensus.Checkpoint
+	Status             consensus.CheckpointStatus
+}
+
+// CheckpointReport compares the finalized checkpoints of the nodes with those
+// of the checkpoint sync providers
+type CheckpointReport struct {
+	Providers   []ProviderCheckpoint
+	Comparisons []CheckpointComparison
+}
+
+// Mismatched returns true if the node finalized a different chain from any
+// provider
+func (r *CheckpointReport) Mismatched(node string) bool {
+	if r == nil {
+		return false
+	}
+	for _, comparison := range r.Comparisons {
+		if comparison.Node == node && comparison.Status == consensus.CheckpointMismatch {
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the comparison of a node with a provider, or nil if there is
+// none
+func (r *CheckpointReport) find(node, provider string) *CheckpointComparison {
+	if r == nil {
+		return nil
+	}
+	for i := range r.Comparisons {
+		if r.Comparisons[i].Node == node && r.Comparisons[i].Provider == provider {
+			return &r.Comparisons[i]
+		}
+	}
+	return nil
+}
+
+// AddCheckpointProvider adds a trusted checkpoint sync endpoint to check the
+// finalized checkpoint of the consensus clients against
+func (m *Monitor) AddCheckpointProvider(name string, source consensus.CheckpointSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checkpointProviders = append(m.checkpointProviders, checkpointProvider{name: name, source: source})
+}
+
+// startCheckpointCheck checks the finalized checkpoints of the nodes in the
+// background, under its own deadline so that slow providers do not hold up
+// polling, and publishes an update once the report is in. Nothing is started
+// while the previous check is still running.
+func (m *Monitor) startCheckpointCheck(ctx context.Context, clients []consensus.Client, results []*consensus.ConsensusNodeInfo) {
+	m.mu.Lock()
+	if m.checkpointRunning {
+		m.mu.Unlock()
+		return
+	}
+	m.checkpointRunning = true
+	m.mu.Unlock()
+
+	go func() {
+		checkCtx, cancel := context.WithTimeout(ctx, checkpointTimeout)
+		defer cancel()
+		checkpoints := m.checkCheckpoints(checkCtx, clients, results)
+
+		m.mu.Lock()
+		m.checkpointRunning = false
+		if checkpoints == nil || ctx.Err() != nil {
+			m.mu.Unlock()
+			return
+		}
+		m.checkpoints = checkpoints
+		for _, event := range checkpointEvents(checkpoints, m.checkpointIssues) {
+			m.events.add(event)
+		}
+		update := m.updateLocked()
+		m.mu.Unlock()
+
+		m.publish(update)
+	}()
+}
+
+// checkCheckpoints compares the finalized checkpoint of each connected node
+// with those of the providers. Comparisons whose checkpoints have not changed
+// are carried over from the last report. Returns nil if no providers are
+// configured.
+func (m *Monitor) checkCheckpoints(ctx context.Context, clients []consensus.Client, results []*consensus.ConsensusNodeInfo) *CheckpointReport {
+	m.mu.Lock()
+	providers := make([]checkpointProvider, len(m.checkpointProviders))
+	copy(providers, m.checkpointProviders)
+	previous := m.checkpoints
+	due := previous == nil || time.Since(m.checkpointFetched) >= checkpointInterval
+	if due {
+		m.checkpointFetched = time.Now()
+	}
+	// Nodes not polled this round keep whatever the event stream last pushed
+	infos := make([]*consensus.ConsensusNodeInfo, len(results))
+	for i, info := range results {
+		infos[i] = info
+		if info == nil && i < len(m.consensusInfos) {
+			infos[i] = m.consensusInfos[i]
+		}
+	}
+	m.mu.Unlock()
+
+	if len(providers) == 0 {
+		return nil
+	}
+
+	report := &CheckpointReport{}
+	if due {
+		report.Providers = fetchProviderCheckpoints(ctx, providers)
+	} else {
+		report.Providers = previous.Providers
+	}
+
+	// Roots are looked up on the nodes concurrently, filling in the
+	// comparisons in place. nodeSources holds the node of each comparison.
+	var nodeSources []consensus.CheckpointSource
+	for i, info := range infos {
+		if info == nil || !info.IsConnected || info.FinalizedRoot == "" {
+			continue
+		}
+		source, ok := clients[i].(consensus.CheckpointSource)
+		if !ok {
+			continue
+		}
+		nodeCheckpoint := consensus.Checkpoint{Epoch: info.FinalizedEpoch, Root: info.FinalizedRoot}
+		for _, provider := range report.Providers {
+			if provider.Checkpoint == nil {
+				continue
+			}
+			report.Comparisons = append(report.Comparisons, CheckpointComparison{
+				Node:               info.Name,
+				Provider:           provider.Name,
+				NodeCheckpoint:     nodeCheckpoint,
+				ProviderCheckpoint: *provider.Checkpoint,
+			})
+			nodeSources = append(nodeSources, source)
+			comparison := &report.Comparisons[len(report.Comparisons)-1]
+			prior := previous.find(info.Name, provider.Name)
+			if prior != nil && prior.Status != consensus.CheckpointUnverified &&
+				prior.NodeCheckpoint == comparison.NodeCheckpoint && prior.ProviderCheckpoint == comparison.ProviderCheckpoint {
+				comparison.Status = prior.Status
+			}
+		}
+	}
+	var wg sync.WaitGroup
+	for i := range report.Comparisons {
+		comparison := &report.Comparisons[i]
+		if comparison.Status != consensus.CheckpointUnverified {
+			continue
+		}
+		wg.Add(1)
+		go func(node consensus.CheckpointSource) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			status, err := consensus.CompareCheckpoint(checkCtx, node, comparison.NodeCheckpoint, comparison.ProviderCheckpoint)
+			if err != nil {
+				logger.Debug("[%s]: Failed to check checkpoint of %s: %v", comparison.Node, comparison.Provider, err)
+			}
+			comparison.Status = status
+		}(nodeSources[i])
+	}
+	wg.Wait()
+	return report
+}
+
+// fetchProviderCheckpoints asks each provider for its finalized checkpoint
+func fetchProviderCheckpoints(ctx context.Context, providers []checkpointProvider) []ProviderCheckpoint {
+	results := make([]ProviderCheckpoint, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func(idx int, provider checkpointProvider) {
+			defer wg.Done()
+
+			fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+
+			results[idx] = ProviderCheckpoint{Name: provider.name}
+			checkpoint, err := provider.source.GetFinalizedCheckpoint(fetchCtx)
+			if err != nil {
+				logger.Warn("Failed to get checkpoint from provider %s: %v", provider.name, err)
+				results[idx].Error = err.Error()
+				return
+			}
+			results[idx].Checkpoint = checkpoint
+		}(i, provider)
+	}
+	wg.Wait()
+	return results
+}
+
+// checkpointEvents returns a critical event when a node's finalized
+// checkpoint first disagrees with a provider, and an info event once it
+// agrees again. Disagreements are tracked in issues, by node and provider.
+func checkpointEvents(report *CheckpointReport, issues map[string]bool) []Event {
+	if report == nil {
+		return nil
+	}
+
+	var events []Event
+	for _, comparison := range report.Comparisons {
+		key := comparison.Node + "/" + comparison.Provider
+		switch {
+		case comparison.Status == consensus.CheckpointMismatch && !issues[key]:
+			issues[key] = true
+			events = append(events, Event{
+				Node:     comparison.Node,
+				Severity: EventCritical,
+				Message:  fmt.Sprintf("Finalized checkpoint disagrees with %s: %s", comparison.Provider, formatCheckpointMismatch(comparison)),
+			})
+		case comparison.Status == consensus.CheckpointMatch && issues[key]:
+			delete(issues, key)
+			events = append(events, Event{
+				Node:     comparison.Node,
+				Severity: EventInfo,
+				Message:  fmt.Sprintf("Finalized checkpoint agrees with %s again", comparison.Provider),
+			})
+		}
+	}
+	return events
+}
+
+// formatCheckpointMismatch describes the two checkpoints of a mismatch
+func formatCheckpointMismatch(comparison CheckpointComparison) string {
+	if comparison.NodeCheckpoint.Epoch == comparison.ProviderCheckpoint.Epoch {
+		return fmt.Sprintf("epoch %d root %s, expected %s", comparison.NodeCheckpoint.Epoch,
+			consensus.ShortRoot(comparison.NodeCheckpoint.Root), consensus.ShortRoot(comparison.ProviderCheckpoint.Root))
+	}
+	return fmt.Sprintf("epoch %d root %s is not in the canonical chain", comparison.ProviderCheckpoint.Epoch,
+		consensus.ShortRoot(comparison.ProviderCheckpoint.Root))
+}
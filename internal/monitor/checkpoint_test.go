@@ -0,0 +1,162 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// mockCheckpointSource reports a fixed checkpoint and canonical roots, once
+// release is closed if it is set
+type mockCheckpointSource struct {
+	mockConsensusClient
+	checkpoint *consensus.Checkpoint
+	canonical  map[string]bool
+	release    chan struct{}
+
+	mu      sync.Mutex
+	lookups int
+}
+
+func (m *mockCheckpointSource) GetFinalizedCheckpoint(ctx context.Context) (*consensus.Checkpoint, error) {
+	if m.release != nil {
+		select {
+		case <-m.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if m.checkpoint == nil {
+		return nil, errors.New("unavailable")
+	}
+	return m.checkpoint, nil
+}
+
+func (m *mockCheckpointSource) IsCanonical(ctx context.Context, root string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lookups++
+	return m.canonical[root], nil
+}
+
+func TestMonitor_CheckCheckpoints(t *testing.T) {
+	node := &mockCheckpointSource{canonical: map[string]bool{"0xaa": true}}
+	m := NewMonitor(time.Second)
+	m.AddConsensusClient(node)
+	m.AddCheckpointProvider("good", &mockCheckpointSource{checkpoint: &consensus.Checkpoint{Epoch: 100, Root: "0xaa"}})
+	m.AddCheckpointProvider("bad", &mockCheckpointSource{checkpoint: &consensus.Checkpoint{Epoch: 100, Root: "0xcc"}})
+	m.AddCheckpointProvider("down", &mockCheckpointSource{})
+
+	clients := []consensus.Client{node}
+	results := []*consensus.ConsensusNodeInfo{{Name: "a", IsConnected: true, FinalizedEpoch: 101, FinalizedRoot: "0xbb"}}
+	report := m.checkCheckpoints(context.Background(), clients, results)
+	require.NotNil(t, report)
+	require.Len(t, report.Providers, 3)
+	assert.Nil(t, report.Providers[2].Checkpoint)
+	assert.Equal(t, "unavailable", report.Providers[2].Error)
+
+	require.Len(t, report.Comparisons, 2)
+	assert.Equal(t, consensus.CheckpointMatch, report.find("a", "good").Status)
+	assert.Equal(t, consensus.CheckpointMismatch, report.find("a", "bad").Status)
+	assert.True(t, report.Mismatched("a"))
+	assert.Equal(t, 2, node.lookups)
+
+	// Unchanged checkpoints are not looked up again
+	m.checkpoints = report
+	report = m.checkCheckpoints(context.Background(), clients, results)
+	require.Len(t, report.Comparisons, 2)
+	assert.Equal(t, 2, node.lookups)
+}
+
+func TestMonitor_CheckCheckpointsInBackground(t *testing.T) {
+	node := &mockCheckpointSource{
+		mockConsensusClient: mockConsensusClient{
+			name:     "a",
+			nodeInfo: &consensus.ConsensusNodeInfo{Name: "a", IsConnected: true, FinalizedEpoch: 101, FinalizedRoot: "0xbb"},
+		},
+		canonical: map[string]bool{"0xaa": true},
+	}
+	release := make(chan struct{})
+	m := NewMonitor(time.Second)
+	m.AddConsensusClient(node)
+	m.AddCheckpointProvider("slow", &mockCheckpointSource{checkpoint: &consensus.Checkpoint{Epoch: 100, Root: "0xaa"}, release: release})
+
+	// Polling does not wait for the provider
+	m.updateAll(context.Background())
+	update := <-m.Updates()
+	assert.Nil(t, update.Checkpoints)
+
+	// Nor start another check while one is running
+	m.updateAll(context.Background())
+	<-m.Updates()
+
+	close(release)
+	select {
+	case update = <-m.Updates():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update with the checkpoints")
+	}
+	require.NotNil(t, update.Checkpoints)
+	require.Len(t, update.Checkpoints.Comparisons, 1)
+	assert.Equal(t, consensus.CheckpointMatch, update.Checkpoints.Comparisons[0].Status)
+
+	m.mu.RLock()
+	assert.False(t, m.checkpointRunning)
+	m.mu.RUnlock()
+}
+
+func TestMonitor_CheckCheckpointsWithoutProviders(t *testing.T) {
+	m := NewMonitor(time.Second)
+	assert.Nil(t, m.checkCheckpoints(context.Background(), nil, nil))
+}
+
+func TestCheckpointEvents(t *testing.T) {
+	issues := make(map[string]bool)
+	mismatch := CheckpointComparison{
+		Node:               "a",
+		Provider:           "checkpointz",
+		NodeCheckpoint:     consensus.Checkpoint{Epoch: 100, Root: "0xbbbbbbbbbbbbbbbbbbbb"},
+		ProviderCheckpoint: consensus.Checkpoint{Epoch: 100, Root: "0xaaaaaaaaaaaaaaaaaaaa"},
+		Status:             consensus.CheckpointMismatch,
+	}
+
+	events := checkpointEvents(&CheckpointReport{Comparisons: []CheckpointComparison{mismatch}}, issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventCritical, events[0].Severity)
+	assert.Equal(t, "a", events[0].Node)
+	assert.Contains(t, events[0].Message, "Finalized checkpoint disagrees with checkpointz: epoch 100")
+
+	// Reported once
+	assert.Empty(t, checkpointEvents(&CheckpointReport{Comparisons: []CheckpointComparison{mismatch}}, issues))
+
+	// Unverified does not resolve it
+	unverified := mismatch
+	unverified.Status = consensus.CheckpointUnverified
+	assert.Empty(t, checkpointEvents(&CheckpointReport{Comparisons: []CheckpointComparison{unverified}}, issues))
+
+	match := mismatch
+	match.Status = consensus.CheckpointMatch
+	events = checkpointEvents(&CheckpointReport{Comparisons: []CheckpointComparison{match}}, issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInfo, events[0].Severity)
+	assert.Empty(t, issues)
+}
@@ -17,11 +17,14 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"github.com/watcheth/watcheth/internal/common"
 	"github.com/watcheth/watcheth/internal/config"
 	"github.com/watcheth/watcheth/internal/consensus"
 	"github.com/watcheth/watcheth/internal/execution"
@@ -35,6 +38,28 @@ const (
 	StatusSymbolOffline    = "○"
 )
 
+// Shown in place of a value whose source failed on a connected node
+const sourceUnknown = "?"
+
+// Shown in place of a value from an RPC method the node does not serve
+const methodDisabled = "n/a"
+
+// Number of recent events shown below the tables
+const maxDisplayedEvents = 5
+
+const (
+	// Height of the validator duties section, including the empty line above
+	dutyViewLines = 4
+	// Number of upcoming attestations listed
+	maxDisplayedDuties = 6
+	// Proposals this close are highlighted
+	proposalSoonSlots = 32
+	// Number of subscribed subnets listed per node
+	maxDisplayedSubnets = 8
+	// How long skipped blocks are flagged in the block arrival column
+	recentGapWindow = 5 * time.Minute
+)
+
 // Animation frames for the title
 var titleAnimationFrames = []string{
 	"   /\\_/\\     \n  ( o.o )    \n   > ^ <     \n  watcheth    ",
@@ -62,9 +87,34 @@ type Display struct {
 	showLogs          bool
 	selectedLogClient int
 	clientNames       []string
-	nextSlotTime      time.Duration   // Time to next slot
-	consensusHeader   *tview.TextView // Header for consensus section
-	showVersions      bool            // Toggle for showing version columns
+	nextSlotTime      time.Duration            // Time to next slot
+	nextFork          *consensus.ScheduledFork // Next fork agreed by the nodes
+	consensusHeader   *tview.TextView          // Header for consensus section
+	showVersions      bool                     // Toggle for showing version columns
+	showPeers         bool                     // Toggle for showing peer detail columns
+	showMetrics       bool                     // Toggle for showing client metric columns
+	showIdentity      bool                     // Toggle for showing the node identity view
+	identityView      *tview.TextView          // P2P identity of each consensus node
+	identityLines     int                      // Number of lines in the identity view
+	showPool          bool                     // Toggle for showing the operation pool view
+	poolView          *tview.TextView          // Operation pool sizes and recent blobs of each node
+	poolLines         int                      // Number of lines in the pool view
+	specView          *tview.TextView          // Config values that differ between nodes
+	checkpointView    *tview.TextView          // Finalized checkpoints of the checkpoint sync providers
+	checkpointLines   int                      // Number of lines in the checkpoint view, 0 if no providers
+	specLines         int                      // Number of lines in the spec view, 0 if all agree
+	eventView         *tview.TextView          // Recent events across all nodes
+	eventLines        int                      // Number of events currently shown
+	slotView          *tview.TextView          // Slot grid of recent epochs
+	slotLines         int                      // Number of epochs currently shown
+	dutyView          *tview.TextView          // Upcoming duties of our validators
+	showDuties        bool                     // Whether validator duties are known
+	balanceView       *tview.TextView          // Balance and status of our validators
+	showBalances      bool                     // Whether validator balances are known
+	effectivenessView *tview.TextView          // Attestation effectiveness of our validators
+	showEffectiveness bool                     // Whether attestation effectiveness is known
+	syncView          *tview.TextView          // Sync committee duties of our validators
+	syncLines         int                      // Number of sync committee lines currently shown
 }
 
 func NewDisplay(monitor *Monitor) *Display {
@@ -86,6 +136,16 @@ func NewDisplay(monitor *Monitor) *Display {
 		clientNames:       []string{},
 		consensusHeader:   tview.NewTextView(),
 		showVersions:      false, // Hidden by default
+		eventView:         tview.NewTextView(),
+		slotView:          tview.NewTextView(),
+		dutyView:          tview.NewTextView(),
+		balanceView:       tview.NewTextView(),
+		effectivenessView: tview.NewTextView(),
+		syncView:          tview.NewTextView(),
+		identityView:      tview.NewTextView(),
+		specView:          tview.NewTextView(),
+		checkpointView:    tview.NewTextView(),
+		poolView:          tview.NewTextView(),
 	}
 }
 
@@ -179,8 +239,16 @@ func (d *Display) getConsensusHeaders() []string {
 		"Status",
 		"EL Offline",
 		"Slot",
+		"Head",
+		"Arrival",
 		"Peers",
-		"Epoch/Final",
+	}
+	if d.showPeers {
+		headers = append(headers, "In/Out", "Conn/Disc", "Churn")
+	}
+	headers = append(headers, "Epoch/Final", "Reorgs")
+	if d.showMetrics {
+		headers = append(headers, "Gossip/s", "Head Delay", "DB", "Memory")
 	}
 	if d.showVersions {
 		headers = append(headers, "Version")
@@ -195,9 +263,11 @@ func (d *Display) getExecutionHeaders() []string {
 		"Port",
 		"Status",
 		"Block",
+		"Arrival",
 		"Peers",
 		"Gas Price",
 		"Chain ID",
+		"Engine",
 	}
 	if d.showVersions {
 		headers = append(headers, "Version")
@@ -229,6 +299,14 @@ func (d *Display) updateLayout() {
 		AddItem(d.title, 4, 0, false). // Simple cat animation
 		AddItem(nil, 1, 0, false)      // Empty space
 
+	// Sync committee duty is rare, so it goes above everything else
+	if d.syncLines > 0 {
+		d.syncView.SetDynamicColors(true)
+		d.syncView.SetWrap(false)
+		flex.AddItem(d.syncView, d.syncLines, 0, false)
+		flex.AddItem(nil, 1, 0, false)
+	}
+
 	// Check if we have validator clients for summary
 	hasValidators := len(d.monitor.GetValidatorInfos()) > 0
 	if hasValidators {
@@ -238,11 +316,29 @@ func (d *Display) updateLayout() {
 		d.validatorSummary.SetWrap(false)
 		// Count lines in validator summary:
 		// 1 header + 1 status + 1 blank + 6 metrics + 2 blanks + 1 states header + 5 state lines + 1 total = 19 lines
-		validatorLines := 19                                       // Fixed height for validator summary
-		flex.AddItem(d.validatorSummary, validatorLines, 0, false) // Fixed height
+		validatorLines := 19 // Fixed height for validator summary
+
+		// Attestation effectiveness from the beacon node goes next to the
+		// validator client's own view of its performance
+		if d.showEffectiveness {
+			d.effectivenessView.SetDynamicColors(true)
+			d.effectivenessView.SetWrap(false)
+			validatorRow := tview.NewFlex().
+				SetDirection(tview.FlexColumn).
+				AddItem(d.validatorSummary, 0, 1, false).
+				AddItem(d.effectivenessView, 0, 1, false)
+			flex.AddItem(validatorRow, validatorLines, 0, false)
+		} else {
+			flex.AddItem(d.validatorSummary, validatorLines, 0, false) // Fixed height
+		}
 
 		// Add empty space after validator summary
 		flex.AddItem(nil, 1, 0, false)
+	} else if d.showEffectiveness {
+		d.effectivenessView.SetDynamicColors(true)
+		d.effectivenessView.SetWrap(false)
+		flex.AddItem(d.effectivenessView, effectivenessViewLines, 0, false)
+		flex.AddItem(nil, 1, 0, false)
 	}
 
 	// Consensus clients section with slot countdown
@@ -276,8 +372,68 @@ func (d *Display) updateLayout() {
 
 	tablesArea := tview.NewFlex().
 		SetDirection(tview.FlexRow).
-		AddItem(consensusSection, consensusHeight, 0, true).
-		AddItem(executionSection, executionHeight, 0, false)
+		AddItem(consensusSection, consensusHeight, 0, true)
+
+	// Config values that differ between nodes, only shown if any do
+	if d.specLines > 0 {
+		d.specView.SetDynamicColors(true)
+		d.specView.SetWrap(false)
+		tablesArea.AddItem(d.specView, d.specLines, 0, false)
+	}
+
+	// Checkpoint sync providers, only shown if any are configured
+	if d.checkpointLines > 0 {
+		d.checkpointView.SetDynamicColors(true)
+		d.checkpointView.SetWrap(false)
+		tablesArea.AddItem(d.checkpointView, d.checkpointLines, 0, false)
+	}
+
+	// Identity of each node, when toggled on
+	if d.showIdentity && d.identityLines > 0 {
+		d.identityView.SetDynamicColors(true)
+		d.identityView.SetWrap(false)
+		tablesArea.AddItem(d.identityView, d.identityLines, 0, false)
+	}
+
+	// Operation pools and blobs of each node, when toggled on
+	if d.showPool && d.poolLines > 0 {
+		d.poolView.SetDynamicColors(true)
+		d.poolView.SetWrap(false)
+		tablesArea.AddItem(d.poolView, d.poolLines, 0, false)
+	}
+
+	// Slot grid, once block production of recent slots is known
+	if d.slotLines > 0 {
+		d.slotView.SetDynamicColors(true)
+		d.slotView.SetWrap(false)
+		tablesArea.AddItem(d.slotView, d.slotLines+2, 0, false) // +2 for empty space and section header
+	}
+
+	// Duties of our own validators, if any are configured
+	if d.showDuties {
+		d.dutyView.SetDynamicColors(true)
+		d.dutyView.SetWrap(false)
+		tablesArea.AddItem(d.dutyView, dutyViewLines, 0, false)
+	}
+	if d.showBalances {
+		d.balanceView.SetDynamicColors(true)
+		d.balanceView.SetWrap(false)
+		tablesArea.AddItem(d.balanceView, balanceViewLines, 0, false)
+	}
+
+	tablesArea.AddItem(executionSection, executionHeight, 0, false)
+
+	// Events section, only shown once something has happened
+	if d.eventLines > 0 {
+		d.eventView.SetDynamicColors(true)
+		d.eventView.SetWrap(false)
+		eventsSection := tview.NewFlex().
+			SetDirection(tview.FlexRow).
+			AddItem(nil, 1, 0, false). // Empty space for separation
+			AddItem(tview.NewTextView().SetText("  ● Events").SetTextColor(tcell.ColorGreen), 1, 0, false).
+			AddItem(d.eventView, d.eventLines, 0, false)
+		tablesArea.AddItem(eventsSection, d.eventLines+2, 0, false)
+	}
 
 	if d.showLogs {
 		// Split view: tables and logs
@@ -343,6 +499,32 @@ func (d *Display) updateLayout() {
 				d.updateLogView()
 			}
 			return nil
+		case 'p', 'P':
+			// Toggle peer detail columns
+			d.showPeers = !d.showPeers
+			d.setupTables()
+			go d.updateTables(d.monitor.GetNodeInfos())
+			d.updateHelpText()
+			return nil
+		case 'i', 'I':
+			// Toggle node identity view
+			d.showIdentity = !d.showIdentity
+			d.updateHelpText()
+			d.updateLayout()
+			return nil
+		case 'o', 'O':
+			// Toggle operation pool view
+			d.showPool = !d.showPool
+			d.updateHelpText()
+			d.updateLayout()
+			return nil
+		case 'm', 'M':
+			// Toggle client metric columns
+			d.showMetrics = !d.showMetrics
+			d.setupTables()
+			go d.updateTables(d.monitor.GetNodeInfos())
+			d.updateHelpText()
+			return nil
 		case 'v', 'V':
 			// Toggle version columns
 			d.showVersions = !d.showVersions
@@ -382,7 +564,7 @@ func (d *Display) updateTables(update NodeUpdate) {
 
 	d.app.QueueUpdateDraw(func() {
 		// Update consensus table
-		d.updateConsensusTable(update.ConsensusInfos)
+		d.updateConsensusTable(update.ConsensusInfos, update.Divergence, update.Forks, update.Specs, update.Checkpoints)
 
 		// Update execution table
 		d.updateExecutionTable(update.ExecutionInfos)
@@ -390,14 +572,33 @@ func (d *Display) updateTables(update NodeUpdate) {
 		// Update validator table
 		d.updateValidatorTable(update.ValidatorInfos)
 
-		// Update layout if validator clients were added/removed
-		if len(update.ValidatorInfos) > 0 {
+		// Update slot grid, our validators and events
+		slotLines, showDuties, showBalances, eventLines := d.slotLines, d.showDuties, d.showBalances, d.eventLines
+		identityLines, specLines, poolLines, checkpointLines := d.identityLines, d.specLines, d.poolLines, d.checkpointLines
+		showEffectiveness, syncLines := d.showEffectiveness, d.syncLines
+		d.updateIdentityView(update.ConsensusInfos)
+		d.updateSpecView(update.ConsensusInfos, update.Specs)
+		d.updateCheckpointView(update.ConsensusInfos, update.Checkpoints)
+		d.updatePoolView(update.ConsensusInfos)
+		d.updateSlotView(update.Slots)
+		d.updateDutyView(update.Duties)
+		d.updateBalanceView(update.Balances)
+		d.updateEffectivenessView(update.Effectiveness)
+		d.updateSyncCommitteeView(update.SyncCommittees)
+		d.updateEventView(update.Events)
+
+		// Update layout if validator clients were added/removed or the
+		// sections below the consensus table changed size
+		if len(update.ValidatorInfos) > 0 || d.slotLines != slotLines || d.showDuties != showDuties ||
+			d.showBalances != showBalances || d.showEffectiveness != showEffectiveness || d.syncLines != syncLines ||
+			d.eventLines != eventLines || d.specLines != specLines || d.checkpointLines != checkpointLines ||
+			(d.showIdentity && d.identityLines != identityLines) || (d.showPool && d.poolLines != poolLines) {
 			d.updateLayout()
 		}
 	})
 }
 
-func (d *Display) updateConsensusTable(infos []*consensus.ConsensusNodeInfo) {
+func (d *Display) updateConsensusTable(infos []*consensus.ConsensusNodeInfo, divergence *DivergenceReport, forks *ForkReport, specs *SpecReport, checkpoints *CheckpointReport) {
 	if infos == nil {
 		infos = []*consensus.ConsensusNodeInfo{}
 	}
@@ -442,7 +643,15 @@ func (d *Display) updateConsensusTable(infos []*consensus.ConsensusNodeInfo) {
 
 		// Status with symbol
 		status, statusColor, statusSymbol := d.getStatusInfo(info)
+		if info.IsConnected && specs.IsOtherNetwork(info.Name) {
+			// Not compared with the other nodes, so flag it over anything else
+			status, statusColor, statusSymbol = "Other network", tcell.ColorRed, StatusSymbolOffline
+		}
+		status, statusColor = degradeStatus(status, statusColor, info.IsConnected, info.Sources)
 		statusText := fmt.Sprintf("%s %s", statusSymbol, status)
+		if info.IsConnected && info.EventStream {
+			statusText += " (live)"
+		}
 		d.setConsensusCell(tableRow, col, statusText, statusColor)
 		col++
 
@@ -473,10 +682,29 @@ func (d *Display) updateConsensusTable(infos []*consensus.ConsensusNodeInfo) {
 		}
 		col++
 
+		// Head root, flagged if it differs from the other nodes at the same slot
+		if info.IsConnected && info.HeadRoot != "" {
+			if divergence.Has(info.Name, DivergenceHead) {
+				d.setConsensusCell(tableRow, col, consensus.ShortRoot(info.HeadRoot)+" ⚠", tcell.ColorRed)
+			} else {
+				d.setConsensusCell(tableRow, col, consensus.ShortRoot(info.HeadRoot), tcell.ColorWhite)
+			}
+		} else {
+			d.setConsensusCell(tableRow, col, "-", tcell.ColorGray)
+		}
+		col++
+
+		// Head arrival delay, median and 90th percentile over recent slots
+		arrivalText, arrivalColor := formatHeadArrival(info)
+		d.setConsensusCell(tableRow, col, arrivalText, arrivalColor)
+		col++
+
 		// Peers with color
 		var peerText string
 		var peerColor tcell.Color
-		if info.IsConnected && info.PeerCount > 0 {
+		if info.IsConnected && info.Sources.Err(consensus.QueryPeers) != nil {
+			peerText, peerColor = sourceUnknown, tcell.ColorYellow
+		} else if info.IsConnected && info.PeerCount > 0 {
 			peerText = fmt.Sprintf("%d", info.PeerCount)
 			if info.PeerCount >= 50 {
 				peerColor = tcell.ColorGreen
@@ -492,8 +720,21 @@ func (d *Display) updateConsensusTable(infos []*consensus.ConsensusNodeInfo) {
 		d.setConsensusCell(tableRow, col, peerText, peerColor)
 		col++
 
+		// Peer breakdown (if enabled)
+		if d.showPeers {
+			for _, cell := range formatPeerDetails(info) {
+				d.setConsensusCell(tableRow, col, cell.text, cell.color)
+				col++
+			}
+		}
+
 		// Epoch with arrow notation when behind
-		if info.IsConnected {
+		if info.IsConnected && (divergence.Has(info.Name, DivergenceJustified) || divergence.Has(info.Name, DivergenceFinalized) ||
+			checkpoints.Mismatched(info.Name)) {
+			// Checkpoints disagree with the other nodes or a checkpoint sync provider
+			epochText := fmt.Sprintf("%d/%d ⚠", info.CurrentEpoch, info.FinalizedEpoch)
+			d.setConsensusCell(tableRow, col, epochText, tcell.ColorRed)
+		} else if info.IsConnected {
 			if info.FinalizedEpoch == info.CurrentEpoch {
 				epochText := fmt.Sprintf("%d ✓", info.CurrentEpoch)
 				d.setConsensusCell(tableRow, col, epochText, tcell.ColorWhite)
@@ -507,10 +748,25 @@ func (d *Display) updateConsensusTable(infos []*consensus.ConsensusNodeInfo) {
 		}
 		col++
 
+		// Reorg counter with details of the most recent one
+		reorgText, reorgColor := formatReorgs(info)
+		d.setConsensusCell(tableRow, col, reorgText, reorgColor)
+		col++
+
+		// Client metrics (if enabled)
+		if d.showMetrics {
+			for _, text := range formatMetrics(info) {
+				d.setConsensusCell(tableRow, col, text, tcell.ColorWhite)
+				col++
+			}
+		}
+
 		// Node version (if enabled)
 		if d.showVersions {
 			var versionText string
-			if info.IsConnected && info.NodeVersion != "" {
+			if info.IsConnected && info.Sources.Err(consensus.QueryVersion) != nil {
+				versionText = sourceUnknown
+			} else if info.IsConnected && info.NodeVersion != "" {
 				// Extract just the client/version part (e.g., "Prysm/v4.0.8" from full version string)
 				parts := strings.Split(info.NodeVersion, " ")
 				if len(parts) > 0 {
@@ -525,14 +781,24 @@ func (d *Display) updateConsensusTable(infos []*consensus.ConsensusNodeInfo) {
 			col++
 		}
 
-		// Fork version (last column)
-		var forkText string
+		// Fork name, or version if not known (last column)
+		forkText, forkColor := "-", tcell.ColorWhite
 		if info.IsConnected && info.CurrentFork != "" {
 			forkText = info.CurrentFork
-		} else {
-			forkText = "-"
+			if info.CurrentForkName != "" {
+				forkText = info.CurrentForkName
+			}
 		}
-		d.setConsensusCell(tableRow, col, forkText, tcell.ColorWhite)
+		if info.IsConnected && forks.Issue(info.Name) != "" {
+			// Fork schedule disagrees with the other nodes
+			forkText += " ⚠"
+			forkColor = tcell.ColorRed
+		}
+		d.setConsensusCell(tableRow, col, forkText, forkColor)
+	}
+
+	if forks != nil {
+		d.nextFork = forks.Next
 	}
 }
 
@@ -573,6 +839,7 @@ func (d *Display) updateExecutionTable(infos []*execution.ExecutionNodeInfo) {
 
 		// Status with symbol
 		status, statusColor, statusSymbol := d.getExecutionStatusInfo(info)
+		status, statusColor = degradeStatus(status, statusColor, info.IsConnected, info.Sources)
 		statusText := fmt.Sprintf("%s %s", statusSymbol, status)
 		d.setExecutionCell(tableRow, col, statusText, statusColor)
 		col++
@@ -591,10 +858,17 @@ func (d *Display) updateExecutionTable(infos []*execution.ExecutionNodeInfo) {
 		}
 		col++
 
+		// Arrival of the last pushed head
+		arrivalText, arrivalColor := formatBlockArrival(info, time.Now())
+		d.setExecutionCell(tableRow, col, arrivalText, arrivalColor)
+		col++
+
 		// Peers with color
 		var peerText string
 		var peerColor tcell.Color
-		if info.IsConnected && info.PeerCount > 0 {
+		if err := info.Sources.Err("net_peerCount"); info.IsConnected && err != nil {
+			peerText, peerColor = rpcUnavailable(err)
+		} else if info.IsConnected && info.PeerCount > 0 {
 			peerText = fmt.Sprintf("%d", info.PeerCount)
 			if info.PeerCount >= 25 {
 				peerColor = tcell.ColorGreen
@@ -611,7 +885,10 @@ func (d *Display) updateExecutionTable(infos []*execution.ExecutionNodeInfo) {
 		col++
 
 		// Gas price
-		if info.IsConnected && info.GasPrice != nil {
+		if err := info.Sources.Err("eth_gasPrice"); info.IsConnected && err != nil {
+			text, color := rpcUnavailable(err)
+			d.setExecutionCell(tableRow, col, text, color)
+		} else if info.IsConnected && info.GasPrice != nil {
 			gasPrice := new(big.Int).Div(info.GasPrice, big.NewInt(1e9)) // Convert to gwei
 			gasPriceText := fmt.Sprintf("%d gwei", gasPrice.Int64())
 			d.setExecutionCell(tableRow, col, gasPriceText, tcell.ColorWhite)
@@ -621,7 +898,10 @@ func (d *Display) updateExecutionTable(infos []*execution.ExecutionNodeInfo) {
 		col++
 
 		// Chain ID
-		if info.IsConnected && info.ChainID != nil {
+		if err := info.Sources.Err("eth_chainId"); info.IsConnected && err != nil {
+			text, color := rpcUnavailable(err)
+			d.setExecutionCell(tableRow, col, text, color)
+		} else if info.IsConnected && info.ChainID != nil {
 			chainIDText := info.ChainID.String()
 			d.setExecutionCell(tableRow, col, chainIDText, tcell.ColorWhite)
 		} else {
@@ -629,10 +909,17 @@ func (d *Display) updateExecutionTable(infos []*execution.ExecutionNodeInfo) {
 		}
 		col++
 
+		// Engine API
+		engineText, engineColor := formatEngine(info.Engine)
+		d.setExecutionCell(tableRow, col, engineText, engineColor)
+		col++
+
 		// Node version (if enabled)
 		if d.showVersions {
 			var versionText string
-			if info.IsConnected && info.NodeVersion != "" {
+			if err := info.Sources.Err("web3_clientVersion"); info.IsConnected && err != nil {
+				versionText, _ = rpcUnavailable(err)
+			} else if info.IsConnected && info.NodeVersion != "" {
 				versionText = info.NodeVersion
 			} else {
 				versionText = "-"
@@ -708,11 +995,428 @@ func (d *Display) setCellWithColoredArrow(table *tview.Table, row, col int, base
 	d.setCell(table, row, col, text, cellColor)
 }
 
+// formatReorgs returns the reorg counter and last reorg detail for a node.
+// Reorgs deeper than a single slot are highlighted in red.
+type tableCell struct {
+	text  string
+	color tcell.Color
+}
+
+// formatPeerDetails returns the peer detail cells: connected peers by
+// direction, peers connecting and disconnected, and churn over the last few
+// minutes
+func formatPeerDetails(info *consensus.ConsensusNodeInfo) []tableCell {
+	peers := info.Peers
+	if !info.IsConnected || peers == nil {
+		return []tableCell{{"-", tcell.ColorGray}, {"-", tcell.ColorGray}, {"-", tcell.ColorGray}}
+	}
+
+	cells := make([]tableCell, 0, 3)
+	if peers.HasDetails {
+		// Few outbound peers suggests the node is not dialling out properly,
+		// few inbound ones that it is not reachable
+		color := tcell.ColorWhite
+		if peers.Outbound == 0 || peers.Inbound == 0 {
+			color = tcell.ColorYellow
+		}
+		cells = append(cells, tableCell{fmt.Sprintf("%d/%d", peers.Inbound, peers.Outbound), color})
+	} else {
+		cells = append(cells, tableCell{"-", tcell.ColorGray})
+	}
+
+	cells = append(cells, tableCell{fmt.Sprintf("%d/%d", peers.Connecting, peers.Disconnected), tcell.ColorWhite})
+
+	if peers.HasDetails && len(peers.Churn) > 0 {
+		color := tcell.ColorWhite
+		// Turning over more than the whole peer set is unusual
+		if uint64(peers.Joined+peers.Left) > 2*peers.Connected {
+			color = tcell.ColorYellow
+		}
+		cells = append(cells, tableCell{fmt.Sprintf("+%d/-%d", peers.Joined, peers.Left), color})
+	} else {
+		cells = append(cells, tableCell{"-", tcell.ColorGray})
+	}
+	return cells
+}
+
+func formatReorgs(info *consensus.ConsensusNodeInfo) (string, tcell.Color) {
+	if !info.IsConnected {
+		return "-", tcell.ColorGray
+	}
+	if info.LastReorg == nil {
+		return fmt.Sprintf("%d", info.ReorgCount), tcell.ColorWhite
+	}
+
+	text := fmt.Sprintf("%d (last: depth %d @ %d)", info.ReorgCount, info.LastReorg.Depth, info.LastReorg.Slot)
+	if info.LastReorg.Depth > 1 {
+		return text, tcell.ColorRed
+	}
+	return text, tcell.ColorYellow
+}
+
+// updateSlotView shows the outcome of every slot in recent epochs, along with
+// the network-wide missed slot rate and any nodes missing blocks the others have
+func (d *Display) updateSlotView(report *SlotReport) {
+	if report == nil || report.Known == 0 {
+		d.slotView.SetText("")
+		d.slotLines = 0
+		return
+	}
+
+	header := fmt.Sprintf("\n  [green]● Slots - Missed: %d/%d (%.1f%%)[-]", report.Missed, report.Known, report.MissedRate())
+	if len(report.LocalMisses) > 0 {
+		nodes := make([]string, 0, len(report.LocalMisses))
+		for node, count := range report.LocalMisses {
+			nodes = append(nodes, fmt.Sprintf("%s (%d)", tview.Escape(node), count))
+		}
+		sort.Strings(nodes)
+		header += fmt.Sprintf(" [yellow]Blocks not seen by: %s[-]", strings.Join(nodes, ", "))
+	}
+
+	lines := []string{header}
+	for _, epoch := range report.Epochs {
+		var grid strings.Builder
+		missed := 0
+		for _, slot := range epoch.Slots {
+			switch {
+			case slot.Status == consensus.SlotMissed:
+				missed++
+				grid.WriteString("[red]■")
+			case slot.Status == consensus.SlotProposed && len(slot.MissingOn) > 0:
+				grid.WriteString("[yellow]■")
+			case slot.Status == consensus.SlotProposed:
+				grid.WriteString("[green]■")
+			default:
+				grid.WriteString("[gray]·")
+			}
+		}
+		lines = append(lines, fmt.Sprintf("  [white]Epoch %-8d %s[-]  [white]%d missed[-]", epoch.Epoch, grid.String(), missed))
+	}
+
+	d.slotView.SetText(strings.Join(lines, "\n"))
+	d.slotLines = len(report.Epochs)
+}
+
+// updateDutyView shows the next proposal and upcoming attestations of the
+// configured validators
+func (d *Display) updateDutyView(duties *consensus.ValidatorDuties) {
+	if duties == nil {
+		d.dutyView.SetText("")
+		d.showDuties = false
+		return
+	}
+
+	header := fmt.Sprintf("\n  [green]● Validator Duties (%d validators)[-]", len(duties.Validators))
+	if len(duties.Unresolved) > 0 {
+		header += fmt.Sprintf(" [yellow]%d not found on node[-]", len(duties.Unresolved))
+	}
+
+	proposal := "  [white]Next proposal: none in current or next epoch[-]"
+	if next := duties.NextProposal(); next != nil {
+		slots := next.Slot - duties.CurrentSlot
+		color := "white"
+		if slots <= proposalSoonSlots {
+			color = "yellow"
+		}
+		proposal = fmt.Sprintf("  [%s]Next proposal: validator %d in %d slots (slot %d)[-]", color, next.ValidatorIndex, slots, next.Slot)
+		if len(duties.Proposals) > 1 {
+			proposal += fmt.Sprintf(" [gray]+%d more[-]", len(duties.Proposals)-1)
+		}
+	}
+
+	attestations := duties.UpcomingAttestations()
+	attestation := "  [white]Attestations: none scheduled[-]"
+	if len(attestations) > 0 {
+		shown := attestations
+		if len(shown) > maxDisplayedDuties {
+			shown = shown[:maxDisplayedDuties]
+		}
+		parts := make([]string, len(shown))
+		for i, duty := range shown {
+			parts[i] = fmt.Sprintf("%d @ %d", duty.ValidatorIndex, duty.Slot)
+		}
+		attestation = fmt.Sprintf("  [white]Attestations: %s[-]", strings.Join(parts, ", "))
+		if len(attestations) > len(shown) {
+			attestation += fmt.Sprintf(" [gray]+%d more[-]", len(attestations)-len(shown))
+		}
+	}
+
+	d.dutyView.SetText(strings.Join([]string{header, proposal, attestation}, "\n"))
+	d.showDuties = true
+}
+
+// updateIdentityView shows the p2p identity of each connected node: its peer
+// ID, subnet subscriptions and advertised address, flagging addresses that
+// other peers cannot reach
+func (d *Display) updateIdentityView(infos []*consensus.ConsensusNodeInfo) {
+	lines := []string{"", "  [green]● Node Identity[-]"}
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		identity := info.Identity
+		if !info.IsConnected || identity == nil {
+			lines = append(lines, fmt.Sprintf("  %s: [gray]identity not available[-]", tview.Escape(info.Name)))
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s: peer %s  seq %d  attnets %s  syncnets %s",
+			tview.Escape(info.Name), consensus.ShortRoot(identity.PeerID), identity.SeqNumber,
+			formatSubnets(identity.Attnets, 64), formatSubnets(identity.Syncnets, 4)))
+
+		address := "[gray]no p2p address[-]"
+		if len(identity.P2PAddresses) > 0 {
+			address = tview.Escape(identity.P2PAddresses[0])
+			if len(identity.P2PAddresses) > 1 {
+				address += fmt.Sprintf(" [gray]+%d more[-]", len(identity.P2PAddresses)-1)
+			}
+		}
+		if len(identity.PrivateAddresses) > 0 {
+			address += " [red]⚠ advertises a private address[-]"
+		}
+		lines = append(lines, "    "+address)
+	}
+
+	d.identityView.SetText(strings.Join(lines, "\n"))
+	d.identityLines = len(lines)
+}
+
+// updateSpecView lists the genesis and spec values of each node that differ
+// from the other nodes, in the order the nodes are configured
+func (d *Display) updateSpecView(infos []*consensus.ConsensusNodeInfo, report *SpecReport) {
+	var lines []string
+	for _, info := range infos {
+		if info == nil || report == nil {
+			continue
+		}
+		differences, color := report.OtherNetwork[info.Name], "red"
+		if len(differences) == 0 {
+			differences, color = report.Differences[info.Name], "yellow"
+		}
+		if len(differences) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  [%s]%s: %s[-]", color, tview.Escape(info.Name), tview.Escape(report.Issue(info.Name))))
+		for _, difference := range differences {
+			lines = append(lines, "    "+tview.Escape(formatSpecDifference(difference)))
+		}
+	}
+	if len(lines) > 0 {
+		lines = append([]string{"", "  [green]● Spec Differences[-]"}, lines...)
+	}
+
+	d.specView.SetText(strings.Join(lines, "\n"))
+	d.specLines = len(lines)
+}
+
+// updateCheckpointView shows the finalized checkpoint of each checkpoint sync
+// provider, and whether each connected node agrees with it
+func (d *Display) updateCheckpointView(infos []*consensus.ConsensusNodeInfo, report *CheckpointReport) {
+	var lines []string
+	if report != nil {
+		lines = append(lines, "", "  [green]● Checkpoint Providers[-]")
+		for _, provider := range report.Providers {
+			if provider.Checkpoint == nil {
+				lines = append(lines, fmt.Sprintf("  %s: [gray]not available[-]", tview.Escape(provider.Name)))
+				continue
+			}
+			line := fmt.Sprintf("  %s: epoch %d %s ", tview.Escape(provider.Name),
+				provider.Checkpoint.Epoch, consensus.ShortRoot(provider.Checkpoint.Root))
+			for _, info := range infos {
+				if info == nil {
+					continue
+				}
+				comparison := report.find(info.Name, provider.Name)
+				switch {
+				case comparison == nil:
+					line += fmt.Sprintf(" [gray]%s -[-]", tview.Escape(info.Name))
+				case comparison.Status == consensus.CheckpointMatch:
+					line += fmt.Sprintf(" [green]%s ✓[-]", tview.Escape(info.Name))
+				case comparison.Status == consensus.CheckpointMismatch:
+					line += fmt.Sprintf(" [red]%s ✗[-]", tview.Escape(info.Name))
+				default:
+					line += fmt.Sprintf(" [yellow]%s ?[-]", tview.Escape(info.Name))
+				}
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	d.checkpointView.SetText(strings.Join(lines, "\n"))
+	d.checkpointLines = len(lines)
+}
+
+// updatePoolView shows the size of each operation pool of each connected
+// node, and the blobs in its recent blocks. Pending slashings and blocks with
+// missing blobs are shown in red.
+func (d *Display) updatePoolView(infos []*consensus.ConsensusNodeInfo) {
+	lines := []string{"", "  [green]● Operation Pool[-]"}
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		pool := info.Pool
+		if !info.IsConnected || pool == nil {
+			lines = append(lines, fmt.Sprintf("  %s: [gray]pool not available[-]", tview.Escape(info.Name)))
+			continue
+		}
+
+		slashingColor := "white"
+		if pool.AttesterSlashings+pool.ProposerSlashings > 0 {
+			slashingColor = "red"
+		}
+		lines = append(lines, fmt.Sprintf("  %s: attestations %s  exits %s  [%s]slashings %s attester, %s proposer[-]  bls changes %s",
+			tview.Escape(info.Name),
+			poolCount(pool, consensus.PoolAttestations, pool.Attestations),
+			poolCount(pool, consensus.PoolVoluntaryExits, pool.VoluntaryExits),
+			slashingColor,
+			poolCount(pool, consensus.PoolAttesterSlashings, pool.AttesterSlashings),
+			poolCount(pool, consensus.PoolProposerSlashings, pool.ProposerSlashings),
+			poolCount(pool, consensus.PoolBLSToExecutionChanges, pool.BLSToExecutionChanges)))
+		lines = append(lines, "    blobs "+formatBlobs(info.Blobs))
+	}
+
+	d.poolView.SetText(strings.Join(lines, "\n"))
+	d.poolLines = len(lines)
+}
+
+// poolCount returns the size of a pool, or - if it could not be fetched
+func poolCount(pool *consensus.OperationPool, name string, count int) string {
+	if !pool.Known(name) {
+		return "-"
+	}
+	return strconv.Itoa(count)
+}
+
+// formatHeadArrival returns the median and 90th percentile of a node's head
+// arrival delay, in yellow if some heads are late and red if most are
+func formatHeadArrival(info *consensus.ConsensusNodeInfo) (string, tcell.Color) {
+	stats := info.HeadArrival
+	if !info.IsConnected || stats == nil {
+		return "-", tcell.ColorGray
+	}
+	text := formatArrival(stats.Median) + "/" + formatArrival(stats.P90)
+	switch {
+	case stats.Median > stats.Deadline:
+		return text, tcell.ColorRed
+	case stats.P90 > stats.Deadline:
+		return text, tcell.ColorYellow
+	}
+	return text, tcell.ColorWhite
+}
+
+// formatMetrics returns the gossip rate, head delay, database size and
+// memory of a node, with - for anything it does not report
+func formatMetrics(info *consensus.ConsensusNodeInfo) []string {
+	texts := []string{"-", "-", "-", "-"}
+	metrics := info.Metrics
+	if !info.IsConnected || metrics == nil {
+		return texts
+	}
+	if metrics.GossipMessageRate > 0 {
+		texts[0] = fmt.Sprintf("%.1f", metrics.GossipMessageRate)
+	}
+	if metrics.HeadDelay > 0 {
+		texts[1] = fmt.Sprintf("%dms", metrics.HeadDelay.Milliseconds())
+	}
+	if metrics.DBSizeBytes > 0 {
+		texts[2] = formatBytes(metrics.DBSizeBytes)
+	}
+	if metrics.MemoryBytes > 0 {
+		texts[3] = formatBytes(metrics.MemoryBytes)
+	}
+	return texts
+}
+
+// formatBytes returns a size in binary units, e.g. 1.5 GiB
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, exp := float64(bytes)/unit, 0
+	for value >= unit && exp < 4 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, "KMGTP"[exp])
+}
+
+// formatBlobs formats the blob count of each recent block, oldest first.
+// Blocks with missing blobs show how many are available.
+func formatBlobs(blocks []consensus.BlockBlobs) string {
+	if len(blocks) == 0 {
+		return "[gray]none known[-]"
+	}
+	parts := make([]string, len(blocks))
+	for i, block := range blocks {
+		switch {
+		case block.Missing():
+			parts[i] = fmt.Sprintf("[red]%d/%d[-]", block.Available, block.Expected)
+		case !block.Known:
+			parts[i] = fmt.Sprintf("[gray]%d?[-]", block.Expected)
+		default:
+			parts[i] = strconv.Itoa(block.Expected)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatSubnets formats the first few subscribed subnets followed by how many
+// of the total the node is subscribed to
+func formatSubnets(subnets []int, total int) string {
+	if len(subnets) == 0 {
+		return fmt.Sprintf("none (0/%d)", total)
+	}
+	shown := subnets
+	if len(shown) > maxDisplayedSubnets {
+		shown = shown[:maxDisplayedSubnets]
+	}
+	parts := make([]string, len(shown))
+	for i, subnet := range shown {
+		parts[i] = strconv.Itoa(subnet)
+	}
+	text := strings.Join(parts, ",")
+	if len(subnets) > len(shown) {
+		text += fmt.Sprintf(",+%d", len(subnets)-len(shown))
+	}
+	return fmt.Sprintf("%s (%d/%d)", text, len(subnets), total)
+}
+
+// updateEventView shows the most recent events, newest first
+func (d *Display) updateEventView(events []Event) {
+	count := len(events)
+	if count > maxDisplayedEvents {
+		count = maxDisplayedEvents
+	}
+
+	lines := make([]string, 0, count)
+	for i := len(events) - 1; i >= len(events)-count; i-- {
+		event := events[i]
+		color := "white"
+		switch event.Severity {
+		case EventWarning:
+			color = "yellow"
+		case EventCritical:
+			color = "red"
+		}
+		lines = append(lines, fmt.Sprintf("  [gray]%s[-] [%s]%s: %s[-]",
+			event.Time.Format("15:04:05"), color, tview.Escape(event.Node), tview.Escape(event.Message)))
+	}
+
+	d.eventView.SetText(strings.Join(lines, "\n"))
+	d.eventLines = count
+}
+
 func (d *Display) getStatusInfo(info *consensus.ConsensusNodeInfo) (string, tcell.Color, string) {
+	if info != nil && info.Health == consensus.HealthNotReady {
+		// Reachable, but the node reports it is not initialized or has issues
+		return "Not ready", tcell.ColorRed, StatusSymbolOffline
+	}
 	if info == nil || !info.IsConnected {
 		return "Offline", tcell.ColorRed, StatusSymbolOffline
 	}
-	if info.IsSyncing {
+	if info.IsSyncing || info.Health == consensus.HealthSyncing {
 		return "Syncing", tcell.ColorYellow, StatusSymbolSyncing
 	}
 	if info.IsOptimistic {
@@ -721,6 +1425,27 @@ func (d *Display) getStatusInfo(info *consensus.ConsensusNodeInfo) (string, tcel
 	return "Synced", tcell.ColorGreen, StatusSymbolSynced
 }
 
+// degradeStatus qualifies the status of a connected node that failed to
+// report some of its data, which is shown as unknown rather than missing
+func degradeStatus(status string, color tcell.Color, connected bool, sources common.Sources) (string, tcell.Color) {
+	if !connected || !sources.Degraded() {
+		return status, color
+	}
+	if color == tcell.ColorGreen {
+		color = tcell.ColorYellow
+	}
+	return status + " (degraded)", color
+}
+
+// rpcUnavailable returns the text and color for a value whose method failed,
+// telling a method the node does not serve from one that failed this time
+func rpcUnavailable(err error) (string, tcell.Color) {
+	if execution.IsMethodNotFound(err) {
+		return methodDisabled, tcell.ColorGray
+	}
+	return sourceUnknown, tcell.ColorYellow
+}
+
 func (d *Display) getExecutionStatusInfo(info *execution.ExecutionNodeInfo) (string, tcell.Color, string) {
 	if info == nil || !info.IsConnected {
 		return "Offline", tcell.ColorRed, StatusSymbolOffline
@@ -729,9 +1454,46 @@ func (d *Display) getExecutionStatusInfo(info *execution.ExecutionNodeInfo) (str
 		syncPercent := fmt.Sprintf("%.1f%%", info.SyncProgress)
 		return fmt.Sprintf("Syncing %s", syncPercent), tcell.ColorYellow, StatusSymbolSyncing
 	}
+	if info.HeadStream {
+		return "Synced (live)", tcell.ColorGreen, StatusSymbolSynced
+	}
 	return "Synced", tcell.ColorGreen, StatusSymbolSynced
 }
 
+// formatEngine returns the state of a node's engine API, or - if it is not
+// checked
+func formatEngine(engine *execution.EngineStatus) (string, tcell.Color) {
+	switch {
+	case engine == nil:
+		return "-", tcell.ColorGray
+	case engine.SecretError != nil:
+		return "no jwt", tcell.ColorYellow
+	case !engine.Reachable:
+		return "down", tcell.ColorRed
+	case !engine.Authenticated:
+		return "no auth", tcell.ColorRed
+	case engine.Error != nil:
+		return sourceUnknown, tcell.ColorYellow
+	case !engine.Capable:
+		return "limited", tcell.ColorYellow
+	}
+	return "ok", tcell.ColorGreen
+}
+
+// formatBlockArrival returns how long after its timestamp the node pushed its
+// last head, in yellow with the number of blocks skipped if the subscription
+// skipped any within recentGapWindow
+func formatBlockArrival(info *execution.ExecutionNodeInfo, now time.Time) (string, tcell.Color) {
+	if !info.IsConnected || !info.HeadStream || info.LastBlockTime.IsZero() {
+		return "-", tcell.ColorGray
+	}
+	text := formatArrival(info.BlockArrival)
+	if gap := info.LastGap; gap != nil && now.Sub(gap.Seen) < recentGapWindow {
+		return fmt.Sprintf("%s ⚠%d", text, gap.Blocks()), tcell.ColorYellow
+	}
+	return text, tcell.ColorWhite
+}
+
 func (d *Display) formatDuration(duration time.Duration) string {
 	if duration < 0 {
 		return "0s"
@@ -752,9 +1514,33 @@ func (d *Display) updateConsensusHeader() {
 	if d.nextSlotTime > 0 {
 		headerText = fmt.Sprintf("  ● Consensus Clients - Next slot in: %s", d.formatDuration(d.nextSlotTime))
 	}
+	if d.nextFork != nil {
+		headerText += fmt.Sprintf(" - Next fork: %s at epoch %d", d.nextFork.DisplayName(), d.nextFork.Epoch)
+		if !d.nextFork.Activation.IsZero() {
+			headerText += fmt.Sprintf(" in %s", formatCountdown(time.Until(d.nextFork.Activation)))
+		}
+	}
 	d.consensusHeader.SetText(headerText)
 }
 
+// formatCountdown formats a long duration in days, hours and minutes
+func formatCountdown(duration time.Duration) string {
+	if duration < 0 {
+		duration = 0
+	}
+	days := int(duration.Hours()) / 24
+	hours := int(duration.Hours()) % 24
+	minutes := int(duration.Minutes()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm%ds", minutes, int(duration.Seconds())%60)
+	}
+}
+
 func (d *Display) updateHelpText() {
 	// Calculate time until next refresh
 	timeLeft := time.Until(d.nextRefresh)
@@ -778,8 +1564,28 @@ func (d *Display) updateHelpText() {
 		versionsHelp = " | v:Hide Versions"
 	}
 
-	helpText := fmt.Sprintf("  q:Quit | r:Refresh%s%s | Next: %ds",
-		versionsHelp, logHelp, int(timeLeft.Seconds()))
+	peersHelp := " | p:Show Peers"
+	if d.showPeers {
+		peersHelp = " | p:Hide Peers"
+	}
+
+	metricsHelp := " | m:Show Metrics"
+	if d.showMetrics {
+		metricsHelp = " | m:Hide Metrics"
+	}
+
+	identityHelp := " | i:Show Identity"
+	if d.showIdentity {
+		identityHelp = " | i:Hide Identity"
+	}
+
+	poolHelp := " | o:Show Pool"
+	if d.showPool {
+		poolHelp = " | o:Hide Pool"
+	}
+
+	helpText := fmt.Sprintf("  q:Quit | r:Refresh%s%s%s%s%s%s | Next: %ds",
+		versionsHelp, peersHelp, metricsHelp, identityHelp, poolHelp, logHelp, int(timeLeft.Seconds()))
 	d.help.SetText(helpText)
 }
 
@@ -861,6 +1667,11 @@ func parsePortFromEndpoint(endpoint string) string {
 		return endpoint
 	}
 
+	// A socket path is too long for the column
+	if u.Scheme == "ipc" {
+		return "ipc"
+	}
+
 	// If port is explicitly specified, return just the port
 	if u.Port() != "" {
 		return u.Port()
@@ -15,8 +15,10 @@ package monitor
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/watcheth/watcheth/internal/consensus"
 	"github.com/watcheth/watcheth/internal/validator"
 )
 
@@ -26,6 +28,15 @@ func (d *Display) updateValidatorTable(infos []*validator.ValidatorNodeInfo) {
 
 const progressBarWidth = 20
 
+const (
+	// Height of the validator balances section, including the empty line above
+	balanceViewLines = 5
+	// Number of validators listed as needing attention
+	maxAttentionValidators = 5
+	// Height of the attestation effectiveness panel
+	effectivenessViewLines = 8
+)
+
 // createProgressBar creates an ASCII progress bar with a fixed width
 func createProgressBar(percentage float64) string {
 	// Ensure percentage is between 0 and 100
@@ -190,7 +201,10 @@ func (d *Display) updateValidatorSummary(infos []*validator.ValidatorNodeInfo) {
 
 		// Status indicator
 		var statusSymbol, statusColor string
-		if info.IsConnected {
+		if info.IsConnected && info.Sources.Degraded() {
+			statusSymbol = "●"
+			statusColor = "yellow"
+		} else if info.IsConnected {
 			statusSymbol = "●"
 			statusColor = "green"
 		} else {
@@ -366,6 +380,241 @@ func (d *Display) updateValidatorSummary(infos []*validator.ValidatorNodeInfo) {
 	d.validatorSummary.SetText(summary.String()).SetDynamicColors(true)
 }
 
+// updateEffectivenessView shows how well our own validators attested in the
+// last completed epoch, based on the rewards they earned. Unlike the
+// validator client metrics this shows whether attestations were included and
+// correct, not just broadcast.
+func (d *Display) updateEffectivenessView(effectiveness *consensus.AttestationEffectiveness) {
+	if effectiveness == nil {
+		d.effectivenessView.SetText("")
+		d.showEffectiveness = false
+		return
+	}
+
+	var summary strings.Builder
+	total := len(effectiveness.Validators)
+	summary.WriteString(fmt.Sprintf("  [green::b]● Attestation Effectiveness[white] (epoch %d, %d validators)\n\n",
+		effectiveness.Epoch, total))
+
+	if total == 0 {
+		summary.WriteString("  [gray]No active validators in this epoch[-]")
+		d.effectivenessView.SetText(summary.String())
+		d.showEffectiveness = true
+		return
+	}
+
+	votes := []struct {
+		label   string
+		correct int
+	}{
+		{"Head:   ", effectiveness.HeadCorrect},
+		{"Target: ", effectiveness.TargetCorrect},
+		{"Source: ", effectiveness.SourceCorrect},
+	}
+	for _, vote := range votes {
+		percent := float64(vote.correct) / float64(total) * 100
+		summary.WriteString(fmt.Sprintf("  %s[%s]%s[white] %5.1f%% (%d/%d)\n",
+			vote.label, getPercentageColor(percent), createProgressBar(percent), percent, vote.correct, total))
+	}
+
+	percent := effectiveness.Effectiveness()
+	summary.WriteString(fmt.Sprintf("  Reward: [%s]%s[white] %5.1f%% (%s of %s)\n\n",
+		getPercentageColor(percent), createProgressBar(percent), percent,
+		formatGwei(effectiveness.Reward, false), formatGwei(effectiveness.IdealReward, false)))
+
+	// Validators that missed a vote that counts towards finality
+	var missed []string
+	for _, performance := range effectiveness.Validators {
+		switch {
+		case !performance.Source:
+			missed = append(missed, fmt.Sprintf("[red]%d[-]", performance.ValidatorIndex))
+		case !performance.Target:
+			missed = append(missed, fmt.Sprintf("[yellow]%d[-]", performance.ValidatorIndex))
+		}
+	}
+	if len(missed) == 0 {
+		summary.WriteString("  Missed: [green]none[-]")
+	} else {
+		more := ""
+		if len(missed) > maxAttentionValidators {
+			more = fmt.Sprintf(" [gray]+%d more[-]", len(missed)-maxAttentionValidators)
+			missed = missed[:maxAttentionValidators]
+		}
+		summary.WriteString("  Missed: " + strings.Join(missed, ", ") + more)
+	}
+
+	d.effectivenessView.SetText(summary.String())
+	d.showEffectiveness = true
+}
+
+// updateSyncCommitteeView shows a banner while any of our validators is in
+// the current or next sync committee, as the duty is rare and easy to miss.
+// Participation is shown per recent block, oldest first.
+func (d *Display) updateSyncCommitteeView(status *consensus.SyncCommitteeStatus) {
+	if status == nil || (status.Current == nil && status.Next == nil) {
+		d.syncView.SetText("")
+		d.syncLines = 0
+		return
+	}
+
+	var summary strings.Builder
+	lines := 0
+	if current := status.Current; current != nil {
+		summary.WriteString(fmt.Sprintf("  [black:yellow:b] ★ SYNC COMMITTEE [-:-:-] [yellow::b]%d validators (%s) until epoch %d[-:-:-]",
+			len(current.Members), syncMemberList(current.Members), current.EndEpoch))
+		participated, expected := status.ParticipationRate()
+		if expected > 0 {
+			percent := float64(participated) / float64(expected) * 100
+			summary.WriteString(fmt.Sprintf(" Participation: [%s]%d/%d (%.1f%%)[-]",
+				getPercentageColor(percent), participated, expected, percent))
+		}
+		summary.WriteString("\n  Recent blocks: ")
+		if len(status.Participation) == 0 {
+			summary.WriteString("[gray]waiting for blocks[-]")
+		}
+		for _, slot := range status.Participation {
+			switch {
+			case !slot.Block:
+				summary.WriteString("[gray]·[-]")
+			case slot.Participated == slot.Expected:
+				summary.WriteString("[green]█[-]")
+			case slot.Participated > 0:
+				summary.WriteString("[yellow]▓[-]")
+			default:
+				summary.WriteString("[red]░[-]")
+			}
+		}
+		lines += 2
+	}
+	if next := status.Next; next != nil {
+		if lines > 0 {
+			summary.WriteString("\n")
+		}
+		summary.WriteString(fmt.Sprintf("  [black:blue:b] ☆ NEXT SYNC COMMITTEE [-:-:-] [blue::b]%d validators (%s) from epoch %d[-:-:-]",
+			len(next.Members), syncMemberList(next.Members), next.StartEpoch))
+		if next.StartEpoch > status.CurrentEpoch {
+			summary.WriteString(fmt.Sprintf(" in %d epochs", next.StartEpoch-status.CurrentEpoch))
+		}
+		lines++
+	}
+
+	d.syncView.SetText(summary.String())
+	d.syncLines = lines
+}
+
+// updateBalanceView shows the balance and status of our own validators, as
+// reported by the beacon node, along with their balance change per epoch
+func (d *Display) updateBalanceView(balances *consensus.ValidatorBalances) {
+	if balances == nil {
+		d.balanceView.SetText("")
+		d.showBalances = false
+		return
+	}
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("\n  [green]● Validator Balances (%d validators)[-] Total: %s",
+		len(balances.Validators), formatGwei(int64(balances.TotalBalance()), false)))
+	if len(balances.Missing) > 0 {
+		summary.WriteString(fmt.Sprintf(" [yellow]%d not found on node[-]", len(balances.Missing)))
+	}
+	summary.WriteString("\n")
+
+	// Status counts, in lifecycle order
+	counts := balances.StatusCounts()
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return validatorStatusOrder(statuses[i]) < validatorStatusOrder(statuses[j])
+	})
+	parts := make([]string, len(statuses))
+	for i, status := range statuses {
+		parts[i] = fmt.Sprintf("[%s]%d %s[-]", validatorStatusColor(status), counts[status], status)
+	}
+	summary.WriteString("  Status:       " + strings.Join(parts, ", ") + "\n")
+
+	// Balance change per complete epoch
+	summary.WriteString("  Epoch deltas: ")
+	if len(balances.Deltas) == 0 {
+		summary.WriteString("[gray]waiting for a complete epoch[-]")
+	}
+	for i, delta := range balances.Deltas {
+		if i > 0 {
+			summary.WriteString(", ")
+		}
+		color := "green"
+		if delta.Delta < 0 {
+			color = "red"
+		}
+		summary.WriteString(fmt.Sprintf("%d [%s]%s[-]", delta.Epoch, color, formatGwei(delta.Delta, true)))
+	}
+	summary.WriteString("\n")
+
+	// Validators that lost balance or were slashed
+	var attention []string
+	for _, validator := range balances.Validators {
+		switch {
+		case validator.Slashed:
+			attention = append(attention, fmt.Sprintf("[red]%d slashed[-]", validator.Index))
+		case validator.HasDelta && validator.EpochDelta < 0 && strings.HasPrefix(validator.Status, "active"):
+			attention = append(attention, fmt.Sprintf("[yellow]%d %s[-]", validator.Index, formatGwei(validator.EpochDelta, true)))
+		}
+	}
+	if len(attention) == 0 {
+		summary.WriteString("  Attention:    [green]none[-]")
+	} else {
+		more := ""
+		if len(attention) > maxAttentionValidators {
+			more = fmt.Sprintf(" [gray]+%d more[-]", len(attention)-maxAttentionValidators)
+			attention = attention[:maxAttentionValidators]
+		}
+		summary.WriteString("  Attention:    " + strings.Join(attention, ", ") + more)
+	}
+
+	d.balanceView.SetText(summary.String())
+	d.showBalances = true
+}
+
+// formatGwei formats a Gwei amount as ETH
+func formatGwei(gwei int64, signed bool) string {
+	if signed {
+		return fmt.Sprintf("%+.6f ETH", float64(gwei)/1e9)
+	}
+	return fmt.Sprintf("%.4f ETH", float64(gwei)/1e9)
+}
+
+// validatorStatusOrder sorts validator statuses by lifecycle stage
+func validatorStatusOrder(status string) int {
+	order := []string{
+		"pending_initialized", "pending_queued",
+		"active_ongoing", "active_exiting", "active_slashed",
+		"exited_unslashed", "exited_slashed",
+		"withdrawal_possible", "withdrawal_done",
+	}
+	for i, s := range order {
+		if s == status {
+			return i
+		}
+	}
+	return len(order)
+}
+
+func validatorStatusColor(status string) string {
+	switch {
+	case strings.HasSuffix(status, "slashed") && status != "exited_unslashed":
+		return "red"
+	case status == "active_ongoing":
+		return "green"
+	case strings.HasPrefix(status, "pending"), status == "active_exiting":
+		return "yellow"
+	case strings.HasPrefix(status, "withdrawal"):
+		return "blue"
+	default:
+		return "white"
+	}
+}
+
 func getPercentageColor(percentage float64) string {
 	if percentage >= 99 {
 		return "green"
@@ -0,0 +1,225 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// DivergenceKind is the part of the chain on which nodes disagree
+type DivergenceKind string
+
+const (
+	DivergenceHead      DivergenceKind = "head"
+	DivergenceJustified DivergenceKind = "justified"
+	DivergenceFinalized DivergenceKind = "finalized"
+)
+
+// Divergence is a node whose root differs from the other nodes at the same
+// slot (for heads) or epoch (for checkpoints)
+type Divergence struct {
+	Node         string
+	Kind         DivergenceKind
+	Position     uint64 // Slot for heads, epoch for checkpoints
+	Root         string
+	MajorityRoot string // Empty if there is no majority, e.g. a 1:1 split
+}
+
+func (d Divergence) key() string {
+	return fmt.Sprintf("%s/%s", d.Node, d.Kind)
+}
+
+// DivergenceReport holds the result of comparing consensus nodes
+type DivergenceReport struct {
+	Divergences []Divergence
+}
+
+// ForNode returns the divergences of a single node
+func (r *DivergenceReport) ForNode(name string) []Divergence {
+	if r == nil {
+		return nil
+	}
+	var divergences []Divergence
+	for _, d := range r.Divergences {
+		if d.Node == name {
+			divergences = append(divergences, d)
+		}
+	}
+	return divergences
+}
+
+// Has returns true if the node diverges on the given kind
+func (r *DivergenceReport) Has(name string, kind DivergenceKind) bool {
+	for _, d := range r.ForNode(name) {
+		if d.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzeDivergence compares the head, justified and finalized roots of all
+// connected nodes. Nodes are only compared against others at the same slot or
+// epoch, so a node that is simply behind is not reported.
+func analyzeDivergence(infos []*consensus.ConsensusNodeInfo) *DivergenceReport {
+	report := &DivergenceReport{}
+
+	views := map[DivergenceKind]func(*consensus.ConsensusNodeInfo) (uint64, string){
+		DivergenceHead: func(info *consensus.ConsensusNodeInfo) (uint64, string) {
+			return info.HeadSlot, info.HeadRoot
+		},
+		DivergenceJustified: func(info *consensus.ConsensusNodeInfo) (uint64, string) {
+			return info.JustifiedEpoch, info.JustifiedRoot
+		},
+		DivergenceFinalized: func(info *consensus.ConsensusNodeInfo) (uint64, string) {
+			return info.FinalizedEpoch, info.FinalizedRoot
+		},
+	}
+
+	for _, kind := range []DivergenceKind{DivergenceHead, DivergenceJustified, DivergenceFinalized} {
+		// Position -> root -> node names
+		groups := make(map[uint64]map[string][]string)
+		for _, info := range infos {
+			if info == nil || !info.IsConnected {
+				continue
+			}
+			position, root := views[kind](info)
+			if root == "" {
+				continue
+			}
+			if groups[position] == nil {
+				groups[position] = make(map[string][]string)
+			}
+			groups[position][root] = append(groups[position][root], info.Name)
+		}
+
+		positions := make([]uint64, 0, len(groups))
+		for position := range groups {
+			positions = append(positions, position)
+		}
+		sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+
+		for _, position := range positions {
+			roots := groups[position]
+			if len(roots) < 2 {
+				continue
+			}
+
+			majority := majorityRoot(roots)
+			for root, nodes := range roots {
+				if root == majority {
+					continue
+				}
+				for _, node := range nodes {
+					report.Divergences = append(report.Divergences, Divergence{
+						Node:         node,
+						Kind:         kind,
+						Position:     position,
+						Root:         root,
+						MajorityRoot: majority,
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(report.Divergences, func(i, j int) bool {
+		return report.Divergences[i].Node < report.Divergences[j].Node
+	})
+	return report
+}
+
+// majorityRoot returns the root shared by the most nodes, or an empty string
+// if the largest groups are tied
+func majorityRoot(roots map[string][]string) string {
+	var majority string
+	best, tied := 0, false
+	for root, nodes := range roots {
+		switch {
+		case len(nodes) > best:
+			majority, best, tied = root, len(nodes), false
+		case len(nodes) == best:
+			tied = true
+		}
+	}
+	if tied {
+		return ""
+	}
+	return majority
+}
+
+// divergenceEvents compares the current report with the set of divergences
+// that were active before and returns events, ordered by node, for nodes that
+// moved onto or back off a minority fork. Divergences of nodes that are not
+// connected are kept without an event. The active set is updated in place.
+func divergenceEvents(report *DivergenceReport, infos []*consensus.ConsensusNodeInfo, active map[string]Divergence) []Event {
+	var events []Event
+
+	connected := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		if info != nil && info.IsConnected {
+			connected[info.Name] = true
+		}
+	}
+
+	current := make(map[string]Divergence, len(report.Divergences))
+	for _, d := range report.Divergences {
+		current[d.key()] = d
+		if _, ok := active[d.key()]; ok {
+			continue
+		}
+
+		severity := EventWarning
+		if d.Kind == DivergenceFinalized {
+			severity = EventCritical
+		}
+		var message string
+		if d.MajorityRoot == "" {
+			message = fmt.Sprintf("No majority for %s at %d, node has %s", d.Kind, d.Position, consensus.ShortRoot(d.Root))
+		} else {
+			message = fmt.Sprintf("On minority fork: %s at %d is %s, majority has %s",
+				d.Kind, d.Position, consensus.ShortRoot(d.Root), consensus.ShortRoot(d.MajorityRoot))
+		}
+		events = append(events, Event{Node: d.Node, Severity: severity, Message: message})
+	}
+
+	for key, d := range active {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		if !connected[d.Node] {
+			// Nothing is known about the node's chain until it is back
+			current[key] = d
+			continue
+		}
+		events = append(events, Event{
+			Node:     d.Node,
+			Severity: EventInfo,
+			Message:  fmt.Sprintf("Back in agreement on %s", d.Kind),
+		})
+	}
+
+	for key := range active {
+		delete(active, key)
+	}
+	for key, d := range current {
+		active[key] = d
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Node < events[j].Node })
+	return events
+}
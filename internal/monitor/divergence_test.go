@@ -0,0 +1,218 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+func nodeAt(name string, headSlot uint64, headRoot string, finalizedEpoch uint64, finalizedRoot string) *consensus.ConsensusNodeInfo {
+	return &consensus.ConsensusNodeInfo{
+		Name:           name,
+		IsConnected:    true,
+		HeadSlot:       headSlot,
+		HeadRoot:       headRoot,
+		JustifiedEpoch: finalizedEpoch + 1,
+		JustifiedRoot:  finalizedRoot + "-j",
+		FinalizedEpoch: finalizedEpoch,
+		FinalizedRoot:  finalizedRoot,
+	}
+}
+
+func TestAnalyzeDivergence(t *testing.T) {
+	tests := []struct {
+		name     string
+		infos    []*consensus.ConsensusNodeInfo
+		expected []Divergence
+	}{
+		{
+			name: "all nodes agree",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeAt("a", 100, "0x1", 2, "0xf"),
+				nodeAt("b", 100, "0x1", 2, "0xf"),
+			},
+		},
+		{
+			name: "lagging node is not a divergence",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeAt("a", 100, "0x1", 2, "0xf"),
+				nodeAt("b", 99, "0x0", 1, "0xe"),
+			},
+		},
+		{
+			name: "minority head",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeAt("a", 100, "0x1", 2, "0xf"),
+				nodeAt("b", 100, "0x1", 2, "0xf"),
+				nodeAt("c", 100, "0x2", 2, "0xf"),
+			},
+			expected: []Divergence{
+				{Node: "c", Kind: DivergenceHead, Position: 100, Root: "0x2", MajorityRoot: "0x1"},
+			},
+		},
+		{
+			name: "split without majority flags both sides",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeAt("a", 100, "0x1", 2, "0xf"),
+				nodeAt("b", 100, "0x2", 2, "0xf"),
+			},
+			expected: []Divergence{
+				{Node: "a", Kind: DivergenceHead, Position: 100, Root: "0x1"},
+				{Node: "b", Kind: DivergenceHead, Position: 100, Root: "0x2"},
+			},
+		},
+		{
+			name: "minority finalized checkpoint",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeAt("a", 100, "0x1", 2, "0xf"),
+				nodeAt("b", 100, "0x1", 2, "0xf"),
+				nodeAt("c", 100, "0x1", 2, "0xe"),
+			},
+			expected: []Divergence{
+				{Node: "c", Kind: DivergenceJustified, Position: 3, Root: "0xe-j", MajorityRoot: "0xf-j"},
+				{Node: "c", Kind: DivergenceFinalized, Position: 2, Root: "0xe", MajorityRoot: "0xf"},
+			},
+		},
+		{
+			name: "disconnected nodes are ignored",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeAt("a", 100, "0x1", 2, "0xf"),
+				{Name: "b", HeadSlot: 100, HeadRoot: "0x2"},
+				nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := analyzeDivergence(tt.infos)
+			assert.ElementsMatch(t, tt.expected, report.Divergences)
+		})
+	}
+}
+
+func TestDivergenceEvents(t *testing.T) {
+	active := make(map[string]Divergence)
+	infos := []*consensus.ConsensusNodeInfo{
+		{Name: "a", IsConnected: true},
+		{Name: "b", IsConnected: true},
+		{Name: "c", IsConnected: true},
+	}
+
+	diverged := &DivergenceReport{Divergences: []Divergence{
+		{Node: "c", Kind: DivergenceHead, Position: 100, Root: "0x2", MajorityRoot: "0x1"},
+		{Node: "c", Kind: DivergenceFinalized, Position: 2, Root: "0xe", MajorityRoot: "0xf"},
+	}}
+	events := divergenceEvents(diverged, infos, active)
+	require.Len(t, events, 2)
+	severities := []EventSeverity{events[0].Severity, events[1].Severity}
+	assert.ElementsMatch(t, []EventSeverity{EventWarning, EventCritical}, severities)
+
+	// Ongoing divergences do not repeat events, even as the slot moves on
+	diverged.Divergences[0].Position = 101
+	assert.Empty(t, divergenceEvents(diverged, infos, active))
+
+	// Resolution is reported once per kind
+	events = divergenceEvents(&DivergenceReport{}, infos, active)
+	require.Len(t, events, 2)
+	for _, event := range events {
+		assert.Equal(t, "c", event.Node)
+		assert.Equal(t, EventInfo, event.Severity)
+	}
+	assert.Empty(t, active)
+}
+
+func TestDivergenceEvents_Disconnected(t *testing.T) {
+	active := make(map[string]Divergence)
+	infos := []*consensus.ConsensusNodeInfo{
+		{Name: "a", IsConnected: true},
+		{Name: "b", IsConnected: true},
+		{Name: "c", IsConnected: true},
+	}
+	diverged := &DivergenceReport{Divergences: []Divergence{
+		{Node: "c", Kind: DivergenceHead, Position: 100, Root: "0x2", MajorityRoot: "0x1"},
+	}}
+	require.Len(t, divergenceEvents(diverged, infos, active), 1)
+
+	// A node that disconnects is not back in agreement
+	infos[2] = &consensus.ConsensusNodeInfo{Name: "c"}
+	assert.Empty(t, divergenceEvents(&DivergenceReport{}, infos, active))
+	assert.Contains(t, active, "c/head")
+
+	// Nor is its divergence raised again when it reconnects still diverged
+	infos[2] = &consensus.ConsensusNodeInfo{Name: "c", IsConnected: true}
+	assert.Empty(t, divergenceEvents(diverged, infos, active))
+
+	events := divergenceEvents(&DivergenceReport{}, infos, active)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInfo, events[0].Severity)
+}
+
+func TestDivergenceEvents_Order(t *testing.T) {
+	infos := []*consensus.ConsensusNodeInfo{
+		{Name: "a", IsConnected: true},
+		{Name: "b", IsConnected: true},
+		{Name: "c", IsConnected: true},
+		{Name: "d", IsConnected: true},
+	}
+	active := map[string]Divergence{
+		"d/head": {Node: "d", Kind: DivergenceHead},
+		"b/head": {Node: "b", Kind: DivergenceHead},
+	}
+	diverged := &DivergenceReport{Divergences: []Divergence{
+		{Node: "c", Kind: DivergenceHead, Position: 100, Root: "0x2"},
+		{Node: "a", Kind: DivergenceHead, Position: 100, Root: "0x3"},
+	}}
+
+	events := divergenceEvents(diverged, infos, active)
+	nodes := make([]string, 0, len(events))
+	for _, event := range events {
+		nodes = append(nodes, event.Node)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, nodes)
+}
+
+func TestMonitor_DivergenceEvents(t *testing.T) {
+	m := NewMonitor(time.Second)
+	m.AddConsensusClient(&mockConsensusClient{name: "a", nodeInfo: nodeAt("a", 100, "0x1", 2, "0xf")})
+	m.AddConsensusClient(&mockConsensusClient{name: "b", nodeInfo: nodeAt("b", 100, "0x1", 2, "0xf")})
+	forked := &mockConsensusClient{name: "c", nodeInfo: nodeAt("c", 100, "0x2", 2, "0xf")}
+	m.AddConsensusClient(forked)
+
+	m.updateAll(context.Background())
+	update := m.GetNodeInfos()
+	assert.True(t, update.Divergence.Has("c", DivergenceHead))
+	assert.False(t, update.Divergence.Has("a", DivergenceHead))
+	require.Len(t, update.Events, 1)
+	assert.Equal(t, "c", update.Events[0].Node)
+	assert.Equal(t, EventWarning, update.Events[0].Severity)
+
+	// Polling again with the same state does not add events
+	m.updateAll(context.Background())
+	assert.Len(t, m.GetNodeInfos().Events, 1)
+
+	// Node catches up with the majority
+	forked.nodeInfo = nodeAt("c", 100, "0x1", 2, "0xf")
+	m.updateAll(context.Background())
+	update = m.GetNodeInfos()
+	assert.Empty(t, update.Divergence.Divergences)
+	require.Len(t, update.Events, 2)
+	assert.Equal(t, EventInfo, update.Events[1].Severity)
+}
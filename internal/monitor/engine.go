@@ -0,0 +1,72 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/watcheth/watcheth/internal/execution"
+)
+
+// engineIssue describes what is wrong with a node's engine API, and how
+// badly, or returns an empty string if nothing is. A node that answers and
+// accepts the JWT, but whose capabilities could not be checked, is a warning,
+// as is a JWT secret that cannot be read, which leaves the node unchecked.
+func engineIssue(engine *execution.EngineStatus) (string, EventSeverity) {
+	switch {
+	case engine.SecretError != nil:
+		return fmt.Sprintf("JWT secret unreadable: %v", engine.SecretError), EventWarning
+	case !engine.Reachable:
+		return "Engine API unreachable", EventCritical
+	case !engine.Authenticated:
+		return "Engine API rejected the JWT", EventCritical
+	case engine.Error != nil:
+		return fmt.Sprintf("Engine API check failed: %v", engine.Error), EventWarning
+	case !engine.Capable:
+		return "Engine API missing " + strings.Join(engine.Missing, ", "), EventCritical
+	}
+	return "", EventInfo
+}
+
+// engineEvents raises an event when the engine API of a node, which the
+// consensus client drives the node through, breaks or recovers. Issues holds
+// the last issue raised for each node.
+func engineEvents(infos []*execution.ExecutionNodeInfo, issues map[string]string) []Event {
+	var events []Event
+	for _, info := range infos {
+		if info == nil || info.Engine == nil {
+			continue
+		}
+		issue, severity := engineIssue(info.Engine)
+		previous, raised := issues[info.Name]
+		switch {
+		case issue != "" && (!raised || previous != issue):
+			issues[info.Name] = issue
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: severity,
+				Message:  issue,
+			})
+		case issue == "" && raised:
+			delete(issues, info.Name)
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: EventInfo,
+				Message:  "Engine API is healthy again",
+			})
+		}
+	}
+	return events
+}
@@ -0,0 +1,74 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/execution"
+)
+
+func TestEngineEvents(t *testing.T) {
+	issues := make(map[string]string)
+	healthy := &execution.EngineStatus{Reachable: true, Authenticated: true, Capable: true}
+	infos := func(engine *execution.EngineStatus) []*execution.ExecutionNodeInfo {
+		return []*execution.ExecutionNodeInfo{
+			{Name: "geth", Engine: engine},
+			{Name: "reth"}, // Engine API not checked
+			nil,
+		}
+	}
+
+	assert.Empty(t, engineEvents(infos(healthy), issues))
+
+	events := engineEvents(infos(&execution.EngineStatus{Reachable: true}), issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, "geth", events[0].Node)
+	assert.Equal(t, EventCritical, events[0].Severity)
+	assert.Equal(t, "Engine API rejected the JWT", events[0].Message)
+
+	// Only reported once, and again when the issue changes
+	assert.Empty(t, engineEvents(infos(&execution.EngineStatus{Reachable: true}), issues))
+	events = engineEvents(infos(&execution.EngineStatus{}), issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Engine API unreachable", events[0].Message)
+
+	missing := &execution.EngineStatus{Reachable: true, Authenticated: true, Missing: []string{"engine_getPayload"}}
+	events = engineEvents(infos(missing), issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Engine API missing engine_getPayload", events[0].Message)
+
+	// Answering, but the capabilities could not be checked
+	failed := &execution.EngineStatus{Reachable: true, Authenticated: true, Error: errors.New("rpc error -32601: Method not found")}
+	events = engineEvents(infos(failed), issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "Engine API check failed: rpc error -32601: Method not found", events[0].Message)
+	assert.Empty(t, engineEvents(infos(failed), issues))
+
+	// Our own configuration, not the node, is at fault
+	unread := &execution.EngineStatus{SecretError: errors.New("no jwt_secret_path configured")}
+	events = engineEvents(infos(unread), issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "JWT secret unreadable: no jwt_secret_path configured", events[0].Message)
+
+	events = engineEvents(infos(healthy), issues)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInfo, events[0].Severity)
+	assert.Empty(t, issues)
+}
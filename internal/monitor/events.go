@@ -0,0 +1,70 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"time"
+
+	"github.com/watcheth/watcheth/internal/logger"
+)
+
+// Number of events kept for display
+const maxEvents = 50
+
+type EventSeverity int
+
+const (
+	EventInfo EventSeverity = iota
+	EventWarning
+	EventCritical
+)
+
+// Event is a notable change detected by the monitor, such as a node moving
+// onto a minority fork
+type Event struct {
+	Time     time.Time
+	Node     string
+	Severity EventSeverity
+	Message  string
+}
+
+// eventLog is a bounded history of events, oldest first
+type eventLog struct {
+	events []Event
+}
+
+func (l *eventLog) add(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	switch event.Severity {
+	case EventInfo:
+		logger.Info("[%s]: %s", event.Node, event.Message)
+	default:
+		logger.Warn("[%s]: %s", event.Node, event.Message)
+	}
+
+	l.events = append(l.events, event)
+	if len(l.events) > maxEvents {
+		l.events = l.events[len(l.events)-maxEvents:]
+	}
+}
+
+// list returns a copy of the events, oldest first
+func (l *eventLog) list() []Event {
+	events := make([]Event, len(l.events))
+	copy(events, l.events)
+	return events
+}
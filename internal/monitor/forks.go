@@ -0,0 +1,143 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// ForkReport holds the result of comparing the fork schedules of consensus
+// nodes
+type ForkReport struct {
+	Next   *consensus.ScheduledFork // Next fork in the majority schedule, nil if none
+	Issues map[string]string        // Per node, how its schedule differs from the majority
+}
+
+// Issue returns how the node's fork schedule differs from the other nodes, or
+// an empty string if it agrees
+func (r *ForkReport) Issue(node string) string {
+	if r == nil {
+		return ""
+	}
+	return r.Issues[node]
+}
+
+// forkScheduleKey summarises a fork schedule for comparison
+func forkScheduleKey(forks []consensus.ScheduledFork) string {
+	parts := make([]string, len(forks))
+	for i, fork := range forks {
+		parts[i] = fmt.Sprintf("%s@%d", strings.ToLower(fork.Version), fork.Epoch)
+	}
+	return strings.Join(parts, ",")
+}
+
+// analyzeForks compares the fork schedules of connected nodes. A node that
+// has no upcoming fork while the majority does is most likely running a
+// release from before the fork was scheduled.
+func analyzeForks(infos []*consensus.ConsensusNodeInfo) *ForkReport {
+	report := &ForkReport{Issues: make(map[string]string)}
+
+	schedules := make(map[string][]string)
+	byKey := make(map[string]*consensus.ConsensusNodeInfo)
+	for _, info := range infos {
+		if info == nil || !info.IsConnected || len(info.ForkSchedule) == 0 {
+			continue
+		}
+		key := forkScheduleKey(info.ForkSchedule)
+		schedules[key] = append(schedules[key], info.Name)
+		byKey[key] = info
+	}
+	if len(schedules) == 0 {
+		return report
+	}
+
+	majority := majorityRoot(schedules)
+	if majority != "" {
+		report.Next = byKey[majority].NextFork
+	}
+	if len(schedules) == 1 {
+		return report
+	}
+
+	for key, nodes := range schedules {
+		if key == majority {
+			continue
+		}
+		info := byKey[key]
+		issue := "Fork schedule differs from the other nodes"
+		switch {
+		case majority == "":
+			issue = "No majority fork schedule across nodes"
+		case report.Next != nil && info.NextFork == nil:
+			issue = fmt.Sprintf("No upcoming fork, others have %s at epoch %d; outdated release?",
+				report.Next.DisplayName(), report.Next.Epoch)
+		case report.Next != nil && info.NextFork != nil && info.NextFork.Epoch != report.Next.Epoch:
+			issue = fmt.Sprintf("Next fork %s at epoch %d, others have epoch %d",
+				info.NextFork.DisplayName(), info.NextFork.Epoch, report.Next.Epoch)
+		}
+		for _, node := range nodes {
+			report.Issues[node] = issue
+		}
+	}
+	return report
+}
+
+// forkEvents compares the current report with the issues that were active
+// before and returns events, ordered by node, for nodes whose fork schedule
+// started or stopped disagreeing. Issues of nodes that were not compared,
+// being disconnected or without a schedule, are kept without an event. The
+// active set is updated in place.
+func forkEvents(report *ForkReport, infos []*consensus.ConsensusNodeInfo, active map[string]string) []Event {
+	var events []Event
+
+	compared := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		if info != nil && info.IsConnected && len(info.ForkSchedule) > 0 {
+			compared[info.Name] = true
+		}
+	}
+
+	current := make(map[string]string, len(report.Issues))
+	for node, issue := range report.Issues {
+		current[node] = issue
+		if active[node] == issue {
+			continue
+		}
+		events = append(events, Event{Node: node, Severity: EventCritical, Message: issue})
+	}
+	for node, issue := range active {
+		if _, ok := current[node]; ok {
+			continue
+		}
+		if !compared[node] {
+			current[node] = issue
+			continue
+		}
+		events = append(events, Event{Node: node, Severity: EventInfo, Message: "Fork schedule agrees with the other nodes"})
+	}
+
+	for node := range active {
+		delete(active, node)
+	}
+	for node, issue := range current {
+		active[node] = issue
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Node < events[j].Node })
+	return events
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+func nodeWithForks(name string, forks ...consensus.ScheduledFork) *consensus.ConsensusNodeInfo {
+	info := &consensus.ConsensusNodeInfo{Name: name, IsConnected: true, CurrentEpoch: 100, ForkSchedule: forks}
+	for i := range forks {
+		if forks[i].Epoch > info.CurrentEpoch {
+			info.NextFork = &forks[i]
+			break
+		}
+	}
+	return info
+}
+
+func TestAnalyzeForks(t *testing.T) {
+	electra := consensus.ScheduledFork{Name: "Electra", Version: "0x05000000", Epoch: 50}
+	fulu := consensus.ScheduledFork{Name: "Fulu", Version: "0x06000000", Epoch: 200}
+	fuluLater := consensus.ScheduledFork{Name: "Fulu", Version: "0x06000000", Epoch: 300}
+
+	tests := []struct {
+		name     string
+		infos    []*consensus.ConsensusNodeInfo
+		next     *consensus.ScheduledFork
+		expected map[string]string
+	}{
+		{
+			name: "all agree",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithForks("a", electra, fulu),
+				nodeWithForks("b", electra, fulu),
+			},
+			next:     &fulu,
+			expected: map[string]string{},
+		},
+		{
+			name: "outdated release",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithForks("a", electra, fulu),
+				nodeWithForks("b", electra, fulu),
+				nodeWithForks("c", electra),
+			},
+			next:     &fulu,
+			expected: map[string]string{"c": "No upcoming fork, others have Fulu at epoch 200; outdated release?"},
+		},
+		{
+			name: "different epoch",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithForks("a", electra, fulu),
+				nodeWithForks("b", electra, fulu),
+				nodeWithForks("c", electra, fuluLater),
+			},
+			next:     &fulu,
+			expected: map[string]string{"c": "Next fork Fulu at epoch 300, others have epoch 200"},
+		},
+		{
+			name: "no majority",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithForks("a", electra, fulu),
+				nodeWithForks("b", electra),
+			},
+			expected: map[string]string{
+				"a": "No majority fork schedule across nodes",
+				"b": "No majority fork schedule across nodes",
+			},
+		},
+		{
+			name: "disconnected nodes are ignored",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithForks("a", electra, fulu),
+				{Name: "b"},
+				nil,
+			},
+			next:     &fulu,
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := analyzeForks(tt.infos)
+			assert.Equal(t, tt.expected, report.Issues)
+			assert.Equal(t, tt.next, report.Next)
+		})
+	}
+}
+
+func TestForkEvents(t *testing.T) {
+	active := make(map[string]string)
+	electra := consensus.ScheduledFork{Name: "Electra", Version: "0x05000000", Epoch: 50}
+	infos := []*consensus.ConsensusNodeInfo{
+		nodeWithForks("a", electra),
+		nodeWithForks("b", electra),
+		nodeWithForks("c", electra),
+	}
+
+	report := &ForkReport{Issues: map[string]string{"c": "Fork schedule differs from the other nodes"}}
+	events := forkEvents(report, infos, active)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventCritical, events[0].Severity)
+	assert.Equal(t, "c", events[0].Node)
+
+	// Ongoing issues are not reported again
+	assert.Empty(t, forkEvents(report, infos, active))
+
+	// Nor resolved by the node going offline or its schedule failing
+	infos[2] = &consensus.ConsensusNodeInfo{Name: "c"}
+	assert.Empty(t, forkEvents(&ForkReport{Issues: map[string]string{}}, infos, active))
+	infos[2] = &consensus.ConsensusNodeInfo{Name: "c", IsConnected: true}
+	assert.Empty(t, forkEvents(&ForkReport{Issues: map[string]string{}}, infos, active))
+	assert.Contains(t, active, "c")
+
+	infos[2] = nodeWithForks("c", electra)
+	events = forkEvents(&ForkReport{Issues: map[string]string{}}, infos, active)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInfo, events[0].Severity)
+	assert.Empty(t, active)
+
+	var nilReport *ForkReport
+	assert.Equal(t, "", nilReport.Issue("c"))
+}
+
+func TestForkEvents_Order(t *testing.T) {
+	electra := consensus.ScheduledFork{Name: "Electra", Version: "0x05000000", Epoch: 50}
+	infos := []*consensus.ConsensusNodeInfo{
+		nodeWithForks("a", electra),
+		nodeWithForks("b", electra),
+		nodeWithForks("c", electra),
+		nodeWithForks("d", electra),
+	}
+	active := map[string]string{"b": "No majority fork schedule across nodes", "d": "No majority fork schedule across nodes"}
+	report := &ForkReport{Issues: map[string]string{
+		"c": "Fork schedule differs from the other nodes",
+		"a": "Fork schedule differs from the other nodes",
+	}}
+
+	events := forkEvents(report, infos, active)
+	nodes := make([]string, 0, len(events))
+	for _, event := range events {
+		nodes = append(nodes, event.Node)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, nodes)
+}
@@ -0,0 +1,51 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// privateAddressEvents returns a warning for nodes that start advertising a
+// private address, which other peers cannot dial. Warned nodes are tracked in
+// warned until their addresses are public again.
+func privateAddressEvents(infos []*consensus.ConsensusNodeInfo, warned map[string]bool) []Event {
+	var events []Event
+	for _, info := range infos {
+		if info == nil || !info.IsConnected || info.Identity == nil {
+			continue
+		}
+		private := info.Identity.PrivateAddresses
+		switch {
+		case len(private) > 0 && !warned[info.Name]:
+			warned[info.Name] = true
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: EventWarning,
+				Message:  fmt.Sprintf("Advertising private address %s", strings.Join(private, ", ")),
+			})
+		case len(private) == 0 && warned[info.Name]:
+			delete(warned, info.Name)
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: EventInfo,
+				Message:  "Advertised addresses are public again",
+			})
+		}
+	}
+	return events
+}
@@ -0,0 +1,55 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+func TestPrivateAddressEvents(t *testing.T) {
+	warned := make(map[string]bool)
+	private := &consensus.ConsensusNodeInfo{
+		Name:        "lighthouse",
+		IsConnected: true,
+		Identity:    &consensus.NodeIdentity{PrivateAddresses: []string{"/ip4/10.0.0.5/tcp/9000"}},
+	}
+	public := &consensus.ConsensusNodeInfo{
+		Name:        "teku",
+		IsConnected: true,
+		Identity:    &consensus.NodeIdentity{P2PAddresses: []string{"/ip4/203.0.113.7/tcp/9000"}},
+	}
+
+	events := privateAddressEvents([]*consensus.ConsensusNodeInfo{private, public, nil}, warned)
+	require.Len(t, events, 1)
+	assert.Equal(t, "lighthouse", events[0].Node)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "Advertising private address /ip4/10.0.0.5/tcp/9000", events[0].Message)
+
+	// Only reported once
+	assert.Empty(t, privateAddressEvents([]*consensus.ConsensusNodeInfo{private, public}, warned))
+
+	// Nodes without an identity keep their state
+	offline := &consensus.ConsensusNodeInfo{Name: "lighthouse"}
+	assert.Empty(t, privateAddressEvents([]*consensus.ConsensusNodeInfo{offline}, warned))
+
+	fixed := &consensus.ConsensusNodeInfo{Name: "lighthouse", IsConnected: true, Identity: &consensus.NodeIdentity{}}
+	events = privateAddressEvents([]*consensus.ConsensusNodeInfo{fixed}, warned)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInfo, events[0].Severity)
+	assert.Empty(t, warned)
+}
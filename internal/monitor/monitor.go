@@ -20,13 +20,28 @@ import (
 
 	"github.com/watcheth/watcheth/internal/consensus"
 	"github.com/watcheth/watcheth/internal/execution"
+	"github.com/watcheth/watcheth/internal/logger"
 	"github.com/watcheth/watcheth/internal/validator"
 )
 
+// streamingPollInterval is how often a consensus client with a connected
+// event stream is still polled, to refresh data the stream does not carry
+const streamingPollInterval = 12 * time.Second
+
 type NodeUpdate struct {
 	ConsensusInfos []*consensus.ConsensusNodeInfo
 	ExecutionInfos []*execution.ExecutionNodeInfo
 	ValidatorInfos []*validator.ValidatorNodeInfo
+	Divergence     *DivergenceReport
+	Forks          *ForkReport
+	Specs          *SpecReport
+	Checkpoints    *CheckpointReport // Nil if no checkpoint providers are configured
+	Slots          *SlotReport
+	Duties         *consensus.ValidatorDuties
+	Balances       *consensus.ValidatorBalances
+	Effectiveness  *consensus.AttestationEffectiveness
+	SyncCommittees *consensus.SyncCommitteeStatus
+	Events         []Event
 }
 
 type Monitor struct {
@@ -35,9 +50,35 @@ type Monitor struct {
 	validatorClients []validator.Client
 	refreshInterval  time.Duration
 
-	consensusInfos []*consensus.ConsensusNodeInfo
-	executionInfos []*execution.ExecutionNodeInfo
-	validatorInfos []*validator.ValidatorNodeInfo
+	consensusInfos    []*consensus.ConsensusNodeInfo
+	consensusLastPoll []time.Time
+	executionInfos    []*execution.ExecutionNodeInfo
+	validatorInfos    []*validator.ValidatorNodeInfo
+
+	divergence        *DivergenceReport
+	forks             *ForkReport
+	specs             *SpecReport
+	slots             *SlotReport
+	duties            *consensus.ValidatorDuties
+	balances          *consensus.ValidatorBalances
+	effectiveness     *consensus.AttestationEffectiveness
+	syncCommittees    *consensus.SyncCommitteeStatus
+	activeDivergences map[string]Divergence
+	privateAddresses  map[string]bool // Nodes warned about advertising a private address
+	forkIssues        map[string]string
+	specIssues        map[string]string
+	slashings         map[string]bool // Slashings, per node, seen in the node's pool
+	missingBlobs      map[string]bool // Blocks, per node, warned about missing blobs
+	lateArrivals      map[string]bool // Nodes warned about heads arriving late
+	checkpointIssues  map[string]bool // Nodes, per provider, whose checkpoint disagrees
+	engineIssues      map[string]string
+	blockGaps         map[string]execution.BlockGap // Last skipped blocks reported per node
+	events            eventLog
+
+	checkpointProviders []checkpointProvider
+	checkpoints         *CheckpointReport
+	checkpointFetched   time.Time // When the providers were last asked
+	checkpointRunning   bool      // Whether a check is running in the background
 
 	mu         sync.RWMutex
 	updateChan chan NodeUpdate
@@ -45,14 +86,27 @@ type Monitor struct {
 
 func NewMonitor(refreshInterval time.Duration) *Monitor {
 	return &Monitor{
-		consensusClients: make([]consensus.Client, 0),
-		executionClients: make([]execution.Client, 0),
-		validatorClients: make([]validator.Client, 0),
-		refreshInterval:  refreshInterval,
-		consensusInfos:   make([]*consensus.ConsensusNodeInfo, 0),
-		executionInfos:   make([]*execution.ExecutionNodeInfo, 0),
-		validatorInfos:   make([]*validator.ValidatorNodeInfo, 0),
-		updateChan:       make(chan NodeUpdate, 1),
+		consensusClients:  make([]consensus.Client, 0),
+		executionClients:  make([]execution.Client, 0),
+		validatorClients:  make([]validator.Client, 0),
+		refreshInterval:   refreshInterval,
+		consensusInfos:    make([]*consensus.ConsensusNodeInfo, 0),
+		consensusLastPoll: make([]time.Time, 0),
+		executionInfos:    make([]*execution.ExecutionNodeInfo, 0),
+		validatorInfos:    make([]*validator.ValidatorNodeInfo, 0),
+		divergence:        &DivergenceReport{},
+		slots:             &SlotReport{},
+		activeDivergences: make(map[string]Divergence),
+		privateAddresses:  make(map[string]bool),
+		forkIssues:        make(map[string]string),
+		specIssues:        make(map[string]string),
+		slashings:         make(map[string]bool),
+		missingBlobs:      make(map[string]bool),
+		lateArrivals:      make(map[string]bool),
+		checkpointIssues:  make(map[string]bool),
+		engineIssues:      make(map[string]string),
+		blockGaps:         make(map[string]execution.BlockGap),
+		updateChan:        make(chan NodeUpdate, 1),
 	}
 }
 
@@ -61,6 +115,7 @@ func (m *Monitor) AddConsensusClient(client consensus.Client) {
 	defer m.mu.Unlock()
 	m.consensusClients = append(m.consensusClients, client)
 	m.consensusInfos = append(m.consensusInfos, &consensus.ConsensusNodeInfo{})
+	m.consensusLastPoll = append(m.consensusLastPoll, time.Time{})
 }
 
 func (m *Monitor) AddExecutionClient(client execution.Client) {
@@ -84,6 +139,9 @@ func (m *Monitor) Start(ctx context.Context) {
 	// Initial update
 	m.updateAll(ctx)
 
+	// Event streams build on the polled state, so start them afterwards
+	m.startEventStreams(ctx)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -114,11 +172,39 @@ func (m *Monitor) updateAll(ctx context.Context) {
 	copy(executionClients, m.executionClients)
 	validatorClients := make([]validator.Client, len(m.validatorClients))
 	copy(validatorClients, m.validatorClients)
+	skipConsensus := make([]bool, len(consensusClients))
+	for i := range consensusClients {
+		skipConsensus[i] = m.streamingLocked(i)
+	}
+	validatorSource := m.validatorSourceLocked()
 	m.mu.RUnlock()
 
+	// Look up our own validators on a single node
+	var duties *consensus.ValidatorDuties
+	if provider, ok := validatorSource.(consensus.DutyProvider); ok {
+		lookupValidators(ctx, &wg, "validator duties", provider.GetDuties, &duties)
+	}
+	var balances *consensus.ValidatorBalances
+	if provider, ok := validatorSource.(consensus.BalanceProvider); ok {
+		lookupValidators(ctx, &wg, "validator balances", provider.GetValidatorBalances, &balances)
+	}
+	var effectiveness *consensus.AttestationEffectiveness
+	if provider, ok := validatorSource.(consensus.EffectivenessProvider); ok {
+		lookupValidators(ctx, &wg, "attestation effectiveness", provider.GetAttestationEffectiveness, &effectiveness)
+	}
+	var syncCommittees *consensus.SyncCommitteeStatus
+	if provider, ok := validatorSource.(consensus.SyncCommitteeProvider); ok {
+		lookupValidators(ctx, &wg, "sync committees", provider.GetSyncCommittees, &syncCommittees)
+	}
+
 	// Update consensus clients
 	consensusResults := make([]*consensus.ConsensusNodeInfo, len(consensusClients))
 	for i, client := range consensusClients {
+		// Clients with a live event stream are only polled occasionally
+		if skipConsensus[i] {
+			continue
+		}
+
 		wg.Add(1)
 		go func(idx int, c consensus.Client) {
 			defer wg.Done()
@@ -191,16 +277,258 @@ func (m *Monitor) updateAll(ctx context.Context) {
 
 	wg.Wait()
 
+	now := time.Now()
 	m.mu.Lock()
+	for i, info := range consensusResults {
+		if i >= len(m.consensusInfos) {
+			break
+		}
+		if info == nil {
+			// Not polled this round, keep whatever the event stream last pushed
+			consensusResults[i] = m.consensusInfos[i]
+		} else {
+			m.consensusLastPoll[i] = now
+		}
+	}
 	m.consensusInfos = consensusResults
 	m.executionInfos = executionResults
 	m.validatorInfos = validatorResults
+	// Keep the last known validator state if a lookup failed
+	if duties != nil {
+		m.duties = duties
+	}
+	if balances != nil {
+		m.balances = balances
+	}
+	if effectiveness != nil {
+		m.effectiveness = effectiveness
+	}
+	if syncCommittees != nil {
+		for _, event := range syncCommitteeEvents(m.syncCommittees, syncCommittees) {
+			m.events.add(event)
+		}
+		m.syncCommittees = syncCommittees
+	}
+	m.analyzeLocked()
+	update := m.updateLocked()
 	m.mu.Unlock()
 
-	update := NodeUpdate{
-		ConsensusInfos: consensusResults,
-		ExecutionInfos: executionResults,
-		ValidatorInfos: validatorResults,
+	m.publish(update)
+	m.startCheckpointCheck(ctx, consensusClients, consensusResults)
+}
+
+// lookupValidators runs a lookup of our own validators alongside the polls
+// of the nodes, storing what it finds in result. Result is left nil if the
+// lookup fails, so that the last known state is kept.
+func lookupValidators[T any](ctx context.Context, wg *sync.WaitGroup, what string, lookup func(context.Context) (*T, error), result **T) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		updateCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		value, err := lookup(updateCtx)
+		if err != nil {
+			logger.Warn("Failed to get %s: %v", what, err)
+			return
+		}
+		*result = value
+	}()
+}
+
+// analyzeLocked compares the latest node infos across nodes and records
+// events for any changes. Caller must hold m.mu.
+func (m *Monitor) analyzeLocked() {
+	m.specs = AnalyzeSpecs(m.consensusInfos)
+	for _, event := range specEvents(m.specs, m.specIssues) {
+		m.events.add(event)
+	}
+
+	// Nodes on another network are not compared with the rest
+	infos := m.specs.sameNetwork(m.consensusInfos)
+	m.divergence = analyzeDivergence(infos)
+	for _, event := range divergenceEvents(m.divergence, infos, m.activeDivergences) {
+		m.events.add(event)
+	}
+	m.forks = analyzeForks(infos)
+	for _, event := range forkEvents(m.forks, infos, m.forkIssues) {
+		m.events.add(event)
+	}
+	m.slots = analyzeSlots(infos)
+	for _, event := range slashingEvents(infos, m.slashings) {
+		m.events.add(event)
+	}
+	for _, event := range missingBlobEvents(infos, m.missingBlobs) {
+		m.events.add(event)
+	}
+	for _, event := range lateArrivalEvents(m.consensusInfos, m.lateArrivals, m.refreshInterval) {
+		m.events.add(event)
+	}
+	for _, event := range privateAddressEvents(m.consensusInfos, m.privateAddresses) {
+		m.events.add(event)
+	}
+	for _, event := range engineEvents(m.executionInfos, m.engineIssues) {
+		m.events.add(event)
+	}
+	for _, event := range blockGapEvents(m.executionInfos, m.blockGaps) {
+		m.events.add(event)
+	}
+}
+
+// updateLocked builds an update from the current state. The info slices are
+// shared, so they must be replaced rather than modified afterwards. Caller
+// must hold m.mu.
+func (m *Monitor) updateLocked() NodeUpdate {
+	return NodeUpdate{
+		ConsensusInfos: m.consensusInfos,
+		ExecutionInfos: m.executionInfos,
+		ValidatorInfos: m.validatorInfos,
+		Divergence:     m.divergence,
+		Forks:          m.forks,
+		Specs:          m.specs,
+		Checkpoints:    m.checkpoints,
+		Slots:          m.slots,
+		Duties:         m.duties,
+		Balances:       m.balances,
+		Effectiveness:  m.effectiveness,
+		SyncCommittees: m.syncCommittees,
+		Events:         m.events.list(),
+	}
+}
+
+// validatorSourceLocked returns the consensus client to look up our own
+// validators on, preferring one that was connected at the last poll. Caller
+// must hold m.mu.
+func (m *Monitor) validatorSourceLocked() consensus.ValidatorSource {
+	var fallback consensus.ValidatorSource
+	for i, client := range m.consensusClients {
+		source, ok := client.(consensus.ValidatorSource)
+		if !ok || !source.HasValidators() {
+			continue
+		}
+		if i < len(m.consensusInfos) && m.consensusInfos[i] != nil && m.consensusInfos[i].IsConnected {
+			return source
+		}
+		if fallback == nil {
+			fallback = source
+		}
+	}
+	return fallback
+}
+
+// streamingLocked returns true if the consensus client at idx has a connected
+// event stream and was polled recently enough. Caller must hold m.mu.
+func (m *Monitor) streamingLocked(idx int) bool {
+	if idx >= len(m.consensusInfos) || idx >= len(m.consensusLastPoll) {
+		return false
+	}
+	info := m.consensusInfos[idx]
+	if info == nil || !info.EventStream {
+		return false
+	}
+	return time.Since(m.consensusLastPoll[idx]) < streamingPollInterval
+}
+
+// startEventStreams subscribes to the event stream of every consensus client
+// that supports and has enabled it, and to new heads of every execution
+// client with a ws:// endpoint
+func (m *Monitor) startEventStreams(ctx context.Context) {
+	m.mu.RLock()
+	consensusClients := make([]consensus.Client, len(m.consensusClients))
+	copy(consensusClients, m.consensusClients)
+	executionClients := make([]execution.Client, len(m.executionClients))
+	copy(executionClients, m.executionClients)
+	m.mu.RUnlock()
+
+	for i, client := range consensusClients {
+		streamer, ok := client.(consensus.EventStreamer)
+		if !ok || !streamer.StreamsEvents() {
+			continue
+		}
+
+		updates := make(chan *consensus.ConsensusNodeInfo, 1)
+		go streamer.StreamEvents(ctx, updates)
+		go m.consumeEventUpdates(ctx, i, updates)
+	}
+
+	for i, client := range executionClients {
+		streamer, ok := client.(execution.HeadStreamer)
+		if !ok || !streamer.StreamsHeads() {
+			continue
+		}
+
+		updates := make(chan *execution.ExecutionNodeInfo, 1)
+		go streamer.StreamHeads(ctx, updates)
+		go m.consumeHeadUpdates(ctx, i, updates)
+	}
+}
+
+// consumeEventUpdates applies node info pushed by an event stream and
+// publishes it without waiting for the next poll
+func (m *Monitor) consumeEventUpdates(ctx context.Context, idx int, updates <-chan *consensus.ConsensusNodeInfo) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case info := <-updates:
+			m.mu.Lock()
+			if idx >= len(m.consensusInfos) {
+				m.mu.Unlock()
+				continue
+			}
+			// Replace rather than modify the slice, as it may be shared with
+			// an update that has already been published
+			consensusInfos := make([]*consensus.ConsensusNodeInfo, len(m.consensusInfos))
+			copy(consensusInfos, m.consensusInfos)
+			consensusInfos[idx] = info
+			m.consensusInfos = consensusInfos
+			m.analyzeLocked()
+			update := m.updateLocked()
+			m.mu.Unlock()
+
+			m.publish(update)
+		}
+	}
+}
+
+// consumeHeadUpdates applies node info pushed by a new heads subscription and
+// publishes it without waiting for the next poll
+func (m *Monitor) consumeHeadUpdates(ctx context.Context, idx int, updates <-chan *execution.ExecutionNodeInfo) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case info := <-updates:
+			m.mu.Lock()
+			if idx >= len(m.executionInfos) {
+				m.mu.Unlock()
+				continue
+			}
+			executionInfos := make([]*execution.ExecutionNodeInfo, len(m.executionInfos))
+			copy(executionInfos, m.executionInfos)
+			executionInfos[idx] = info
+			m.executionInfos = executionInfos
+			update := m.updateLocked()
+			m.mu.Unlock()
+
+			m.publish(update)
+		}
+	}
+}
+
+// publish sends an update without blocking, replacing any update that has
+// not been consumed yet so that readers always see the latest state
+func (m *Monitor) publish(update NodeUpdate) {
+	select {
+	case m.updateChan <- update:
+		return
+	default:
+	}
+
+	select {
+	case <-m.updateChan:
+	default:
 	}
 
 	select {
@@ -226,6 +554,16 @@ func (m *Monitor) GetNodeInfos() NodeUpdate {
 		ConsensusInfos: consensusInfos,
 		ExecutionInfos: executionInfos,
 		ValidatorInfos: validatorInfos,
+		Divergence:     m.divergence,
+		Forks:          m.forks,
+		Specs:          m.specs,
+		Checkpoints:    m.checkpoints,
+		Slots:          m.slots,
+		Duties:         m.duties,
+		Balances:       m.balances,
+		Effectiveness:  m.effectiveness,
+		SyncCommittees: m.syncCommittees,
+		Events:         m.events.list(),
 	}
 }
 
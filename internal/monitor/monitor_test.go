@@ -15,6 +15,7 @@ package monitor
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -475,3 +476,181 @@ func TestMonitor_UpdateChannelNonBlocking(t *testing.T) {
 		t.Fatal("updateAll blocked when channel was full")
 	}
 }
+
+type mockStreamingConsensusClient struct {
+	mockConsensusClient
+	events []*consensus.ConsensusNodeInfo
+}
+
+func (m *mockStreamingConsensusClient) StreamsEvents() bool {
+	return true
+}
+
+func (m *mockStreamingConsensusClient) StreamEvents(ctx context.Context, updates chan<- *consensus.ConsensusNodeInfo) {
+	for _, info := range m.events {
+		select {
+		case updates <- info:
+		case <-ctx.Done():
+			return
+		}
+	}
+	<-ctx.Done()
+}
+
+func TestMonitor_EventStream(t *testing.T) {
+	monitor := NewMonitor(time.Hour)
+
+	monitor.AddConsensusClient(&mockStreamingConsensusClient{
+		mockConsensusClient: mockConsensusClient{
+			name: "lighthouse",
+			nodeInfo: &consensus.ConsensusNodeInfo{
+				Name:        "lighthouse",
+				IsConnected: true,
+				HeadSlot:    100,
+			},
+		},
+		events: []*consensus.ConsensusNodeInfo{
+			{Name: "lighthouse", IsConnected: true, HeadSlot: 101, EventStream: true},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Start(ctx)
+
+	// The streamed head should arrive without waiting for the next poll
+	assert.Eventually(t, func() bool {
+		infos := monitor.GetConsensusInfos()
+		return len(infos) == 1 && infos[0] != nil && infos[0].HeadSlot == 101
+	}, time.Second, 10*time.Millisecond)
+
+	// A connected stream suppresses polling until the streaming poll interval
+	monitor.updateAll(ctx)
+	infos := monitor.GetConsensusInfos()
+	assert.Equal(t, uint64(101), infos[0].HeadSlot)
+}
+
+type mockStreamingExecutionClient struct {
+	mockExecutionClient
+	heads []*execution.ExecutionNodeInfo
+}
+
+func (m *mockStreamingExecutionClient) StreamsHeads() bool {
+	return true
+}
+
+func (m *mockStreamingExecutionClient) StreamHeads(ctx context.Context, updates chan<- *execution.ExecutionNodeInfo) {
+	for _, info := range m.heads {
+		select {
+		case updates <- info:
+		case <-ctx.Done():
+			return
+		}
+	}
+	<-ctx.Done()
+}
+
+func TestMonitor_HeadStream(t *testing.T) {
+	monitor := NewMonitor(time.Hour)
+
+	monitor.AddExecutionClient(&mockStreamingExecutionClient{
+		mockExecutionClient: mockExecutionClient{
+			name:     "geth",
+			nodeInfo: &execution.ExecutionNodeInfo{Name: "geth", IsConnected: true, CurrentBlock: 100},
+		},
+		heads: []*execution.ExecutionNodeInfo{
+			{Name: "geth", IsConnected: true, CurrentBlock: 101, HeadStream: true},
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go monitor.Start(ctx)
+
+	// The pushed head should arrive without waiting for the next poll
+	assert.Eventually(t, func() bool {
+		infos := monitor.GetExecutionInfos()
+		return len(infos) == 1 && infos[0] != nil && infos[0].CurrentBlock == 101
+	}, time.Second, 10*time.Millisecond)
+}
+
+type mockDutyConsensusClient struct {
+	mockConsensusClient
+	duties        *consensus.ValidatorDuties
+	balances      *consensus.ValidatorBalances
+	effectiveness *consensus.AttestationEffectiveness
+	dutyErr       error
+	calls         int
+}
+
+func (m *mockDutyConsensusClient) HasValidators() bool {
+	return true
+}
+
+func (m *mockDutyConsensusClient) GetDuties(ctx context.Context) (*consensus.ValidatorDuties, error) {
+	m.calls++
+	return m.duties, m.dutyErr
+}
+
+func (m *mockDutyConsensusClient) GetValidatorBalances(ctx context.Context) (*consensus.ValidatorBalances, error) {
+	return m.balances, m.dutyErr
+}
+
+func (m *mockDutyConsensusClient) GetAttestationEffectiveness(ctx context.Context) (*consensus.AttestationEffectiveness, error) {
+	return m.effectiveness, m.dutyErr
+}
+
+func TestMonitor_ValidatorDuties(t *testing.T) {
+	monitor := NewMonitor(time.Hour)
+
+	offline := &mockDutyConsensusClient{
+		mockConsensusClient: mockConsensusClient{name: "offline", err: errors.New("connection refused")},
+	}
+	online := &mockDutyConsensusClient{
+		mockConsensusClient: mockConsensusClient{
+			name:     "online",
+			nodeInfo: &consensus.ConsensusNodeInfo{Name: "online", IsConnected: true},
+		},
+		duties: &consensus.ValidatorDuties{
+			CurrentSlot: 100,
+			Proposals:   []consensus.ProposerDuty{{Slot: 110, ValidatorIndex: 7}},
+		},
+		balances: &consensus.ValidatorBalances{
+			Validators: []consensus.ValidatorBalance{{Index: 7, Status: "active_ongoing", Balance: 32_000_000_000}},
+		},
+		effectiveness: &consensus.AttestationEffectiveness{
+			Epoch:       1,
+			Validators:  []consensus.AttestationPerformance{{ValidatorIndex: 7, Head: true, Target: true, Source: true}},
+			Reward:      9_000,
+			IdealReward: 10_000,
+		},
+	}
+	monitor.AddConsensusClient(offline)
+	monitor.AddConsensusClient(online)
+
+	// Before the first poll the first capable client is used
+	monitor.updateAll(context.Background())
+	assert.Equal(t, 1, offline.calls)
+	assert.Nil(t, monitor.GetNodeInfos().Duties)
+
+	// Afterwards a connected one is preferred
+	monitor.updateAll(context.Background())
+	assert.Equal(t, 1, offline.calls)
+	assert.Equal(t, 1, online.calls)
+	update := monitor.GetNodeInfos()
+	duties, balances, effectiveness := update.Duties, update.Balances, update.Effectiveness
+	assert.NotNil(t, duties)
+	assert.Equal(t, uint64(110), duties.NextProposal().Slot)
+	assert.NotNil(t, balances)
+	assert.Equal(t, uint64(32_000_000_000), balances.TotalBalance())
+	assert.NotNil(t, effectiveness)
+	assert.InDelta(t, 90.0, effectiveness.Effectiveness(), 0.001)
+
+	// A failed lookup keeps the last known state
+	online.duties, online.balances, online.effectiveness, online.dutyErr = nil, nil, nil, errors.New("timeout")
+	monitor.updateAll(context.Background())
+	update = monitor.GetNodeInfos()
+	assert.Equal(t, duties, update.Duties)
+	assert.Equal(t, balances, update.Balances)
+	assert.Equal(t, effectiveness, update.Effectiveness)
+}
@@ -0,0 +1,152 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// Number of slashed validators listed in an event
+const maxSlashingValidators = 8
+
+// slashingEvents returns a critical event for each slashing that appears in
+// the pool of any node, reported once however many nodes have it. Slashings
+// are tracked in seen per node, as node/slashing, until they leave that node's
+// pool, i.e. they are included in a block or dropped. A node whose slashing
+// pool could not be fetched keeps the slashings it had.
+func slashingEvents(infos []*consensus.ConsensusNodeInfo, seen map[string]bool) []Event {
+	reported := make(map[string]bool)
+	for key := range seen {
+		_, slashing := splitSlashingKey(key)
+		reported[slashing] = true
+	}
+
+	var events []Event
+	current := make(map[string]bool)
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		known := func(kind string) bool {
+			return info.IsConnected && info.Pool != nil && info.Pool.Known(slashingPools[kind])
+		}
+		for key := range seen {
+			node, slashing := splitSlashingKey(key)
+			if node == info.Name && !known(strings.SplitN(slashing, ":", 2)[0]) {
+				current[key] = true
+			}
+		}
+		if !info.IsConnected || info.Pool == nil {
+			continue
+		}
+
+		for _, slashing := range info.Pool.Slashings {
+			key := slashing.Key()
+			current[info.Name+"/"+key] = true
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: EventCritical,
+				Message:  fmt.Sprintf("%s slashing in the pool for %s", strings.ToUpper(slashing.Kind[:1])+slashing.Kind[1:], slashedList(slashing.Validators)),
+			})
+		}
+	}
+
+	for key := range seen {
+		delete(seen, key)
+	}
+	for key := range current {
+		seen[key] = true
+	}
+	return events
+}
+
+// slashingPools are the pools that hold each kind of slashing
+var slashingPools = map[string]string{
+	consensus.SlashingAttester: consensus.PoolAttesterSlashings,
+	consensus.SlashingProposer: consensus.PoolProposerSlashings,
+}
+
+// splitSlashingKey splits a key of seen slashings into the node and the
+// slashing. Node names may contain a slash, slashing keys do not.
+func splitSlashingKey(key string) (string, string) {
+	i := strings.LastIndex(key, "/")
+	return key[:i], key[i+1:]
+}
+
+// slashedList lists the first few slashed validators
+func slashedList(validators []uint64) string {
+	if len(validators) == 0 {
+		return "no validators"
+	}
+	shown := validators
+	if len(shown) > maxSlashingValidators {
+		shown = shown[:maxSlashingValidators]
+	}
+	parts := make([]string, len(shown))
+	for i, index := range shown {
+		parts[i] = strconv.FormatUint(index, 10)
+	}
+	text := "validator " + strings.Join(parts, ", ")
+	if len(validators) > 1 {
+		text = "validators " + strings.Join(parts, ", ")
+	}
+	if len(validators) > len(shown) {
+		text += fmt.Sprintf(" and %d more", len(validators)-len(shown))
+	}
+	return text
+}
+
+// missingBlobEvents returns a warning for each recent block that a node does
+// not serve all blobs for. Blocks are tracked in warned, per node, until they
+// drop out of the node's recent blocks.
+func missingBlobEvents(infos []*consensus.ConsensusNodeInfo, warned map[string]bool) []Event {
+	var events []Event
+	current := make(map[string]bool)
+	for _, info := range infos {
+		if info == nil || !info.IsConnected {
+			continue
+		}
+		for _, block := range info.Blobs {
+			if !block.Missing() {
+				continue
+			}
+			key := info.Name + "/" + block.Root
+			current[key] = true
+			if warned[key] {
+				continue
+			}
+			events = append(events, Event{
+				Node:     info.Name,
+				Severity: EventWarning,
+				Message:  fmt.Sprintf("Missing blobs for block at slot %d: %d of %d available", block.Slot, block.Available, block.Expected),
+			})
+		}
+	}
+
+	for key := range warned {
+		delete(warned, key)
+	}
+	for key := range current {
+		warned[key] = true
+	}
+	return events
+}
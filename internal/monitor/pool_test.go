@@ -0,0 +1,99 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+func nodeWithPool(name string, slashings ...consensus.Slashing) *consensus.ConsensusNodeInfo {
+	return &consensus.ConsensusNodeInfo{
+		Name:        name,
+		IsConnected: true,
+		Pool:        &consensus.OperationPool{Slashings: slashings},
+	}
+}
+
+func TestSlashingEvents(t *testing.T) {
+	seen := make(map[string]bool)
+	proposer := consensus.Slashing{Kind: consensus.SlashingProposer, Validators: []uint64{42}}
+	attester := consensus.Slashing{Kind: consensus.SlashingAttester, Validators: []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}
+
+	// Reported once, whichever nodes have it
+	events := slashingEvents([]*consensus.ConsensusNodeInfo{
+		nodeWithPool("a", proposer),
+		nodeWithPool("b", proposer),
+	}, seen)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventCritical, events[0].Severity)
+	assert.Equal(t, "a", events[0].Node)
+	assert.Equal(t, "Proposer slashing in the pool for validator 42", events[0].Message)
+
+	events = slashingEvents([]*consensus.ConsensusNodeInfo{
+		nodeWithPool("a", proposer),
+		nodeWithPool("b", proposer, attester),
+	}, seen)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Attester slashing in the pool for validators 1, 2, 3, 4, 5, 6, 7, 8 and 2 more", events[0].Message)
+
+	// Not reported again while a node that had it cannot be asked
+	unavailable := nodeWithPool("a")
+	unavailable.Pool.Failed = []string{consensus.PoolProposerSlashings}
+	disconnected := nodeWithPool("b")
+	disconnected.IsConnected = false
+	assert.Empty(t, slashingEvents([]*consensus.ConsensusNodeInfo{unavailable, disconnected}, seen))
+	assert.Empty(t, slashingEvents([]*consensus.ConsensusNodeInfo{
+		nodeWithPool("a", proposer),
+		nodeWithPool("b", proposer, attester),
+	}, seen))
+
+	// Nor while it stays in the pool of any node
+	assert.Empty(t, slashingEvents([]*consensus.ConsensusNodeInfo{nodeWithPool("a"), nodeWithPool("b", proposer)}, seen))
+	assert.Empty(t, slashingEvents([]*consensus.ConsensusNodeInfo{nodeWithPool("a", proposer), nodeWithPool("b", proposer)}, seen))
+
+	// Included in a block, so gone from the pools
+	assert.Empty(t, slashingEvents([]*consensus.ConsensusNodeInfo{nodeWithPool("a"), nodeWithPool("b")}, seen))
+	assert.Empty(t, seen)
+}
+
+func TestMissingBlobEvents(t *testing.T) {
+	warned := make(map[string]bool)
+	infos := []*consensus.ConsensusNodeInfo{
+		{
+			Name:        "a",
+			IsConnected: true,
+			Blobs: []consensus.BlockBlobs{
+				{Slot: 10, Root: "0x01", Expected: 3, Available: 3, Known: true},
+				{Slot: 11, Root: "0x02", Expected: 6, Available: 4, Known: true},
+				{Slot: 12, Root: "0x03", Expected: 2},
+			},
+		},
+	}
+
+	events := missingBlobEvents(infos, warned)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "Missing blobs for block at slot 11: 4 of 6 available", events[0].Message)
+
+	// Not reported again while the block is recent
+	assert.Empty(t, missingBlobEvents(infos, warned))
+
+	infos[0].Blobs = infos[0].Blobs[2:]
+	assert.Empty(t, missingBlobEvents(infos, warned))
+	assert.Empty(t, warned)
+}
@@ -0,0 +1,130 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"sort"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// Number of most recent epochs included in the slot report
+const slotReportEpochs = 3
+
+// NetworkSlot is the outcome of a slot across all connected nodes
+type NetworkSlot struct {
+	Slot      uint64
+	Status    consensus.SlotStatus
+	Proposer  *consensus.ProposerDuty
+	MissingOn []string // Nodes that report the slot as missed while others have a block
+}
+
+type EpochReport struct {
+	Epoch uint64
+	Slots []NetworkSlot
+}
+
+// SlotReport combines the slot history of all connected nodes, so that slots
+// missed by the network can be told apart from blocks a single node lacks
+type SlotReport struct {
+	Epochs      []EpochReport  // Oldest first
+	Missed      int            // Slots missed network-wide
+	Known       int            // Slots with a known outcome
+	LocalMisses map[string]int // Per node, slots missed only by that node
+}
+
+// MissedRate returns the network-wide missed slot rate as a percentage
+func (r *SlotReport) MissedRate() float64 {
+	if r == nil || r.Known == 0 {
+		return 0
+	}
+	return float64(r.Missed) / float64(r.Known) * 100
+}
+
+// analyzeSlots merges the slot history of all connected nodes. A slot is
+// proposed if any node has a block for it, and missed if every node that
+// checked it has none.
+func analyzeSlots(infos []*consensus.ConsensusNodeInfo) *SlotReport {
+	report := &SlotReport{LocalMisses: make(map[string]int)}
+
+	epochs := make(map[uint64][]NetworkSlot)
+	missedBy := make(map[uint64][]string)
+	for _, info := range infos {
+		if info == nil || !info.IsConnected {
+			continue
+		}
+		for _, epoch := range info.SlotHistory {
+			slots, ok := epochs[epoch.Epoch]
+			if !ok {
+				slots = make([]NetworkSlot, len(epoch.Slots))
+				for i, record := range epoch.Slots {
+					slots[i] = NetworkSlot{Slot: record.Slot, Status: consensus.SlotPending}
+				}
+				epochs[epoch.Epoch] = slots
+			}
+			for i, record := range epoch.Slots {
+				if i >= len(slots) {
+					break
+				}
+				slot := &slots[i]
+				if slot.Proposer == nil {
+					slot.Proposer = record.Proposer
+				}
+				switch record.Status {
+				case consensus.SlotProposed:
+					slot.Status = consensus.SlotProposed
+				case consensus.SlotMissed:
+					missedBy[record.Slot] = append(missedBy[record.Slot], info.Name)
+				}
+			}
+		}
+	}
+
+	numbers := make([]uint64, 0, len(epochs))
+	for epoch := range epochs {
+		numbers = append(numbers, epoch)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	if len(numbers) > slotReportEpochs {
+		numbers = numbers[len(numbers)-slotReportEpochs:]
+	}
+
+	for _, epoch := range numbers {
+		slots := epochs[epoch]
+		for i := range slots {
+			slot := &slots[i]
+			nodes := missedBy[slot.Slot]
+			switch {
+			case slot.Status == consensus.SlotProposed:
+				slot.MissingOn = nodes
+				for _, node := range nodes {
+					report.LocalMisses[node]++
+				}
+			case len(nodes) > 0:
+				slot.Status = consensus.SlotMissed
+			}
+
+			switch slot.Status {
+			case consensus.SlotMissed:
+				report.Missed++
+				report.Known++
+			case consensus.SlotProposed:
+				report.Known++
+			}
+		}
+		report.Epochs = append(report.Epochs, EpochReport{Epoch: epoch, Slots: slots})
+	}
+
+	return report
+}
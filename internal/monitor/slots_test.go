@@ -0,0 +1,78 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// nodeWithSlots returns a node whose single tracked epoch has the given slot
+// statuses, starting at slot epoch*len(statuses)
+func nodeWithSlots(name string, epoch uint64, statuses ...consensus.SlotStatus) *consensus.ConsensusNodeInfo {
+	slots := make([]consensus.SlotRecord, len(statuses))
+	for i, status := range statuses {
+		slots[i] = consensus.SlotRecord{Slot: epoch*uint64(len(statuses)) + uint64(i), Status: status}
+	}
+	return &consensus.ConsensusNodeInfo{
+		Name:        name,
+		IsConnected: true,
+		SlotHistory: []consensus.EpochSlots{{Epoch: epoch, Slots: slots}},
+	}
+}
+
+func TestAnalyzeSlots(t *testing.T) {
+	const (
+		pending  = consensus.SlotPending
+		proposed = consensus.SlotProposed
+		missed   = consensus.SlotMissed
+	)
+
+	report := analyzeSlots([]*consensus.ConsensusNodeInfo{
+		nodeWithSlots("a", 5, proposed, missed, proposed, pending),
+		nodeWithSlots("b", 5, proposed, missed, missed, pending),
+		{Name: "offline"},
+		nil,
+	})
+
+	require.Len(t, report.Epochs, 1)
+	slots := report.Epochs[0].Slots
+	assert.Equal(t, uint64(20), slots[0].Slot)
+	assert.Equal(t, proposed, slots[0].Status)
+	assert.Equal(t, missed, slots[1].Status, "missed by every node")
+	assert.Equal(t, proposed, slots[2].Status, "proposed if any node has the block")
+	assert.Equal(t, []string{"b"}, slots[2].MissingOn)
+	assert.Equal(t, pending, slots[3].Status)
+
+	assert.Equal(t, 1, report.Missed)
+	assert.Equal(t, 3, report.Known)
+	assert.InDelta(t, 33.3, report.MissedRate(), 0.1)
+	assert.Equal(t, map[string]int{"b": 1}, report.LocalMisses)
+}
+
+func TestAnalyzeSlotsKeepsRecentEpochs(t *testing.T) {
+	var infos []*consensus.ConsensusNodeInfo
+	for epoch := uint64(1); epoch <= slotReportEpochs+2; epoch++ {
+		infos = append(infos, nodeWithSlots("node", epoch, consensus.SlotProposed))
+	}
+
+	report := analyzeSlots(infos)
+	require.Len(t, report.Epochs, slotReportEpochs)
+	assert.Equal(t, uint64(3), report.Epochs[0].Epoch)
+	assert.Equal(t, slotReportEpochs, report.Known)
+	assert.Zero(t, (&SlotReport{}).MissedRate())
+}
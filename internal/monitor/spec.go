@@ -0,0 +1,231 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// SpecDifference is a config value of a node that differs from the value on
+// the other nodes
+type SpecDifference struct {
+	Key      string
+	Value    string
+	Expected string // Value on the majority of nodes
+}
+
+// SpecReport holds the result of comparing the genesis and spec of consensus
+// nodes
+type SpecReport struct {
+	OtherNetwork map[string][]SpecDifference // Per node on another network, the identity values that differ
+	Differences  map[string][]SpecDifference // Per node on the same network, the spec values that differ
+}
+
+// IsOtherNetwork returns true if the node is on a different network to the
+// other nodes
+func (r *SpecReport) IsOtherNetwork(node string) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.OtherNetwork[node]
+	return ok
+}
+
+// Issue returns how the node's config differs from the other nodes, or an
+// empty string if it agrees
+func (r *SpecReport) Issue(node string) string {
+	if r == nil {
+		return ""
+	}
+	if differences, ok := r.OtherNetwork[node]; ok {
+		return "On a different network: " + specKeys(differences) + " differ"
+	}
+	if differences, ok := r.Differences[node]; ok {
+		return "Spec differs from the other nodes: " + specKeys(differences)
+	}
+	return ""
+}
+
+// sameNetwork returns the infos of the nodes that are not on another network,
+// so they are not compared as if they were peers
+func (r *SpecReport) sameNetwork(infos []*consensus.ConsensusNodeInfo) []*consensus.ConsensusNodeInfo {
+	if r == nil || len(r.OtherNetwork) == 0 {
+		return infos
+	}
+	filtered := make([]*consensus.ConsensusNodeInfo, 0, len(infos))
+	for _, info := range infos {
+		if info != nil && r.IsOtherNetwork(info.Name) {
+			continue
+		}
+		filtered = append(filtered, info)
+	}
+	return filtered
+}
+
+// specKeys lists the keys of the differences
+func specKeys(differences []SpecDifference) string {
+	keys := make([]string, len(differences))
+	for i, difference := range differences {
+		keys[i] = difference.Key
+	}
+	return strings.Join(keys, ", ")
+}
+
+// AnalyzeSpecs compares the genesis and spec of the nodes. Nodes that differ
+// from the majority in anything that identifies the network are on another
+// network. The rest are compared key by key, on the keys that both sides
+// report, as implementations do not all serve the same set of keys. On a tie
+// the earliest configured node is taken as the reference.
+func AnalyzeSpecs(infos []*consensus.ConsensusNodeInfo) *SpecReport {
+	report := &SpecReport{
+		OtherNetwork: make(map[string][]SpecDifference),
+		Differences:  make(map[string][]SpecDifference),
+	}
+
+	var nodes []*consensus.ConsensusNodeInfo
+	for _, info := range infos {
+		if info != nil && info.Network != nil {
+			nodes = append(nodes, info)
+		}
+	}
+	if len(nodes) < 2 {
+		return report
+	}
+
+	identities := make(map[string]map[string]string, len(nodes))
+	for _, info := range nodes {
+		identities[info.Name] = info.Network.Identity()
+	}
+	expected := majorityValues(nodes, func(info *consensus.ConsensusNodeInfo) map[string]string {
+		return identities[info.Name]
+	})
+
+	var sameNetwork []*consensus.ConsensusNodeInfo
+	for _, info := range nodes {
+		var differences []SpecDifference
+		for _, key := range consensus.NetworkIdentityKeys {
+			if value := identities[info.Name][key]; value != expected[key] {
+				differences = append(differences, SpecDifference{Key: key, Value: value, Expected: expected[key]})
+			}
+		}
+		if len(differences) > 0 {
+			report.OtherNetwork[info.Name] = differences
+			continue
+		}
+		sameNetwork = append(sameNetwork, info)
+	}
+
+	spec := func(info *consensus.ConsensusNodeInfo) map[string]string {
+		return info.Network.Spec
+	}
+	expected = majorityValues(sameNetwork, spec)
+	for _, info := range sameNetwork {
+		var differences []SpecDifference
+		for key, value := range info.Network.Spec {
+			want, ok := expected[key]
+			if !ok || strings.EqualFold(value, want) {
+				continue
+			}
+			differences = append(differences, SpecDifference{Key: key, Value: value, Expected: want})
+		}
+		if len(differences) > 0 {
+			sort.Slice(differences, func(i, j int) bool { return differences[i].Key < differences[j].Key })
+			report.Differences[info.Name] = differences
+		}
+	}
+	return report
+}
+
+// majorityValues returns, for each key reported by more than one node, the
+// value held by most of the nodes that report it. Values are compared case
+// insensitively.
+func majorityValues(nodes []*consensus.ConsensusNodeInfo, values func(*consensus.ConsensusNodeInfo) map[string]string) map[string]string {
+	byKey := make(map[string]map[string][]string)
+	first := make(map[string]string)
+	for _, info := range nodes {
+		for key, value := range values(info) {
+			value = strings.ToLower(value)
+			if byKey[key] == nil {
+				byKey[key] = make(map[string][]string)
+				first[key] = value
+			}
+			byKey[key][value] = append(byKey[key][value], info.Name)
+		}
+	}
+
+	majority := make(map[string]string, len(byKey))
+	for key, groups := range byKey {
+		count := 0
+		for _, names := range groups {
+			count += len(names)
+		}
+		if count < 2 {
+			continue
+		}
+		value := majorityRoot(groups)
+		if value == "" {
+			value = first[key]
+		}
+		majority[key] = value
+	}
+	return majority
+}
+
+// specEvents compares the current report with the issues that were active
+// before and returns events for nodes whose config started or stopped
+// differing, ordered by node. The active set is updated in place.
+func specEvents(report *SpecReport, active map[string]string) []Event {
+	current := make(map[string]string)
+	for node := range report.OtherNetwork {
+		current[node] = report.Issue(node)
+	}
+	for node := range report.Differences {
+		current[node] = report.Issue(node)
+	}
+
+	var events []Event
+	for node, issue := range current {
+		if active[node] == issue {
+			continue
+		}
+		severity := EventWarning
+		if report.IsOtherNetwork(node) {
+			severity = EventCritical
+		}
+		events = append(events, Event{Node: node, Severity: severity, Message: issue})
+	}
+	for node := range active {
+		if _, ok := current[node]; !ok {
+			events = append(events, Event{Node: node, Severity: EventInfo, Message: "Network config agrees with the other nodes"})
+		}
+	}
+
+	for node := range active {
+		delete(active, node)
+	}
+	for node, issue := range current {
+		active[node] = issue
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Node < events[j].Node })
+	return events
+}
+
+// formatSpecDifference describes a differing value for display
+func formatSpecDifference(difference SpecDifference) string {
+	return fmt.Sprintf("%s = %s, others have %s", difference.Key, difference.Value, difference.Expected)
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+const (
+	mainnetRoot = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	holeskyRoot = "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1"
+)
+
+func nodeWithNetwork(name, root string, spec map[string]string) *consensus.ConsensusNodeInfo {
+	return &consensus.ConsensusNodeInfo{
+		Name:        name,
+		IsConnected: true,
+		Network: &consensus.NetworkConfig{
+			GenesisTime:           time.Unix(1606824023, 0),
+			GenesisValidatorsRoot: root,
+			GenesisForkVersion:    "0x00000000",
+			DepositContract:       "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+			DepositChainID:        "1",
+			Spec:                  spec,
+		},
+	}
+}
+
+func TestAnalyzeSpecs(t *testing.T) {
+	spec := map[string]string{"SECONDS_PER_SLOT": "12", "SLOTS_PER_EPOCH": "32"}
+
+	tests := []struct {
+		name         string
+		infos        []*consensus.ConsensusNodeInfo
+		otherNetwork map[string][]SpecDifference
+		differences  map[string][]SpecDifference
+	}{
+		{
+			name: "all agree",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithNetwork("a", mainnetRoot, spec),
+				nodeWithNetwork("b", "0x4B363DB94E286120D76EB905340FDD4E54BFE9F06BF33FF6CF5AD27F511BFE95", spec),
+			},
+			otherNetwork: map[string][]SpecDifference{},
+			differences:  map[string][]SpecDifference{},
+		},
+		{
+			name: "other network",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithNetwork("a", mainnetRoot, spec),
+				nodeWithNetwork("b", mainnetRoot, spec),
+				nodeWithNetwork("c", holeskyRoot, spec),
+			},
+			otherNetwork: map[string][]SpecDifference{
+				"c": {{Key: "GENESIS_VALIDATORS_ROOT", Value: holeskyRoot, Expected: mainnetRoot}},
+			},
+			differences: map[string][]SpecDifference{},
+		},
+		{
+			name: "tie takes the first node as reference",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithNetwork("a", mainnetRoot, spec),
+				nodeWithNetwork("b", holeskyRoot, spec),
+			},
+			otherNetwork: map[string][]SpecDifference{
+				"b": {{Key: "GENESIS_VALIDATORS_ROOT", Value: holeskyRoot, Expected: mainnetRoot}},
+			},
+			differences: map[string][]SpecDifference{},
+		},
+		{
+			name: "spec differs",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithNetwork("a", mainnetRoot, spec),
+				nodeWithNetwork("b", mainnetRoot, spec),
+				nodeWithNetwork("c", mainnetRoot, map[string]string{"SECONDS_PER_SLOT": "6", "SLOTS_PER_EPOCH": "32", "ONLY_ON_C": "1"}),
+			},
+			otherNetwork: map[string][]SpecDifference{},
+			differences: map[string][]SpecDifference{
+				"c": {{Key: "SECONDS_PER_SLOT", Value: "6", Expected: "12"}},
+			},
+		},
+		{
+			name: "unknown network is skipped",
+			infos: []*consensus.ConsensusNodeInfo{
+				nodeWithNetwork("a", mainnetRoot, spec),
+				{Name: "b"},
+			},
+			otherNetwork: map[string][]SpecDifference{},
+			differences:  map[string][]SpecDifference{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := AnalyzeSpecs(tt.infos)
+			assert.Equal(t, tt.otherNetwork, report.OtherNetwork)
+			assert.Equal(t, tt.differences, report.Differences)
+		})
+	}
+}
+
+func TestSpecReport_SameNetwork(t *testing.T) {
+	infos := []*consensus.ConsensusNodeInfo{
+		nodeWithNetwork("a", mainnetRoot, nil),
+		nodeWithNetwork("b", mainnetRoot, nil),
+		nodeWithNetwork("c", holeskyRoot, nil),
+	}
+
+	report := AnalyzeSpecs(infos)
+	assert.True(t, report.IsOtherNetwork("c"))
+	assert.Equal(t, "On a different network: GENESIS_VALIDATORS_ROOT differ", report.Issue("c"))
+
+	filtered := report.sameNetwork(infos)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "a", filtered[0].Name)
+	assert.Equal(t, "b", filtered[1].Name)
+
+	var nilReport *SpecReport
+	assert.False(t, nilReport.IsOtherNetwork("c"))
+	assert.Len(t, nilReport.sameNetwork(infos), 3)
+}
+
+func TestSpecEvents(t *testing.T) {
+	active := make(map[string]string)
+
+	report := &SpecReport{
+		OtherNetwork: map[string][]SpecDifference{"c": {{Key: "DEPOSIT_CHAIN_ID", Value: "17000", Expected: "1"}}},
+		Differences:  map[string][]SpecDifference{"b": {{Key: "SECONDS_PER_SLOT", Value: "6", Expected: "12"}}},
+	}
+	events := specEvents(report, active)
+	require.Len(t, events, 2)
+	assert.Equal(t, "b", events[0].Node)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "c", events[1].Node)
+	assert.Equal(t, EventCritical, events[1].Severity)
+
+	// Ongoing issues are not reported again
+	assert.Empty(t, specEvents(report, active))
+
+	events = specEvents(&SpecReport{}, active)
+	require.Len(t, events, 2)
+	assert.Equal(t, "b", events[0].Node)
+	assert.Equal(t, "c", events[1].Node)
+	assert.Equal(t, EventInfo, events[0].Severity)
+	assert.Empty(t, active)
+}
@@ -0,0 +1,82 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+// Node name used for events about our own validators
+const validatorsEventNode = "validators"
+
+// syncCommitteeEvents returns events for our validators joining the current
+// or next sync committee, and for sync aggregates they are missing from that
+// were not in the previous status
+func syncCommitteeEvents(previous, current *consensus.SyncCommitteeStatus) []Event {
+	if current == nil {
+		return nil
+	}
+
+	var events []Event
+	if current.Next != nil && (previous == nil || previous.Next == nil || previous.Next.Period != current.Next.Period) {
+		events = append(events, Event{
+			Node:     validatorsEventNode,
+			Severity: EventInfo,
+			Message: fmt.Sprintf("%d validators in next sync committee from epoch %d: %s",
+				len(current.Next.Members), current.Next.StartEpoch, syncMemberList(current.Next.Members)),
+		})
+	}
+	if current.Current != nil && (previous == nil || previous.Current == nil || previous.Current.Period != current.Current.Period) {
+		events = append(events, Event{
+			Node:     validatorsEventNode,
+			Severity: EventInfo,
+			Message: fmt.Sprintf("%d validators in sync committee until epoch %d: %s",
+				len(current.Current.Members), current.Current.EndEpoch, syncMemberList(current.Current.Members)),
+		})
+	}
+
+	seen := make(map[uint64]bool)
+	if previous != nil {
+		for _, slot := range previous.Participation {
+			seen[slot.Slot] = true
+		}
+	}
+	for _, slot := range current.Participation {
+		if seen[slot.Slot] || len(slot.Missed) == 0 {
+			continue
+		}
+		missed := make([]string, len(slot.Missed))
+		for i, index := range slot.Missed {
+			missed[i] = strconv.FormatUint(index, 10)
+		}
+		events = append(events, Event{
+			Node:     validatorsEventNode,
+			Severity: EventWarning,
+			Message:  fmt.Sprintf("Sync committee signature missing at slot %d from %s", slot.Slot, strings.Join(missed, ", ")),
+		})
+	}
+	return events
+}
+
+func syncMemberList(members []consensus.SyncCommitteeMember) string {
+	indices := make([]string, len(members))
+	for i, member := range members {
+		indices[i] = strconv.FormatUint(member.ValidatorIndex, 10)
+	}
+	return strings.Join(indices, ", ")
+}
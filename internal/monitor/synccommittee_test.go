@@ -0,0 +1,73 @@
+// Copyright © 2025 Attestant Limited.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/watcheth/watcheth/internal/consensus"
+)
+
+func TestSyncCommitteeEvents(t *testing.T) {
+	next := &consensus.SyncCommitteePeriod{
+		Period:     2,
+		StartEpoch: 512,
+		EndEpoch:   768,
+		Members:    []consensus.SyncCommitteeMember{{ValidatorIndex: 9, Positions: []int{4}}},
+	}
+	current := &consensus.SyncCommitteePeriod{
+		Period:     1,
+		StartEpoch: 256,
+		EndEpoch:   512,
+		Members:    []consensus.SyncCommitteeMember{{ValidatorIndex: 5, Positions: []int{1, 3}}, {ValidatorIndex: 7, Positions: []int{2}}},
+	}
+
+	// Nothing to report outside of sync committees
+	assert.Empty(t, syncCommitteeEvents(nil, &consensus.SyncCommitteeStatus{}))
+	assert.Empty(t, syncCommitteeEvents(nil, nil))
+
+	// Joining the next committee is reported once
+	status := &consensus.SyncCommitteeStatus{Next: next}
+	events := syncCommitteeEvents(nil, status)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventInfo, events[0].Severity)
+	assert.Equal(t, "1 validators in next sync committee from epoch 512: 9", events[0].Message)
+	assert.Empty(t, syncCommitteeEvents(status, &consensus.SyncCommitteeStatus{Next: next}))
+
+	// Missing signatures are reported for newly checked blocks only
+	previous := &consensus.SyncCommitteeStatus{
+		Current: current,
+		Participation: []consensus.SyncSlotParticipation{
+			{Slot: 10, Block: true, Participated: 2, Expected: 3, Missed: []uint64{7}},
+		},
+	}
+	status = &consensus.SyncCommitteeStatus{
+		Current: current,
+		Participation: []consensus.SyncSlotParticipation{
+			{Slot: 10, Block: true, Participated: 2, Expected: 3, Missed: []uint64{7}},
+			{Slot: 11},
+			{Slot: 12, Block: true, Expected: 3, Missed: []uint64{5, 7}},
+		},
+	}
+	events = syncCommitteeEvents(previous, status)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventWarning, events[0].Severity)
+	assert.Equal(t, "Sync committee signature missing at slot 12 from 5, 7", events[0].Message)
+
+	events = syncCommitteeEvents(nil, &consensus.SyncCommitteeStatus{Current: current})
+	require.Len(t, events, 1)
+	assert.Equal(t, "2 validators in sync committee until epoch 512: 5, 7", events[0].Message)
+}
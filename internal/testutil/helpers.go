@@ -21,6 +21,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/watcheth/watcheth/internal/common"
 )
 
 // HTTPTestServer creates a test HTTP server with custom handler
@@ -60,6 +63,25 @@ func MockHTTPEndpoints(endpoints map[string]struct {
 	}
 }
 
+// WebSocketTestServer creates a test server that upgrades each connection to
+// a WebSocket and passes it to handler, closing it once handler returns. Its
+// endpoint is returned with the ws:// scheme.
+func WebSocketTestServer(t *testing.T, handler func(conn *common.WebSocketConn)) (*httptest.Server, string) {
+	t.Helper()
+	var upgrader websocket.Upgrader
+	server := HTTPTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// The upgrader has answered with an error status
+			return
+		}
+		ws := common.NewWebSocketConn(conn)
+		defer func() { _ = ws.Close() }()
+		handler(ws)
+	})
+	return server, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
 // AssertContains checks if a string contains a substring
 func AssertContains(t *testing.T, str, substr string) {
 	t.Helper()
@@ -17,6 +17,10 @@ import (
 	"context"
 )
 
+// SourceMetrics is the Prometheus endpoint of a validator client, from which
+// all of its node info is scraped
+const SourceMetrics = "metrics"
+
 // Client interface for validator clients
 type Client interface {
 	GetNodeInfo(ctx context.Context) (*ValidatorNodeInfo, error)
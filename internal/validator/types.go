@@ -15,6 +15,8 @@ package validator
 
 import (
 	"time"
+
+	"github.com/watcheth/watcheth/internal/common"
 )
 
 type ValidatorNodeInfo struct {
@@ -23,6 +25,7 @@ type ValidatorNodeInfo struct {
 	IsConnected bool
 	LastError   error
 	LastUpdate  time.Time
+	Sources     common.Sources // Outcome of each scrape, by source
 
 	// Essential metrics
 	Ready                      bool    // Service ready status
@@ -15,14 +15,11 @@ package vouch
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"time"
 
 	io_prometheus_client "github.com/prometheus/client_model/go"
-	"github.com/prometheus/common/expfmt"
 	"github.com/watcheth/watcheth/internal/common"
 	"github.com/watcheth/watcheth/internal/logger"
 	"github.com/watcheth/watcheth/internal/validator"
@@ -32,6 +29,7 @@ type VouchClient struct {
 	name       string
 	endpoint   string
 	httpClient *http.Client
+	sources    common.SourceTracker
 }
 
 func NewVouchClient(name, endpoint string) *VouchClient {
@@ -49,7 +47,10 @@ func (c *VouchClient) GetNodeInfo(ctx context.Context) (*validator.ValidatorNode
 		LastUpdate: time.Now(),
 	}
 
+	start := time.Now()
 	metrics, err := c.fetchMetrics(ctx)
+	c.sources.Observe(validator.SourceMetrics, start, err)
+	info.Sources = c.sources.Snapshot()
 	if err != nil {
 		info.IsConnected = false
 		info.LastError = err
@@ -65,41 +66,11 @@ func (c *VouchClient) GetNodeInfo(ctx context.Context) (*validator.ValidatorNode
 }
 
 func (c *VouchClient) fetchMetrics(ctx context.Context) (map[string]*io_prometheus_client.MetricFamily, error) {
-	// Don't append /metrics if it's already in the endpoint
-	url := c.endpoint
-	if !strings.HasSuffix(c.endpoint, "/metrics") {
-		url = fmt.Sprintf("%s/metrics", c.endpoint)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			logger.Debug("Failed to close response body: %v", err)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d from metrics endpoint", resp.StatusCode)
-	}
-
-	return c.parsePrometheusResponse(resp.Body)
+	return common.FetchMetrics(ctx, c.httpClient, c.endpoint)
 }
 
 func (c *VouchClient) parsePrometheusResponse(r io.Reader) (map[string]*io_prometheus_client.MetricFamily, error) {
-	parser := expfmt.TextParser{}
-	metricFamilies, err := parser.TextToMetricFamilies(r)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse metrics: %w", err)
-	}
-	return metricFamilies, nil
+	return common.ParseMetrics(r)
 }
 
 func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_client.MetricFamily, info *validator.ValidatorNodeInfo) {
@@ -112,7 +83,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 
 	// Attestation mark seconds (average from histogram)
 	if mf, ok := metricFamilies["vouch_attestation_mark_seconds"]; ok {
-		if sum, count := getHistogramSumAndCount(mf); count > 0 {
+		if sum, count := common.HistogramSumAndCount(mf); count > 0 {
 			info.AttestationMarkSeconds = sum / count
 		}
 	}
@@ -120,7 +91,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 	// Attestation success rate and counts
 	if mf, ok := metricFamilies["vouch_attestation_process_requests_total"]; ok {
 		for _, m := range mf.Metric {
-			result := getLabelValue(m.Label, "result")
+			result := common.LabelValue(m.Label, "result")
 			if m.Counter != nil && m.Counter.Value != nil {
 				switch result {
 				case "succeeded":
@@ -138,7 +109,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 
 	// Block proposal mark seconds
 	if mf, ok := metricFamilies["vouch_beaconblockproposal_mark_seconds"]; ok {
-		if sum, count := getHistogramSumAndCount(mf); count > 0 {
+		if sum, count := common.HistogramSumAndCount(mf); count > 0 {
 			info.BlockProposalMarkSeconds = sum / count
 		}
 	}
@@ -147,7 +118,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 	// Note: These are the same metrics as BeaconBlockProposalSucceeded/Failed
 	if mf, ok := metricFamilies["vouch_beaconblockproposal_process_requests_total"]; ok {
 		for _, m := range mf.Metric {
-			result := getLabelValue(m.Label, "result")
+			result := common.LabelValue(m.Label, "result")
 			if m.Counter != nil && m.Counter.Value != nil {
 				switch result {
 				case "succeeded":
@@ -165,7 +136,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 
 	// Beacon node response time (average from histogram, convert to milliseconds)
 	if mf, ok := metricFamilies["vouch_client_operation_duration_seconds"]; ok {
-		if sum, count := getHistogramSumAndCount(mf); count > 0 {
+		if sum, count := common.HistogramSumAndCount(mf); count > 0 {
 			info.BeaconNodeResponseTime = (sum / count) * 1000
 		}
 	}
@@ -180,7 +151,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 	// Blocks from relay
 	if mf, ok := metricFamilies["vouch_beaconblockproposal_process_blocks_total"]; ok {
 		for _, m := range mf.Metric {
-			method := getLabelValue(m.Label, "method")
+			method := common.LabelValue(m.Label, "method")
 			if method == "relay" && m.Counter != nil && m.Counter.Value != nil {
 				info.BlocksFromRelay = uint64(*m.Counter.Value)
 			}
@@ -189,7 +160,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 
 	// Relay auction duration and count (from histogram)
 	if mf, ok := metricFamilies["vouch_relay_auction_block_duration_seconds"]; ok {
-		if sum, count := getHistogramSumAndCount(mf); count > 0 {
+		if sum, count := common.HistogramSumAndCount(mf); count > 0 {
 			info.RelayAuctionDuration = sum / count
 			info.RelayAuctionCount = uint64(count)
 		}
@@ -198,7 +169,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 	// Relay validator registrations
 	if mf, ok := metricFamilies["vouch_relay_validator_registrations_total"]; ok {
 		for _, m := range mf.Metric {
-			result := getLabelValue(m.Label, "result")
+			result := common.LabelValue(m.Label, "result")
 			if m.Counter != nil && m.Counter.Value != nil {
 				switch result {
 				case "succeeded":
@@ -213,7 +184,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 	// Relay builder bid requests
 	if mf, ok := metricFamilies["vouch_relay_builder_bid_total"]; ok {
 		for _, m := range mf.Metric {
-			result := getLabelValue(m.Label, "result")
+			result := common.LabelValue(m.Label, "result")
 			if m.Counter != nil && m.Counter.Value != nil {
 				switch result {
 				case "succeeded":
@@ -228,7 +199,7 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 	// Relay execution config requests
 	if mf, ok := metricFamilies["vouch_relay_execution_config_total"]; ok {
 		for _, m := range mf.Metric {
-			result := getLabelValue(m.Label, "result")
+			result := common.LabelValue(m.Label, "result")
 			if m.Counter != nil && m.Counter.Value != nil {
 				switch result {
 				case "succeeded":
@@ -244,40 +215,10 @@ func (c *VouchClient) parseMetrics(metricFamilies map[string]*io_prometheus_clie
 	info.ValidatorStates = make(map[string]uint64)
 	if mf, ok := metricFamilies["vouch_accountmanager_accounts_total"]; ok {
 		for _, m := range mf.Metric {
-			state := getLabelValue(m.Label, "state")
+			state := common.LabelValue(m.Label, "state")
 			if state != "" && m.Gauge != nil && m.Gauge.Value != nil {
 				info.ValidatorStates[state] = uint64(*m.Gauge.Value)
 			}
 		}
 	}
 }
-
-// Helper functions
-
-func getLabelValue(labels []*io_prometheus_client.LabelPair, name string) string {
-	for _, label := range labels {
-		if label.Name != nil && *label.Name == name && label.Value != nil {
-			return *label.Value
-		}
-	}
-	return ""
-}
-
-func getHistogramSumAndCount(mf *io_prometheus_client.MetricFamily) (sum float64, count float64) {
-	if mf == nil || len(mf.Metric) == 0 {
-		return 0, 0
-	}
-
-	for _, m := range mf.Metric {
-		if m.Histogram != nil {
-			if m.Histogram.SampleSum != nil {
-				sum += *m.Histogram.SampleSum
-			}
-			if m.Histogram.SampleCount != nil {
-				count += float64(*m.Histogram.SampleCount)
-			}
-		}
-	}
-
-	return sum, count
-}